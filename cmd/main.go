@@ -3,18 +3,25 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"os"
 	"os/signal"
+	"sync"
 	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
 
 	"github.com/duynhne/order-service/config"
+	"github.com/duynhne/order-service/db/migrations"
 	database "github.com/duynhne/order-service/internal/core"
+	"github.com/duynhne/order-service/internal/core/cache"
+	"github.com/duynhne/order-service/internal/core/domain"
 	"github.com/duynhne/order-service/internal/core/repository"
 	logicv1 "github.com/duynhne/order-service/internal/logic/v1"
 	v1 "github.com/duynhne/order-service/internal/web/v1"
@@ -44,7 +51,7 @@ func main() {
 
 	initProfiling(cfg, logger)
 
-	pool, err := database.Connect(context.Background())
+	pool, err := database.Connect(context.Background(), database.WithLogger(logger))
 	if err != nil {
 		logger.Error("Failed to connect to database", zap.Error(err))
 		return
@@ -52,23 +59,94 @@ func main() {
 	defer pool.Close()
 	logger.Info("Database connection pool established")
 
-	orderRepo := repository.NewPostgresOrderRepository(pool)
+	if err := runMigrations(cfg, pool, logger); err != nil {
+		logger.Error("Failed to run database migrations", zap.Error(err))
+		return
+	}
+
+	orderRepo := repository.NewPostgresOrderRepository(pool,
+		repository.WithLogger(logger),
+		repository.WithVerifyOnRead(cfg.Order.VerifyOnRead),
+	)
 	txManager := repository.NewPostgresTransactionManager(pool)
-	orderService := logicv1.NewOrderService(orderRepo, txManager)
-	v1.SetOrderService(orderService)
+	shippingStrategy := cfg.Order.ResolvedShippingStrategy()
+	shippingCalculator, err := logicv1.NewShippingCalculator(shippingStrategy, logicv1.ShippingRates{
+		Rate:                  cfg.Order.FlatShippingRate,
+		PerUnitRate:           cfg.Order.PerUnitShippingRate,
+		FreeShippingThreshold: cfg.Order.FreeShippingThreshold,
+		ExpressSurcharge:      cfg.Order.ExpressShippingSurcharge,
+	})
+	if err != nil {
+		logger.Error("Invalid shipping configuration", zap.Error(err))
+		return
+	}
+	logger.Info("Shipping calculator configured", zap.String("strategy", shippingStrategy))
+	transitions, err := orderTransitions(cfg, logger)
+	if err != nil {
+		logger.Error("Invalid order transitions", zap.Error(err))
+		return
+	}
+	orderService := logicv1.NewOrderService(orderRepo, txManager,
+		logicv1.WithShippingCalculator(shippingCalculator),
+		logicv1.WithAllowZeroPrice(cfg.Order.AllowZeroPrice),
+		logicv1.WithMinOrderTotal(cfg.Order.MinTotal),
+		logicv1.WithMaxDistinctProducts(cfg.Order.MaxDistinctProducts),
+		logicv1.WithTaxRate(cfg.Order.TaxRate),
+		logicv1.WithRoundingMode(logicv1.RoundingMode(cfg.Order.RoundingMode)),
+		logicv1.WithMergeDuplicateItems(cfg.Order.MergeDuplicateItems),
+		logicv1.WithProductNameLimit(cfg.Order.MaxProductNameLength, cfg.Order.TruncateLongNames, logger),
+		logicv1.WithDeliveryLeadTime(cfg.Order.DeliveryBaseDays, cfg.Order.ExpressDeliveryAdjustDays),
+		logicv1.WithNotifier(initNotifier(cfg, logger), logger),
+		logicv1.WithPromotionEngine(promotionEngine(cfg)),
+		logicv1.WithPricePolicy(logicv1.PricePolicy(cfg.Order.PricePolicy), priceCatalog(cfg)),
+		logicv1.WithStockCheck(inventoryChecker(cfg, logger)),
+		logicv1.WithDraftOrders(cfg.Order.Drafts),
+		logicv1.WithTransitions(transitions),
+		logicv1.WithOrderCache(orderCache(cfg, logger)),
+	)
 
 	authClient := middleware.NewAuthClient(cfg.AuthServiceURL)
 	logger.Info("Auth client initialized", zap.String("auth_service_url", cfg.AuthServiceURL))
 
-	shippingClient := v1.NewShippingClient(cfg.ShippingServiceURL)
-	v1.SetShippingClient(shippingClient)
+	shippingClient, cartClient := initDownstreamClients(cfg, logger)
+	handlerCfg := v1.HandlerConfig{
+		DefaultPageSize:            cfg.Pagination.DefaultPageSize,
+		MaxPageSize:                cfg.Pagination.MaxPageSize,
+		RevealForbidden:            !cfg.Order.NotFoundOnForbidden,
+		GoneForPurged:              cfg.Order.GoneForPurged,
+		ResponseEnvelope:           cfg.ResponseEnvelope,
+		StrictJSON:                 cfg.StrictJSON,
+		JSONCase:                   cfg.JSONCase,
+		CartClearTimeout:           cfg.CartClearTimeout,
+		ShippingAggregationTimeout: cfg.ShippingAggregationTimeout,
+		DetailsTimeout:             cfg.Service.WriteTimeout,
+	}
+	var createQueue *logicv1.OrderQueue
+	if cfg.Order.AsyncCreate {
+		createQueue = logicv1.NewOrderQueue(orderService, cfg.Order.QueueSize, cfg.Order.QueueWorkers)
+	}
+	orderHandler := v1.NewOrderHandler(orderService, shippingClient, cartClient, createQueue, handlerCfg)
+
+	if cfg.InternalServiceToken == "" {
+		logger.Warn("INTERNAL_SERVICE_TOKEN not set; internal order routes will be rejected")
+	}
+	if cfg.PaymentWebhookSecret == "" {
+		logger.Warn("PAYMENT_WEBHOOK_SECRET not set; payment webhooks will be rejected")
+	}
+	webhookHandler := v1.NewPaymentWebhookHandler(orderService, cfg.PaymentWebhookSecret)
+	adminHandler := v1.NewAdminHandler(orderService, handlerCfg)
 
-	cartClient := v1.NewCartClient(cfg.CartServiceURL)
-	v1.SetCartClient(cartClient)
+	stopWorkers := startBackgroundWorkers(cfg, orderService, shippingClient, createQueue, logger)
 
 	var isShuttingDown atomic.Bool
-	srv := setupServer(cfg, logger, authClient, &isShuttingDown)
-	runGracefulShutdown(cfg, srv, tp, pool, logger, &isShuttingDown)
+	handlers := routeHandlers{
+		order:        orderHandler,
+		webhook:      webhookHandler,
+		admin:        adminHandler,
+		dependencies: v1.NewDependencyHealthHandler(dependencyProbes(pool, shippingClient, cartClient), dependencyProbeTimeout),
+	}
+	srv := setupServer(cfg, logger, authClient, handlers, &isShuttingDown)
+	runGracefulShutdown(cfg, srv, tp, pool, stopWorkers, logger, &isShuttingDown)
 }
 
 func initTracing(cfg *config.Config, logger *zap.Logger) interface{ Shutdown(context.Context) error } {
@@ -100,12 +178,276 @@ func initProfiling(cfg *config.Config, logger *zap.Logger) {
 	logger.Info("Profiling initialized", zap.String("endpoint", cfg.Profiling.Endpoint))
 }
 
-func setupServer(cfg *config.Config, logger *zap.Logger, authClient *middleware.AuthClient, isShuttingDown *atomic.Bool) *http.Server {
+// runMigrations applies embedded SQL migrations when RUN_MIGRATIONS=true, without seed data.
+// Progress is shared with the Flyway job through flyway_schema_history.
+func runMigrations(cfg *config.Config, pool *pgxpool.Pool, logger *zap.Logger) error {
+	if !cfg.RunMigrations {
+		logger.Info("Embedded migrations disabled (RUN_MIGRATIONS=false)")
+		return nil
+	}
+
+	all, err := database.LoadMigrations(migrations.FS, "sql")
+	if err != nil {
+		return err
+	}
+	applied, err := database.RunMigrations(context.Background(), pool, all)
+	if err != nil {
+		return err
+	}
+	logger.Info("Database migrations complete",
+		zap.Ints("applied_versions", applied),
+		zap.Int("known_versions", len(all)),
+	)
+	return nil
+}
+
+// initDownstreamClients creates the shipping and cart clients.
+// A client is left nil (feature disabled) when its base URL is not configured.
+func initDownstreamClients(cfg *config.Config, logger *zap.Logger) (*v1.ShippingClient, *v1.CartClient) {
+	for _, name := range cfg.MissingDependencies() {
+		logger.Warn("Downstream service URL not configured; dependent feature disabled",
+			zap.String("env", name),
+			zap.Bool("strict_dependencies", cfg.StrictDependencies),
+		)
+	}
+
+	var shippingClient *v1.ShippingClient
+	if cfg.ShippingServiceURL != "" {
+		shippingClient = v1.NewShippingClient(cfg.ShippingServiceURL, cfg.ShippingPathTemplate)
+	}
+	var cartClient *v1.CartClient
+	if cfg.CartServiceURL != "" {
+		cartClient = v1.NewCartClient(cfg.CartServiceURL)
+	}
+	return shippingClient, cartClient
+}
+
+// promotionEngine builds the automatic promotion rules from config; nil when none are configured
+func promotionEngine(cfg *config.Config) logicv1.PromotionEngine {
+	if cfg.Order.PromotionMinUnits == 0 || cfg.Order.PromotionPercentOff == 0 {
+		return nil
+	}
+	return logicv1.RulePromotionEngine{Rules: []logicv1.PromotionRule{
+		logicv1.QuantityPercentOff{
+			ID:          "bulk",
+			Description: fmt.Sprintf("Buy %d, get %g%% off", cfg.Order.PromotionMinUnits, cfg.Order.PromotionPercentOff*100),
+			MinUnits:    cfg.Order.PromotionMinUnits,
+			PercentOff:  cfg.Order.PromotionPercentOff,
+		},
+	}}
+}
+
+// orderTransitions reads the state machine from ORDER_TRANSITIONS or ORDER_TRANSITIONS_FILE;
+// nil (the built-in transitions) when neither is set
+func orderTransitions(cfg *config.Config, logger *zap.Logger) (logicv1.Transitions, error) {
+	data, source := []byte(cfg.Order.Transitions), "ORDER_TRANSITIONS"
+	if cfg.Order.TransitionsFile != "" {
+		var err error
+		if data, err = os.ReadFile(cfg.Order.TransitionsFile); err != nil {
+			return nil, fmt.Errorf("read ORDER_TRANSITIONS_FILE: %w", err)
+		}
+		source = cfg.Order.TransitionsFile
+	}
+	if len(data) == 0 {
+		logger.Info("Order transitions: built-in")
+		return nil, nil
+	}
+
+	transitions, err := logicv1.ParseTransitions(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	logger.Info("Order transitions loaded", zap.String("source", source))
+	return transitions, nil
+}
+
+// priceCatalog returns the product service client used by the price policy, or nil when
+// PRODUCT_SERVICE_URL is not configured (only allowed with ORDER_PRICE_POLICY=trust_client).
+func priceCatalog(cfg *config.Config) domain.PriceCatalog {
+	if cfg.ProductServiceURL == "" {
+		return nil
+	}
+	return v1.NewProductClient(cfg.ProductServiceURL)
+}
+
+// inventoryChecker returns the inventory service client for the pre-create stock check, or nil
+// (no check) unless ORDER_STOCK_PRECHECK is set; config validation requires INVENTORY_SERVICE_URL then.
+func inventoryChecker(cfg *config.Config, logger *zap.Logger) domain.InventoryChecker {
+	if !cfg.Order.StockPrecheck {
+		return nil
+	}
+	logger.Info("Stock pre-check enabled", zap.String("inventory_service_url", cfg.InventoryServiceURL))
+	return v1.NewInventoryClient(cfg.InventoryServiceURL)
+}
+
+// orderCache returns the in-memory order cache, or nil (no caching) when ORDER_CACHE_TTL is 0
+func orderCache(cfg *config.Config, logger *zap.Logger) domain.OrderCache {
+	if cfg.Order.CacheTTL <= 0 {
+		return nil
+	}
+	logger.Info("Order cache enabled",
+		zap.Duration("ttl", cfg.Order.CacheTTL),
+		zap.Int("max_entries", cfg.Order.CacheMaxEntries),
+	)
+	return cache.NewMemoryOrderCache(cfg.Order.CacheTTL, cfg.Order.CacheMaxEntries)
+}
+
+// initNotifier returns the customer notifier, or nil (notifications disabled) when
+// NOTIFICATION_SERVICE_URL is not configured.
+func initNotifier(cfg *config.Config, logger *zap.Logger) domain.Notifier {
+	if cfg.NotificationServiceURL == "" {
+		logger.Info("Status notifications disabled (NOTIFICATION_SERVICE_URL not set)")
+		return nil
+	}
+	logger.Info("Status notifications enabled", zap.String("notification_service_url", cfg.NotificationServiceURL))
+	return v1.NewNotificationClient(cfg.NotificationServiceURL)
+}
+
+// draftExpiryInterval is how often unconfirmed drafts are looked for (or every ORDER_DRAFT_TTL, if shorter)
+const draftExpiryInterval = time.Minute
+
+// startBackgroundWorkers starts optional background jobs and returns a function that
+// cancels them and waits for them to exit (the order queue drains pending creations first,
+// then in-flight status notifications are delivered).
+func startBackgroundWorkers(
+	cfg *config.Config,
+	orderService *logicv1.OrderService,
+	shippingClient *v1.ShippingClient,
+	createQueue *logicv1.OrderQueue,
+	logger *zap.Logger,
+) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+
+	if createQueue != nil {
+		logger.Info("Async order creation enabled",
+			zap.Int("queue_size", cfg.Order.QueueSize),
+			zap.Int("workers", cfg.Order.QueueWorkers),
+		)
+		wg.Go(func() { createQueue.Run(ctx, logger) })
+	}
+
+	switch {
+	case !cfg.Reconciliation.Enabled:
+		logger.Info("Reconciliation disabled (RECONCILE_ENABLED=false)")
+	case shippingClient == nil:
+		logger.Warn("Reconciliation enabled but shipping client not configured; worker not started")
+	default:
+		logger.Info("Reconciliation worker started",
+			zap.Duration("interval", cfg.Reconciliation.Interval),
+			zap.Duration("lookback", cfg.Reconciliation.Lookback),
+		)
+		wg.Go(func() {
+			orderService.RunReconciliation(ctx, shippingClient,
+				cfg.Reconciliation.Interval, cfg.Reconciliation.Lookback, reconcileLogger(logger))
+		})
+	}
+
+	if cfg.Order.Drafts {
+		interval := min(cfg.Order.DraftTTL, draftExpiryInterval)
+		logger.Info("Draft expiry worker started",
+			zap.Duration("ttl", cfg.Order.DraftTTL),
+			zap.Duration("interval", interval),
+		)
+		wg.Go(func() { orderService.RunDraftExpiry(ctx, cfg.Order.DraftTTL, interval, logger) })
+	}
+
+	return func() {
+		cancel()
+		wg.Wait()
+		orderService.WaitForNotifications()
+	}
+}
+
+// reconcileLogger logs the outcome of each reconciliation run and every order it flagged
+func reconcileLogger(logger *zap.Logger) func(logicv1.ReconcileResult, error) {
+	return func(result logicv1.ReconcileResult, err error) {
+		for _, f := range result.Findings {
+			fields := []zap.Field{
+				zap.String("order_id", f.OrderID),
+				zap.String("order_status", f.OrderStatus.String()),
+				zap.String("shipment_status", f.ShipmentStatus),
+			}
+			switch {
+			case f.Err != nil:
+				logger.Warn("Reconciliation: order not reconciled", append(fields, zap.Error(f.Err))...)
+			case f.Updated:
+				logger.Info("Reconciliation: order status updated", append(fields, zap.String("to", f.Target.String()))...)
+			default:
+				logger.Warn("Reconciliation: order status differs from shipment", fields...)
+			}
+		}
+		if err != nil {
+			logger.Error("Reconciliation run failed", zap.Error(err))
+			return
+		}
+		logger.Info("Reconciliation run complete",
+			zap.Int("checked", result.Checked),
+			zap.Int("updated", result.Updated),
+			zap.Int("discrepancies", result.Discrepancies),
+			zap.Int("errors", result.Errors),
+		)
+	}
+}
+
+// routeHandlers groups the HTTP handlers mounted by setupServer
+type routeHandlers struct {
+	order        *v1.OrderHandler
+	webhook      *v1.PaymentWebhookHandler
+	admin        *v1.AdminHandler
+	dependencies gin.HandlerFunc
+}
+
+// dependencyProbeTimeout bounds each probe of GET /health/dependencies
+const dependencyProbeTimeout = 2 * time.Second
+
+// dependencyProbes lists what GET /health/dependencies checks: the database (critical) and the
+// shipping and cart services, reported disabled when their URL is not configured
+func dependencyProbes(pool *pgxpool.Pool, shippingClient *v1.ShippingClient, cartClient *v1.CartClient) []v1.DependencyProbe {
+	probes := []v1.DependencyProbe{
+		{Name: "database", Critical: true, Check: pool.Ping},
+		{Name: "shipping-service"},
+		{Name: "cart-service"},
+	}
+	if shippingClient != nil {
+		probes[1].Check = shippingClient.Ping
+	}
+	if cartClient != nil {
+		probes[2].Check = cartClient.Ping
+	}
+	return probes
+}
+
+// routeLogLevels builds the per-route log levels from LOG_ROUTE_LEVELS (validated by config.Load)
+func routeLogLevels(cfg *config.Config, logger *zap.Logger) middleware.RouteLogLevels {
+	parsed, err := cfg.Logging.ParseRouteLevels()
+	if err == nil {
+		var levels middleware.RouteLogLevels
+		if levels, err = middleware.NewRouteLogLevels(parsed); err == nil {
+			return levels
+		}
+	}
+	logger.Warn("Ignoring LOG_ROUTE_LEVELS", zap.Error(err))
+	return nil
+}
+
+func setupServer(
+	cfg *config.Config,
+	logger *zap.Logger,
+	authClient *middleware.AuthClient,
+	handlers routeHandlers,
+	isShuttingDown *atomic.Bool,
+) *http.Server {
 	r := gin.Default()
 
 	r.Use(middleware.TracingMiddleware())
-	r.Use(middleware.LoggingMiddleware(logger))
+	r.Use(middleware.LoggingMiddleware(logger, routeLogLevels(cfg, logger)))
 	r.Use(middleware.PrometheusMiddleware())
+	// Before the concurrency limit so a 503 still carries the CORS headers the browser needs to read it
+	r.Use(middleware.CORSMiddleware(cfg.CORS))
+	r.Use(middleware.ConcurrencyLimitMiddleware(cfg.MaxConcurrentRequests, logger))
+	r.Use(middleware.CompressionMiddleware(cfg.ResponseCompressionMinBytes))
+	r.Use(middleware.FeatureFlagsMiddleware(cfg.FeatureFlags))
 
 	r.GET("/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{"status": "ok"})
@@ -117,22 +459,94 @@ func setupServer(cfg *config.Config, logger *zap.Logger, authClient *middleware.
 		}
 		c.JSON(http.StatusOK, gin.H{"status": "ok"})
 	})
+	r.GET("/readyz", readyzHandler(cfg, isShuttingDown))
+	r.GET("/health/dependencies", handlers.dependencies)
 	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
 	// Order v1 routes — all private (JWT required). Variant A edge naming.
 	privateOrders := r.Group("/order/v1/private")
 	privateOrders.Use(middleware.AuthMiddleware(authClient, logger, cfg.AuthAllowUnauthenticatedFallback))
 	{
-		privateOrders.GET("/orders", v1.ListOrders)
-		privateOrders.GET("/orders/:id", v1.GetOrder)
-		privateOrders.GET("/orders/:id/details", v1.GetOrderDetails)
-		privateOrders.POST("/orders", v1.CreateOrder)
+		privateOrders.GET("/orders", handlers.order.ListOrders)
+		privateOrders.GET("/orders/details", handlers.order.ListOrderDetails)
+		privateOrders.GET("/orders/jobs/:job_id", handlers.order.GetCreateJob)
+		privateOrders.GET("/orders/by-ref/:ref", handlers.order.GetOrderByExternalRef)
+		privateOrders.GET("/orders/by-number/:number", handlers.order.GetOrderByNumber)
+		privateOrders.GET("/orders/:id", handlers.order.GetOrder)
+		privateOrders.GET("/orders/:id/details", handlers.order.GetOrderDetails)
+		privateOrders.GET("/orders/:id/actions", handlers.order.GetOrderActions)
+		privateOrders.GET("/orders/:id/status", handlers.order.GetOrderStatus)
+		privateOrders.GET("/orders/:id/items", handlers.order.GetOrderItems)
+		privateOrders.GET("/orders/:id/timeline", handlers.order.GetOrderTimeline)
+		privateOrders.POST("/orders/:id/items/:product_id/cancel", handlers.order.CancelOrderItem)
+		privateOrders.PUT("/orders/:id/address", handlers.order.UpdateShippingAddress)
+		privateOrders.POST("/orders/:id/confirm", handlers.order.ConfirmOrder)
+		privateOrders.POST("/orders", handlers.order.CreateOrder)
+		privateOrders.POST("/orders/from-cart", handlers.order.CreateOrderFromCart)
+		privateOrders.POST("/orders/quote", handlers.order.QuoteOrder)
+		privateOrders.POST("/orders/by-refs", handlers.order.GetOrdersByExternalRefs)
+		privateOrders.POST("/shipping/estimate", handlers.order.EstimateShipping)
 	}
 
+	// Public webhooks — no JWT; authenticated by HMAC signature in the handler.
+	publicWebhooks := r.Group("/order/v1/public/webhooks")
+	{
+		publicWebhooks.POST("/payment", handlers.webhook.HandlePayment)
+	}
+
+	// Internal service-to-service routes — no JWT and no user scoping; authenticated by the shared
+	// service token. Never expose them through the public ingress.
+	internalOrders := r.Group("/order/v1/internal")
+	internalOrders.Use(middleware.ServiceTokenMiddleware(cfg.InternalServiceToken, logger))
+	{
+		internalOrders.GET("/orders/:id", handlers.order.GetInternalOrder)
+	}
+
+	// Admin routes — JWT plus admin role; results are not scoped to the caller.
+	adminOrders := r.Group("/order/v1/private/admin")
+	adminOrders.Use(
+		middleware.AuthMiddleware(authClient, logger, cfg.AuthAllowUnauthenticatedFallback),
+		middleware.RequireRole(middleware.RoleAdmin),
+	)
+	{
+		adminOrders.GET("/orders/search", handlers.admin.SearchOrders)
+		adminOrders.GET("/orders/export", handlers.admin.ExportOrders)
+		adminOrders.GET("/orders/metrics", handlers.admin.GetOrderStats)
+		adminOrders.DELETE("/orders/purge", handlers.admin.PurgeOrders)
+		adminOrders.GET("/orders/:id/internal-note", handlers.admin.GetInternalNote)
+		adminOrders.PATCH("/orders/:id/internal-note", handlers.admin.UpdateInternalNote)
+		adminOrders.GET("/integrity/orphaned-items", handlers.admin.ListOrphanedItems)
+	}
+
+	// Bounded reads and writes keep slow or stalled clients from holding connections indefinitely
 	return &http.Server{
 		Addr:              ":" + cfg.Service.Port,
 		Handler:           r,
-		ReadHeaderTimeout: 10 * time.Second,
+		ReadHeaderTimeout: cfg.Service.ReadHeaderTimeout,
+		ReadTimeout:       cfg.Service.ReadTimeout,
+		WriteTimeout:      cfg.Service.WriteTimeout,
+		IdleTimeout:       cfg.Service.IdleTimeout,
+	}
+}
+
+// readyzHandler reports readiness including downstream dependency configuration.
+// Missing downstream URLs report "degraded" (200), or fail readiness (503) when STRICT_DEPENDENCIES=true.
+func readyzHandler(cfg *config.Config, isShuttingDown *atomic.Bool) gin.HandlerFunc {
+	missing := cfg.MissingDependencies()
+	return func(c *gin.Context) {
+		if isShuttingDown.Load() {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"status": "shutting_down"})
+			return
+		}
+		if len(missing) == 0 {
+			c.JSON(http.StatusOK, gin.H{"status": "ok"})
+			return
+		}
+		status := http.StatusOK
+		if cfg.StrictDependencies {
+			status = http.StatusServiceUnavailable
+		}
+		c.JSON(status, gin.H{"status": "degraded", "missing_dependencies": missing})
 	}
 }
 
@@ -141,6 +555,7 @@ func runGracefulShutdown(
 	srv *http.Server,
 	tp interface{ Shutdown(context.Context) error },
 	pool interface{ Close() },
+	stopWorkers func(),
 	logger *zap.Logger,
 	isShuttingDown *atomic.Bool,
 ) {
@@ -176,6 +591,9 @@ func runGracefulShutdown(
 		logger.Info("HTTP server shutdown complete")
 	}
 
+	stopWorkers()
+	logger.Info("Background workers stopped")
+
 	pool.Close()
 	logger.Info("Database pool closed")
 
@@ -23,6 +23,7 @@ package config
 import (
 	"fmt"
 	"net"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
@@ -34,23 +35,83 @@ import (
 // defaultServiceName is the fallback service name when SERVICE_NAME is not set
 const defaultServiceName = "unknown"
 
+// DefaultShippingPathTemplate is the shipping service's internal shipment-by-order endpoint
+const DefaultShippingPathTemplate = "/shipping/v1/internal/orders/%s"
+
 // Config holds all configuration for a microservice
 type Config struct {
-	Service            ServiceConfig   // Service-specific settings (port, name, version)
-	Tracing            TracingConfig   // OpenTelemetry/Tempo configuration
-	Profiling          ProfilingConfig // Pyroscope continuous profiling
-	Logging            LoggingConfig   // Structured logging (Zap)
-	Metrics            MetricsConfig   // Prometheus metrics
-	Database           DatabaseConfig  // PostgreSQL database configuration
-	ShutdownTimeout                   int    // Graceful shutdown timeout in seconds - from SHUTDOWN_TIMEOUT env (default: 10)
+	Service         ServiceConfig        // Service-specific settings (port, name, version)
+	Tracing         TracingConfig        // OpenTelemetry/Tempo configuration
+	Profiling       ProfilingConfig      // Pyroscope continuous profiling
+	Logging         LoggingConfig        // Structured logging (Zap)
+	Metrics         MetricsConfig        // Prometheus metrics
+	Database        DatabaseConfig       // PostgreSQL database configuration
+	Order           OrderConfig          // Order pricing rules
+	Pagination      PaginationConfig     // List endpoint page sizes
+	Reconciliation  ReconciliationConfig // Background order/shipping reconciliation
+	CORS            CORSConfig           // Cross-origin access for browser clients
+	ShutdownTimeout int                  // Graceful shutdown timeout in seconds - from SHUTDOWN_TIMEOUT env (default: 10)
 	// ReadinessDrainDelay: delay after failing readiness before shutting down the HTTP server.
 	// This gives Kubernetes/Service routing time to stop sending new traffic.
 	// From READINESS_DRAIN_DELAY env (default: 5s, max: 30s).
-	ReadinessDrainDelay               int
-	AuthServiceURL                    string // Auth service URL for token introspection - from AUTH_SERVICE_URL env
-	ShippingServiceURL                string // Shipping service URL for order aggregation - from SHIPPING_SERVICE_URL env
-	CartServiceURL                    string // Cart service URL for cart clearing - from CART_SERVICE_URL env
-	AuthAllowUnauthenticatedFallback  bool   // When true, allow requests without token with user_id="1" (demo only). Default: false.
+	ReadinessDrainDelay int
+	AuthServiceURL      string // Auth service URL for token introspection - from AUTH_SERVICE_URL env
+	ShippingServiceURL  string // Shipping service URL for order aggregation - from SHIPPING_SERVICE_URL env
+	// ShippingPathTemplate: path appended to ShippingServiceURL to look up an order's shipment;
+	// must contain exactly one %s (the order ID). From SHIPPING_PATH_TEMPLATE env
+	// (default: DefaultShippingPathTemplate).
+	ShippingPathTemplate string
+	// ShippingAggregationTimeout bounds the shipment fetch of the order-details endpoint and of
+	// ?expand=shipment, so a slow shipping service cannot use up the request budget.
+	// From SHIPPING_AGGREGATION_TIMEOUT env (default: 2s).
+	ShippingAggregationTimeout time.Duration
+	CartServiceURL             string // Cart service URL for cart clearing - from CART_SERVICE_URL env
+	// CartClearTimeout bounds the post-commit cart clear, retries included. It runs detached from the
+	// request so a client disconnect does not cancel it. From CART_CLEAR_TIMEOUT env (default: 5s).
+	CartClearTimeout time.Duration
+	// ProductServiceURL: product service URL for catalog prices, used by ORDER_PRICE_POLICY
+	// trust_catalog and reject_on_mismatch. From PRODUCT_SERVICE_URL env (default: empty).
+	ProductServiceURL string
+	// InventoryServiceURL: inventory service URL for the pre-create stock check (ORDER_STOCK_PRECHECK).
+	// From INVENTORY_SERVICE_URL env (default: empty).
+	InventoryServiceURL string
+	// NotificationServiceURL: notification service URL for customer status change notifications.
+	// Empty (the default) disables notifications. From NOTIFICATION_SERVICE_URL env.
+	NotificationServiceURL           string
+	AuthAllowUnauthenticatedFallback bool // When true, allow requests without token with user_id="1" (demo only). Default: false.
+	// StrictDependencies: when true, /readyz fails (503) if a downstream service URL is missing.
+	// When false (default), missing URLs only degrade features and are reported as "degraded".
+	// From STRICT_DEPENDENCIES env.
+	StrictDependencies bool
+	// PaymentWebhookSecret: shared HMAC-SHA256 secret used to verify payment provider webhooks.
+	// When empty, all webhook requests are rejected. From PAYMENT_WEBHOOK_SECRET env.
+	PaymentWebhookSecret string
+	// InternalServiceToken: shared secret internal services send in X-Service-Token to call the
+	// /order/v1/internal routes, which skip user scoping. When empty, all internal requests are
+	// rejected. From INTERNAL_SERVICE_TOKEN env.
+	InternalServiceToken string
+	// RunMigrations: apply embedded db/migrations/sql on startup (alternative to the Flyway job, sharing its
+	// history table; seed data is skipped).
+	// From RUN_MIGRATIONS env (default: false).
+	RunMigrations bool
+	// ResponseEnvelope: wrap success bodies as {"data": ..., "meta": {...}} instead of bare objects.
+	// From API_RESPONSE_ENVELOPE env (default: false).
+	ResponseEnvelope bool
+	// StrictJSON: reject order create/quote requests containing unknown JSON fields (400 naming the field).
+	// From STRICT_JSON env (default: false).
+	StrictJSON bool
+	// JSONCase: key casing of success response bodies, "snake" (the struct tags) or "camel".
+	// From API_JSON_CASE env (default: snake).
+	JSONCase string
+	// MaxConcurrentRequests: requests handled at once before new ones get 503 + Retry-After
+	// (health checks and metrics are exempt). From MAX_CONCURRENT_REQUESTS env (default: 0, unlimited).
+	MaxConcurrentRequests int
+	// ResponseCompressionMinBytes: gzip/deflate response bodies of at least this many bytes when the client
+	// accepts it. From RESPONSE_COMPRESSION_MIN_BYTES env (default: 0, compression disabled).
+	ResponseCompressionMinBytes int
+	// FeatureFlags: comma-separated feature flags enabled for every request; a request adds or, with
+	// "-name", removes flags through the X-Feature-Flags header. From FEATURE_FLAGS env (default: empty).
+	FeatureFlags string
 }
 
 // ServiceConfig defines basic service configuration
@@ -59,16 +120,52 @@ type ServiceConfig struct {
 	Port    string // HTTP server port (default: "8080") - from PORT env
 	Version string // Service version (optional) - from VERSION env
 	Env     string // Environment (dev/staging/production) - from ENV env
+
+	// HTTP server timeouts against slow clients (slowloris); 0 disables one.
+	// ReadHeaderTimeout: time to read the request headers - from HTTP_READ_HEADER_TIMEOUT env (default: 10s).
+	// ReadTimeout: time to read the whole request, body included - from HTTP_READ_TIMEOUT env (default: 30s).
+	// WriteTimeout: time from the end of the headers to the end of the response; the NDJSON export
+	// extends it line by line - from HTTP_WRITE_TIMEOUT env (default: 60s).
+	// IdleTimeout: keep-alive wait for the next request - from HTTP_IDLE_TIMEOUT env (default: 120s).
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+}
+
+// OTEL_TRACES_SAMPLER values (OpenTelemetry SDK environment variable spec)
+const (
+	TracesSamplerAlwaysOn                = "always_on"
+	TracesSamplerAlwaysOff               = "always_off"
+	TracesSamplerTraceIDRatio            = "traceidratio"
+	TracesSamplerParentBasedAlwaysOn     = "parentbased_always_on"
+	TracesSamplerParentBasedAlwaysOff    = "parentbased_always_off"
+	TracesSamplerParentBasedTraceIDRatio = "parentbased_traceidratio"
+)
+
+var validTracesSamplers = []string{
+	TracesSamplerAlwaysOn,
+	TracesSamplerAlwaysOff,
+	TracesSamplerTraceIDRatio,
+	TracesSamplerParentBasedAlwaysOn,
+	TracesSamplerParentBasedAlwaysOff,
+	TracesSamplerParentBasedTraceIDRatio,
 }
 
 // TracingConfig defines OpenTelemetry tracing configuration
 // Traces are sent to OpenTelemetry Collector for distributed tracing analysis
 type TracingConfig struct {
-	Enabled            bool    // Enable tracing (default: true) - from TRACING_ENABLED env
-	Endpoint           string  // OTel Collector endpoint - from OTEL_COLLECTOR_ENDPOINT env
-	SampleRate         float64 // Trace sampling rate (0.0-1.0) - from OTEL_SAMPLE_RATE env
-	ServiceName        string  // Service name for traces (defaults to ServiceConfig.Name)
-	MaxExportBatchSize int     // Max spans per batch (default: 512)
+	Enabled  bool   // Enable tracing (default: true) - from TRACING_ENABLED env
+	Endpoint string // OTel Collector endpoint - from OTEL_COLLECTOR_ENDPOINT env
+	// Sampler: always_on | always_off | traceidratio | parentbased_always_on | parentbased_always_off |
+	// parentbased_traceidratio - from OTEL_TRACES_SAMPLER env (default: parentbased_traceidratio).
+	// parentbased_* follow the caller's sampling decision and apply the base sampler only to root spans.
+	Sampler string
+	// SampleRate is the ratio (0.0-1.0) for the *traceidratio samplers - from OTEL_TRACES_SAMPLER_ARG env,
+	// falling back to OTEL_SAMPLE_RATE (default: 0.1)
+	SampleRate         float64
+	ServiceName        string // Service name for traces (defaults to ServiceConfig.Name)
+	MaxExportBatchSize int    // Max spans per batch (default: 512)
 }
 
 // ProfilingConfig defines Pyroscope continuous profiling configuration
@@ -82,6 +179,36 @@ type ProfilingConfig struct {
 type LoggingConfig struct {
 	Level  string // Log level: debug, info, warn, error (default: "info") - from LOG_LEVEL env
 	Format string // Log format: json, console (default: "json") - from LOG_FORMAT env
+	// RouteLevels demotes a route's info logs (handler success logs and the access log) to debug
+	// to cut volume on hot reads; warn/error logs are never affected. Comma-separated
+	// "METHOD /route/pattern=level" with level debug or info, using Gin route patterns, e.g.
+	// "GET /order/v1/private/orders=debug,GET /order/v1/private/orders/:id=debug".
+	// From LOG_ROUTE_LEVELS env (default: empty, every route logs at info).
+	RouteLevels string
+}
+
+// validRouteLogLevels are the levels a route's info logs can be written at
+var validRouteLogLevels = []string{"debug", "info"}
+
+// ParseRouteLevels parses RouteLevels into a map keyed by "METHOD /route/pattern"
+func (l LoggingConfig) ParseRouteLevels() (map[string]string, error) {
+	levels := map[string]string{}
+	for entry := range strings.SplitSeq(l.RouteLevels, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		route, level, ok := strings.Cut(entry, "=")
+		method, path, hasPath := strings.Cut(strings.TrimSpace(route), " ")
+		level = strings.ToLower(strings.TrimSpace(level))
+		path = strings.TrimSpace(path)
+		if !ok || !hasPath || !strings.HasPrefix(path, "/") || !contains(validRouteLogLevels, level) {
+			return nil, fmt.Errorf("invalid LOG_ROUTE_LEVELS entry %q: want \"METHOD /path=level\" with level one of %v",
+				entry, validRouteLogLevels)
+		}
+		levels[strings.ToUpper(method)+" "+path] = level
+	}
+	return levels, nil
 }
 
 // MetricsConfig defines Prometheus metrics configuration
@@ -90,18 +217,159 @@ type MetricsConfig struct {
 	Path    string // Metrics endpoint path (default: "/metrics") - from METRICS_PATH env
 }
 
+// OrderConfig defines order pricing rules
+type OrderConfig struct {
+	FlatShippingRate      float64 // Flat shipping charge per order - from ORDER_FLAT_SHIPPING_RATE env (default: 5.00)
+	FreeShippingThreshold float64 // Subtotal above which shipping is free; 0 disables - from ORDER_FREE_SHIPPING_THRESHOLD env (default: 0)
+	// ShippingStrategy: flat | per_item | free_over - from SHIPPING_STRATEGY env.
+	// Default: free_over when ORDER_FREE_SHIPPING_THRESHOLD > 0, otherwise flat (previous behavior).
+	// A threshold with SHIPPING_STRATEGY=flat or per_item is rejected: only free_over applies it.
+	// per_item charges ORDER_FLAT_SHIPPING_RATE as a base fee plus ORDER_SHIPPING_PER_UNIT_RATE per unit.
+	ShippingStrategy    string
+	PerUnitShippingRate float64 // Per-unit charge for per_item - from ORDER_SHIPPING_PER_UNIT_RATE env (default: 0.50)
+	// ExpressShippingSurcharge is added to shipping for priority=express orders, with any strategy.
+	// From ORDER_EXPRESS_SHIPPING_SURCHARGE env (default: 10.00).
+	ExpressShippingSurcharge float64
+	// NotFoundOnForbidden: answer 404 (not 403) when a user requests another user's order,
+	// so responses don't confirm which order IDs exist. From ORDER_NOTFOUND_ON_FORBIDDEN env (default: true).
+	NotFoundOnForbidden bool
+	// GoneForPurged: answer 410 Gone (not 404) for orders removed by the admin purge.
+	// From ORDER_GONE_FOR_PURGED env (default: false).
+	GoneForPurged  bool
+	AllowZeroPrice bool    // Accept items priced at 0 - from ORDER_ALLOW_ZERO_PRICE env (default: true)
+	MinTotal       float64 // Minimum subtotal before shipping; 0 disables - from ORDER_MIN_TOTAL env (default: 0)
+	// TaxRate: default tax rate (fraction, 0.08 = 8%) for items without their own tax_rate.
+	// From ORDER_TAX_RATE env (default: 0, untaxed).
+	TaxRate float64
+	// RoundingMode: how computed amounts (item subtotal, tax, shipping) are rounded to cents:
+	// half_up | half_even (bankers) - from ORDER_ROUNDING_MODE env (default: half_up).
+	RoundingMode string
+	// PricePolicy: which item price wins over the other when the client sends one and the catalog has one:
+	// trust_client | trust_catalog | reject_on_mismatch - from ORDER_PRICE_POLICY env (default: trust_client).
+	// The catalog policies need PRODUCT_SERVICE_URL.
+	PricePolicy string
+	// StockPrecheck: ask the inventory service whether every item is in stock before creating an order,
+	// rejecting it with all short items listed. Needs INVENTORY_SERVICE_URL.
+	// From ORDER_STOCK_PRECHECK env (default: false).
+	StockPrecheck bool
+	// MaxDistinctProducts: maximum distinct product_ids per order; 0 disables.
+	// From ORDER_MAX_DISTINCT_PRODUCTS env (default: 100).
+	MaxDistinctProducts int
+	// PromotionMinUnits / PromotionPercentOff: automatic "buy N get X% off" promotion applied to
+	// carts of at least PromotionMinUnits units (PercentOff is a fraction, 0.10 = 10%).
+	// From ORDER_PROMOTION_MIN_UNITS / ORDER_PROMOTION_PERCENT_OFF env (default: 0, disabled).
+	PromotionMinUnits   int
+	PromotionPercentOff float64
+	// MergeDuplicateItems: sum quantities of line items with the same product_id into one item
+	// (they must share a price). From ORDER_MERGE_DUPLICATE_ITEMS env (default: false).
+	MergeDuplicateItems bool
+	// MaxProductNameLength: longest stored product name in bytes after HTML escaping, 1 to 255 (the
+	// column size). From ORDER_MAX_PRODUCT_NAME_LENGTH env (default: 255).
+	MaxProductNameLength int
+	// TruncateLongNames: cut longer product names with a logged warning; when false such orders are
+	// rejected with 400. From ORDER_TRUNCATE_LONG_NAMES env (default: true).
+	TruncateLongNames bool
+	// VerifyOnRead: log a warning when an order's stored subtotal differs from the sum of its items
+	// on single-order reads (never fails the read). From ORDER_VERIFY_ON_READ env (default: false).
+	VerifyOnRead bool
+	// DeliveryBaseDays: order-time delivery estimate, in days after the order date.
+	// From ORDER_DELIVERY_BASE_DAYS env (default: 5).
+	DeliveryBaseDays int
+	// ExpressDeliveryAdjustDays: added to DeliveryBaseDays for express orders (negative = sooner).
+	// From ORDER_EXPRESS_DELIVERY_ADJUST_DAYS env (default: -3).
+	ExpressDeliveryAdjustDays int
+	// AsyncCreate: POST /orders enqueues the order and returns 202 with a job status URL
+	// instead of creating it synchronously. From ORDER_ASYNC_CREATE env (default: false).
+	AsyncCreate  bool
+	QueueSize    int // Max pending async creations before 503 - from ORDER_QUEUE_SIZE env (default: 1000)
+	QueueWorkers int // Concurrent async creations (DB load cap) - from ORDER_QUEUE_WORKERS env (default: 4)
+	// Drafts: POST /orders creates a draft the owner places with POST /orders/:id/confirm.
+	// From ORDER_DRAFTS env (default: false, orders are placed as pending right away).
+	Drafts bool
+	// DraftTTL: unconfirmed drafts older than this are cancelled - from ORDER_DRAFT_TTL env (default: 30m)
+	DraftTTL time.Duration
+	// Transitions: JSON state machine replacing the built-in one, e.g. {"pending": ["paid", "cancelled"], ...}
+	// (see logicv1.ParseTransitions). From ORDER_TRANSITIONS env (default: empty, built-in transitions).
+	Transitions string
+	// TransitionsFile: file holding the Transitions JSON (e.g. a mounted ConfigMap), read at startup.
+	// From ORDER_TRANSITIONS_FILE env; mutually exclusive with ORDER_TRANSITIONS.
+	TransitionsFile string
+	// CacheTTL: how long single-order reads are cached in memory; each replica caches on its own, so
+	// another replica's changes can be served for up to this long. From ORDER_CACHE_TTL env (default: 0, disabled).
+	CacheTTL        time.Duration
+	CacheMaxEntries int // Most orders cached per replica - from ORDER_CACHE_MAX_ENTRIES env (default: 10000)
+}
+
+// ResolvedShippingStrategy returns ShippingStrategy, or the default when unset:
+// free_over if a free-shipping threshold is configured, otherwise flat
+func (o OrderConfig) ResolvedShippingStrategy() string {
+	switch {
+	case o.ShippingStrategy != "":
+		return o.ShippingStrategy
+	case o.FreeShippingThreshold > 0:
+		return "free_over"
+	default:
+		return "flat"
+	}
+}
+
+// PaginationConfig defines server-side page size limits for list endpoints
+type PaginationConfig struct {
+	DefaultPageSize int // Page size when client sends no limit - from DEFAULT_PAGE_SIZE env (default: 20)
+	MaxPageSize     int // Hard cap on client-supplied limit - from MAX_PAGE_SIZE env (default: 100)
+}
+
+// ReconciliationConfig defines the background worker that syncs order status with shipping
+type ReconciliationConfig struct {
+	Enabled  bool          // Run the worker - from RECONCILE_ENABLED env (default: false)
+	Interval time.Duration // Time between runs - from RECONCILE_INTERVAL env (default: 5m)
+	Lookback time.Duration // Only orders updated within this window are checked - from RECONCILE_LOOKBACK env (default: 24h)
+}
+
+// CORSConfig defines which browser origins may call the API cross-origin. All lists are comma-separated.
+type CORSConfig struct {
+	// AllowedOrigins: origins ("https://shop.example.com", scheme and host, no path) answered with CORS
+	// headers; "*" allows any origin. From CORS_ALLOWED_ORIGINS env (default: empty, no CORS headers at all).
+	AllowedOrigins string
+	// AllowedMethods: methods a preflight may approve - from CORS_ALLOWED_METHODS env (default: GET,POST,PUT,PATCH,DELETE)
+	AllowedMethods string
+	// AllowedHeaders: request headers a preflight may approve - from CORS_ALLOWED_HEADERS env
+	// (default: Authorization,Content-Type,X-Feature-Flags)
+	AllowedHeaders string
+	MaxAge         time.Duration // How long browsers may cache a preflight - from CORS_MAX_AGE env (default: 10m)
+}
+
+// Origins returns AllowedOrigins as a list
+func (c CORSConfig) Origins() []string { return splitList(c.AllowedOrigins) }
+
+// Methods returns AllowedMethods as an upper-case list
+func (c CORSConfig) Methods() []string { return splitList(strings.ToUpper(c.AllowedMethods)) }
+
+// Headers returns AllowedHeaders as a list
+func (c CORSConfig) Headers() []string { return splitList(c.AllowedHeaders) }
+
 // DatabaseConfig defines PostgreSQL database configuration
 // All database connections use separate environment variables (not DATABASE_URL string)
 type DatabaseConfig struct {
-	Host           string // Database host - from DB_HOST env
-	Port           string // Database port - from DB_PORT env (default: "5432")
-	Name           string // Database name - from DB_NAME env
-	User           string // Database user - from DB_USER env
-	Password       string // Database password - from DB_PASSWORD env
+	Host     string // Database host, or comma-separated host[:port] list - from DB_HOST env
+	Port     string // Database port - from DB_PORT env (default: "5432")
+	Name     string // Database name - from DB_NAME env
+	User     string // Database user - from DB_USER env
+	Password string // Database password - from DB_PASSWORD env
+	// PasswordFile: file holding the password (mounted secret), used instead of Password.
+	// From DB_PASSWORD_FILE env; the database package reads it when connecting.
+	PasswordFile   string
 	SSLMode        string // SSL mode - from DB_SSLMODE env (default: "disable")
 	MaxConnections int    // Max connections - from DB_POOL_MAX_CONNECTIONS env (default: 25)
+	MinConnections int    // Connections kept open (pre-warmed) - from DB_POOL_MIN_CONNECTIONS env (default: 0)
 	PoolMode       string // Pool mode - from DB_POOL_MODE env (optional)
 	PoolerType     string // Pooler type - from DB_POOLER_TYPE env (optional)
+	// TraceConnections: log connection attempts, acquires and releases with the backend PID at
+	// debug level, for PgCat debugging. From DB_TRACE_CONNECTIONS env (default: false).
+	TraceConnections bool
+	// AcquireTimeout: longest wait for a free pooled connection before a query fails as
+	// "database busy" (503). From DB_ACQUIRE_TIMEOUT env (default: 5s, 0 waits for the request deadline).
+	AcquireTimeout time.Duration
 }
 
 // BuildDSN constructs PostgreSQL connection string from config
@@ -128,11 +396,17 @@ func Load() *Config {
 			Port:    getEnv("PORT", "8080"),
 			Version: getEnv("VERSION", "dev"),
 			Env:     getEnv("ENV", "development"),
+
+			ReadHeaderTimeout: getEnvDuration("HTTP_READ_HEADER_TIMEOUT", 10*time.Second),
+			ReadTimeout:       getEnvDuration("HTTP_READ_TIMEOUT", 30*time.Second),
+			WriteTimeout:      getEnvDuration("HTTP_WRITE_TIMEOUT", 60*time.Second),
+			IdleTimeout:       getEnvDuration("HTTP_IDLE_TIMEOUT", 120*time.Second),
 		},
 		Tracing: TracingConfig{
 			Enabled:            getEnvBool("TRACING_ENABLED", true),
 			Endpoint:           getEnv("OTEL_COLLECTOR_ENDPOINT", "otel-collector-opentelemetry-collector.monitoring.svc.cluster.local:4318"),
-			SampleRate:         getEnvFloat("OTEL_SAMPLE_RATE", 0.1), // 10% default (production)
+			Sampler:            strings.ToLower(getEnv("OTEL_TRACES_SAMPLER", TracesSamplerParentBasedTraceIDRatio)),
+			SampleRate:         getEnvFloat("OTEL_TRACES_SAMPLER_ARG", getEnvFloat("OTEL_SAMPLE_RATE", 0.1)), // 10% default (production)
 			ServiceName:        getEnv("SERVICE_NAME", defaultServiceName),
 			MaxExportBatchSize: getEnvInt("OTEL_BATCH_SIZE", 512),
 		},
@@ -144,6 +418,8 @@ func Load() *Config {
 		Logging: LoggingConfig{
 			Level:  getEnv("LOG_LEVEL", "info"),
 			Format: getEnv("LOG_FORMAT", "json"),
+
+			RouteLevels: getEnv("LOG_ROUTE_LEVELS", ""),
 		},
 		Metrics: MetricsConfig{
 			Enabled: getEnvBool("METRICS_ENABLED", true),
@@ -155,17 +431,86 @@ func Load() *Config {
 			Name:           getEnv("DB_NAME", ""),
 			User:           getEnv("DB_USER", ""),
 			Password:       getEnv("DB_PASSWORD", ""),
+			PasswordFile:   getEnv("DB_PASSWORD_FILE", ""),
 			SSLMode:        getEnv("DB_SSLMODE", "disable"),
 			MaxConnections: getEnvInt("DB_POOL_MAX_CONNECTIONS", 25),
+			MinConnections: getEnvInt("DB_POOL_MIN_CONNECTIONS", 0),
 			PoolMode:       getEnv("DB_POOL_MODE", ""),
 			PoolerType:     getEnv("DB_POOLER_TYPE", ""),
+
+			TraceConnections: getEnvBool("DB_TRACE_CONNECTIONS", false),
+			AcquireTimeout:   getEnvDuration("DB_ACQUIRE_TIMEOUT", 5*time.Second),
+		},
+		Order: OrderConfig{
+			FlatShippingRate:          getEnvFloat("ORDER_FLAT_SHIPPING_RATE", 5.00),
+			FreeShippingThreshold:     getEnvFloat("ORDER_FREE_SHIPPING_THRESHOLD", 0),
+			ShippingStrategy:          strings.ToLower(getEnv("SHIPPING_STRATEGY", "")),
+			PerUnitShippingRate:       getEnvFloat("ORDER_SHIPPING_PER_UNIT_RATE", 0.50),
+			ExpressShippingSurcharge:  getEnvFloat("ORDER_EXPRESS_SHIPPING_SURCHARGE", 10.00),
+			NotFoundOnForbidden:       getEnvBool("ORDER_NOTFOUND_ON_FORBIDDEN", true),
+			GoneForPurged:             getEnvBool("ORDER_GONE_FOR_PURGED", false),
+			AllowZeroPrice:            getEnvBool("ORDER_ALLOW_ZERO_PRICE", true),
+			MinTotal:                  getEnvFloat("ORDER_MIN_TOTAL", 0),
+			TaxRate:                   getEnvFloat("ORDER_TAX_RATE", 0),
+			RoundingMode:              strings.ToLower(getEnv("ORDER_ROUNDING_MODE", "half_up")),
+			PricePolicy:               strings.ToLower(getEnv("ORDER_PRICE_POLICY", "trust_client")),
+			StockPrecheck:             getEnvBool("ORDER_STOCK_PRECHECK", false),
+			MaxDistinctProducts:       getEnvInt("ORDER_MAX_DISTINCT_PRODUCTS", 100),
+			PromotionMinUnits:         getEnvInt("ORDER_PROMOTION_MIN_UNITS", 0),
+			PromotionPercentOff:       getEnvFloat("ORDER_PROMOTION_PERCENT_OFF", 0),
+			MergeDuplicateItems:       getEnvBool("ORDER_MERGE_DUPLICATE_ITEMS", false),
+			MaxProductNameLength:      getEnvInt("ORDER_MAX_PRODUCT_NAME_LENGTH", 255),
+			TruncateLongNames:         getEnvBool("ORDER_TRUNCATE_LONG_NAMES", true),
+			VerifyOnRead:              getEnvBool("ORDER_VERIFY_ON_READ", false),
+			DeliveryBaseDays:          getEnvInt("ORDER_DELIVERY_BASE_DAYS", 5),
+			ExpressDeliveryAdjustDays: getEnvInt("ORDER_EXPRESS_DELIVERY_ADJUST_DAYS", -3),
+			AsyncCreate:               getEnvBool("ORDER_ASYNC_CREATE", false),
+			QueueSize:                 getEnvInt("ORDER_QUEUE_SIZE", 1000),
+			QueueWorkers:              getEnvInt("ORDER_QUEUE_WORKERS", 4),
+			Drafts:                    getEnvBool("ORDER_DRAFTS", false),
+			DraftTTL:                  getEnvDuration("ORDER_DRAFT_TTL", 30*time.Minute),
+			Transitions:               getEnv("ORDER_TRANSITIONS", ""),
+			TransitionsFile:           getEnv("ORDER_TRANSITIONS_FILE", ""),
+			CacheTTL:                  getEnvDuration("ORDER_CACHE_TTL", 0),
+			CacheMaxEntries:           getEnvInt("ORDER_CACHE_MAX_ENTRIES", 10000),
+		},
+		Pagination: PaginationConfig{
+			DefaultPageSize: getEnvInt("DEFAULT_PAGE_SIZE", 20),
+			MaxPageSize:     getEnvInt("MAX_PAGE_SIZE", 100),
+		},
+		Reconciliation: ReconciliationConfig{
+			Enabled:  getEnvBool("RECONCILE_ENABLED", false),
+			Interval: getEnvDuration("RECONCILE_INTERVAL", 5*time.Minute),
+			Lookback: getEnvDuration("RECONCILE_LOOKBACK", 24*time.Hour),
+		},
+		CORS: CORSConfig{
+			AllowedOrigins: getEnv("CORS_ALLOWED_ORIGINS", ""),
+			AllowedMethods: getEnv("CORS_ALLOWED_METHODS", "GET,POST,PUT,PATCH,DELETE"),
+			AllowedHeaders: getEnv("CORS_ALLOWED_HEADERS", "Authorization,Content-Type,X-Feature-Flags"),
+			MaxAge:         getEnvDuration("CORS_MAX_AGE", 10*time.Minute),
 		},
 		ShutdownTimeout:                  getEnvDurationSeconds("SHUTDOWN_TIMEOUT", 10),
 		ReadinessDrainDelay:              getEnvDurationSecondsWithMax("READINESS_DRAIN_DELAY", 5, 30),
 		AuthServiceURL:                   getEnv("AUTH_SERVICE_URL", "http://auth.auth.svc.cluster.local:8080"),
-		ShippingServiceURL:               getEnv("SHIPPING_SERVICE_URL", "http://shipping.shipping.svc.cluster.local:8080"),
-		CartServiceURL:                   getEnv("CART_SERVICE_URL", "http://cart.cart.svc.cluster.local:8080"),
+		ShippingServiceURL:               getEnvAllowEmpty("SHIPPING_SERVICE_URL", "http://shipping.shipping.svc.cluster.local:8080"),
+		ShippingPathTemplate:             getEnv("SHIPPING_PATH_TEMPLATE", DefaultShippingPathTemplate),
+		CartServiceURL:                   getEnvAllowEmpty("CART_SERVICE_URL", "http://cart.cart.svc.cluster.local:8080"),
+		ShippingAggregationTimeout:       getEnvDuration("SHIPPING_AGGREGATION_TIMEOUT", 2*time.Second),
+		CartClearTimeout:                 getEnvDuration("CART_CLEAR_TIMEOUT", 5*time.Second),
+		ProductServiceURL:                getEnv("PRODUCT_SERVICE_URL", ""),
+		InventoryServiceURL:              getEnv("INVENTORY_SERVICE_URL", ""),
+		NotificationServiceURL:           getEnv("NOTIFICATION_SERVICE_URL", ""),
 		AuthAllowUnauthenticatedFallback: getEnvBool("AUTH_ALLOW_UNAUTHENTICATED_FALLBACK", false),
+		StrictDependencies:               getEnvBool("STRICT_DEPENDENCIES", false),
+		PaymentWebhookSecret:             getEnv("PAYMENT_WEBHOOK_SECRET", ""),
+		InternalServiceToken:             getEnv("INTERNAL_SERVICE_TOKEN", ""),
+		RunMigrations:                    getEnvBool("RUN_MIGRATIONS", false),
+		ResponseEnvelope:                 getEnvBool("API_RESPONSE_ENVELOPE", false),
+		StrictJSON:                       getEnvBool("STRICT_JSON", false),
+		JSONCase:                         getEnv("API_JSON_CASE", "snake"),
+		MaxConcurrentRequests:            getEnvInt("MAX_CONCURRENT_REQUESTS", 0),
+		ResponseCompressionMinBytes:      getEnvInt("RESPONSE_COMPRESSION_MIN_BYTES", 0),
+		FeatureFlags:                     getEnv("FEATURE_FLAGS", ""),
 	}
 }
 
@@ -179,6 +524,11 @@ func (c *Config) Validate() error {
 	errs = append(errs, c.validateProfiling()...)
 	errs = append(errs, c.validateLogging()...)
 	errs = append(errs, c.validateDatabase()...)
+	errs = append(errs, c.validateOrder()...)
+	errs = append(errs, c.validatePagination()...)
+	errs = append(errs, c.validateReconciliation()...)
+	errs = append(errs, c.validateCORS()...)
+	errs = append(errs, c.validateShipping()...)
 
 	if len(errs) > 0 {
 		return fmt.Errorf("configuration validation failed:\n  - %s", strings.Join(errs, "\n  - "))
@@ -196,12 +546,42 @@ func (c *Config) validateService() []string {
 		errs = append(errs, "PORT is required (e.g., '8080')")
 	}
 	if _, err := strconv.Atoi(c.Service.Port); err != nil {
-		errs = append(errs, "PORT must be a valid number, got: " + c.Service.Port)
+		errs = append(errs, "PORT must be a valid number, got: "+c.Service.Port)
 	}
 	validEnvs := []string{"development", "dev", "staging", "stage", "production", "prod"}
 	if !contains(validEnvs, c.Service.Env) {
 		errs = append(errs, fmt.Sprintf("ENV must be one of %v, got: %s", validEnvs, c.Service.Env))
 	}
+	serverTimeouts := []struct {
+		env   string
+		value time.Duration
+	}{
+		{"HTTP_READ_HEADER_TIMEOUT", c.Service.ReadHeaderTimeout},
+		{"HTTP_READ_TIMEOUT", c.Service.ReadTimeout},
+		{"HTTP_WRITE_TIMEOUT", c.Service.WriteTimeout},
+		{"HTTP_IDLE_TIMEOUT", c.Service.IdleTimeout},
+	}
+	for _, t := range serverTimeouts {
+		if t.value < 0 {
+			errs = append(errs, fmt.Sprintf("%s must be >= 0 (0 = no timeout), got: %s", t.env, t.value))
+		}
+	}
+	if c.MaxConcurrentRequests < 0 {
+		errs = append(errs, fmt.Sprintf("MAX_CONCURRENT_REQUESTS must be >= 0 (0 = unlimited), got: %d", c.MaxConcurrentRequests))
+	}
+	if c.ResponseCompressionMinBytes < 0 {
+		errs = append(errs, fmt.Sprintf("RESPONSE_COMPRESSION_MIN_BYTES must be >= 0 (0 = disabled), got: %d", c.ResponseCompressionMinBytes))
+	}
+	validJSONCases := []string{"snake", "camel"}
+	if !contains(validJSONCases, c.JSONCase) {
+		errs = append(errs, fmt.Sprintf("API_JSON_CASE must be one of %v, got: %s", validJSONCases, c.JSONCase))
+	}
+	if c.ShippingAggregationTimeout <= 0 {
+		errs = append(errs, "SHIPPING_AGGREGATION_TIMEOUT must be a positive duration (e.g., '2s')")
+	}
+	if c.CartClearTimeout <= 0 {
+		errs = append(errs, "CART_CLEAR_TIMEOUT must be a positive duration (e.g., '5s')")
+	}
 	return errs
 }
 
@@ -213,8 +593,11 @@ func (c *Config) validateTracing() []string {
 	if c.Tracing.Endpoint == "" {
 		errs = append(errs, "OTEL_COLLECTOR_ENDPOINT is required when tracing is enabled")
 	}
+	if !contains(validTracesSamplers, c.Tracing.Sampler) {
+		errs = append(errs, fmt.Sprintf("OTEL_TRACES_SAMPLER must be one of %v, got: %s", validTracesSamplers, c.Tracing.Sampler))
+	}
 	if c.Tracing.SampleRate < 0 || c.Tracing.SampleRate > 1.0 {
-		errs = append(errs, fmt.Sprintf("OTEL_SAMPLE_RATE must be between 0.0 and 1.0, got: %.2f", c.Tracing.SampleRate))
+		errs = append(errs, fmt.Sprintf("OTEL_TRACES_SAMPLER_ARG must be between 0.0 and 1.0, got: %.2f", c.Tracing.SampleRate))
 	}
 	if c.Tracing.ServiceName == "" || c.Tracing.ServiceName == defaultServiceName {
 		errs = append(errs, "SERVICE_NAME is required for tracing (used in Tempo queries)")
@@ -246,6 +629,9 @@ func (c *Config) validateLogging() []string {
 	if !contains(validLogFormats, strings.ToLower(c.Logging.Format)) {
 		errs = append(errs, fmt.Sprintf("LOG_FORMAT must be one of %v, got: %s", validLogFormats, c.Logging.Format))
 	}
+	if _, err := c.Logging.ParseRouteLevels(); err != nil {
+		errs = append(errs, err.Error())
+	}
 	return errs
 }
 
@@ -260,17 +646,183 @@ func (c *Config) validateDatabase() []string {
 	if c.Database.User == "" {
 		errs = append(errs, "DB_USER is required when DB_HOST is set")
 	}
-	if c.Database.Password == "" {
-		errs = append(errs, "DB_PASSWORD is required when DB_HOST is set")
+	if c.Database.PasswordFile != "" {
+		if _, err := os.ReadFile(c.Database.PasswordFile); err != nil {
+			errs = append(errs, fmt.Sprintf("DB_PASSWORD_FILE is not readable: %v", err))
+		}
+	} else if c.Database.Password == "" {
+		errs = append(errs, "DB_PASSWORD or DB_PASSWORD_FILE is required when DB_HOST is set")
 	}
 	if c.Database.Port != "" {
 		if _, err := strconv.Atoi(c.Database.Port); err != nil {
-			errs = append(errs, "DB_PORT must be a valid number, got: " + c.Database.Port)
+			errs = append(errs, "DB_PORT must be a valid number, got: "+c.Database.Port)
+		}
+	}
+	if c.Database.MaxConnections < 1 {
+		errs = append(errs, fmt.Sprintf("DB_POOL_MAX_CONNECTIONS must be >= 1, got: %d", c.Database.MaxConnections))
+	}
+	if c.Database.MinConnections < 0 || c.Database.MinConnections > c.Database.MaxConnections {
+		errs = append(errs, fmt.Sprintf("DB_POOL_MIN_CONNECTIONS must be between 0 and DB_POOL_MAX_CONNECTIONS (%d), got: %d",
+			c.Database.MaxConnections, c.Database.MinConnections))
+	}
+	if c.Database.AcquireTimeout < 0 {
+		errs = append(errs, fmt.Sprintf("DB_ACQUIRE_TIMEOUT must be >= 0, got: %s", c.Database.AcquireTimeout))
+	}
+	return errs
+}
+
+func (c *Config) validateShipping() []string {
+	var errs []string
+	tmpl := c.ShippingPathTemplate
+	if !strings.HasPrefix(tmpl, "/") || strings.Count(tmpl, "%s") != 1 || strings.Count(tmpl, "%") != 1 {
+		errs = append(errs, "SHIPPING_PATH_TEMPLATE must start with '/' and contain exactly one %s (the order ID), got: "+tmpl)
+	}
+	return errs
+}
+
+func (c *Config) validateOrder() []string {
+	var errs []string
+	if c.Order.FlatShippingRate < 0 {
+		errs = append(errs, fmt.Sprintf("ORDER_FLAT_SHIPPING_RATE must be >= 0, got: %.2f", c.Order.FlatShippingRate))
+	}
+	if c.Order.FreeShippingThreshold < 0 {
+		errs = append(errs, fmt.Sprintf("ORDER_FREE_SHIPPING_THRESHOLD must be >= 0, got: %.2f", c.Order.FreeShippingThreshold))
+	}
+	strategy := c.Order.ResolvedShippingStrategy()
+	validStrategies := []string{"flat", "per_item", "free_over"}
+	if !contains(validStrategies, strategy) {
+		errs = append(errs, fmt.Sprintf("SHIPPING_STRATEGY must be one of %v, got: %s", validStrategies, strategy))
+	}
+	if c.Order.MinTotal < 0 {
+		errs = append(errs, fmt.Sprintf("ORDER_MIN_TOTAL must be >= 0, got: %.2f", c.Order.MinTotal))
+	}
+	if c.Order.TaxRate < 0 || c.Order.TaxRate > 1 {
+		errs = append(errs, fmt.Sprintf("ORDER_TAX_RATE must be between 0.0 and 1.0, got: %.4f", c.Order.TaxRate))
+	}
+	validPricePolicies := []string{"trust_client", "trust_catalog", "reject_on_mismatch"}
+	switch {
+	case !contains(validPricePolicies, c.Order.PricePolicy):
+		errs = append(errs, fmt.Sprintf("ORDER_PRICE_POLICY must be one of %v, got: %s", validPricePolicies, c.Order.PricePolicy))
+	case c.Order.PricePolicy != "trust_client" && strings.TrimSpace(c.ProductServiceURL) == "":
+		errs = append(errs, fmt.Sprintf("PRODUCT_SERVICE_URL is required when ORDER_PRICE_POLICY=%s", c.Order.PricePolicy))
+	}
+	if c.Order.StockPrecheck && strings.TrimSpace(c.InventoryServiceURL) == "" {
+		errs = append(errs, "INVENTORY_SERVICE_URL is required when ORDER_STOCK_PRECHECK=true")
+	}
+	if c.Order.MaxDistinctProducts < 0 {
+		errs = append(errs, fmt.Sprintf("ORDER_MAX_DISTINCT_PRODUCTS must be >= 0, got: %d", c.Order.MaxDistinctProducts))
+	}
+	if c.Order.MaxProductNameLength < 1 || c.Order.MaxProductNameLength > 255 {
+		errs = append(errs, fmt.Sprintf("ORDER_MAX_PRODUCT_NAME_LENGTH must be between 1 and 255, got: %d", c.Order.MaxProductNameLength))
+	}
+	if c.Order.PromotionMinUnits < 0 {
+		errs = append(errs, fmt.Sprintf("ORDER_PROMOTION_MIN_UNITS must be >= 0, got: %d", c.Order.PromotionMinUnits))
+	}
+	if c.Order.PromotionPercentOff < 0 || c.Order.PromotionPercentOff > 1 {
+		errs = append(errs, fmt.Sprintf("ORDER_PROMOTION_PERCENT_OFF must be between 0.0 and 1.0, got: %.4f", c.Order.PromotionPercentOff))
+	}
+	validRoundingModes := []string{"half_up", "half_even"}
+	if !contains(validRoundingModes, c.Order.RoundingMode) {
+		errs = append(errs, fmt.Sprintf("ORDER_ROUNDING_MODE must be one of %v, got: %s", validRoundingModes, c.Order.RoundingMode))
+	}
+	if c.Order.ExpressShippingSurcharge < 0 {
+		errs = append(errs, fmt.Sprintf("ORDER_EXPRESS_SHIPPING_SURCHARGE must be >= 0, got: %.2f", c.Order.ExpressShippingSurcharge))
+	}
+	if c.Order.PerUnitShippingRate < 0 {
+		errs = append(errs, fmt.Sprintf("ORDER_SHIPPING_PER_UNIT_RATE must be >= 0, got: %.2f", c.Order.PerUnitShippingRate))
+	}
+	if strategy == "free_over" && c.Order.FreeShippingThreshold <= 0 {
+		errs = append(errs, "ORDER_FREE_SHIPPING_THRESHOLD must be > 0 when SHIPPING_STRATEGY=free_over")
+	}
+	// Only free_over applies the threshold: a threshold next to another explicit strategy would be ignored
+	if strategy != "free_over" && c.Order.FreeShippingThreshold > 0 {
+		errs = append(errs, fmt.Sprintf("ORDER_FREE_SHIPPING_THRESHOLD is only applied with SHIPPING_STRATEGY=free_over, got: %s", strategy))
+	}
+	if c.Order.DeliveryBaseDays < 0 {
+		errs = append(errs, fmt.Sprintf("ORDER_DELIVERY_BASE_DAYS must be >= 0, got: %d", c.Order.DeliveryBaseDays))
+	}
+	if c.Order.AsyncCreate {
+		if c.Order.QueueSize < 1 {
+			errs = append(errs, fmt.Sprintf("ORDER_QUEUE_SIZE must be >= 1, got: %d", c.Order.QueueSize))
 		}
+		if c.Order.QueueWorkers < 1 {
+			errs = append(errs, fmt.Sprintf("ORDER_QUEUE_WORKERS must be >= 1, got: %d", c.Order.QueueWorkers))
+		}
+	}
+	if c.Order.Drafts && c.Order.DraftTTL <= 0 {
+		errs = append(errs, fmt.Sprintf("ORDER_DRAFT_TTL must be > 0 when ORDER_DRAFTS=true, got: %s", c.Order.DraftTTL))
+	}
+	if c.Order.Transitions != "" && c.Order.TransitionsFile != "" {
+		errs = append(errs, "ORDER_TRANSITIONS and ORDER_TRANSITIONS_FILE are mutually exclusive")
+	}
+	if c.Order.CacheTTL < 0 {
+		errs = append(errs, fmt.Sprintf("ORDER_CACHE_TTL must be >= 0, got: %s", c.Order.CacheTTL))
+	}
+	if c.Order.CacheTTL > 0 && c.Order.CacheMaxEntries < 1 {
+		errs = append(errs, fmt.Sprintf("ORDER_CACHE_MAX_ENTRIES must be >= 1 when ORDER_CACHE_TTL is set, got: %d", c.Order.CacheMaxEntries))
+	}
+	return errs
+}
+
+func (c *Config) validatePagination() []string {
+	var errs []string
+	if c.Pagination.MaxPageSize < 1 {
+		errs = append(errs, fmt.Sprintf("MAX_PAGE_SIZE must be >= 1, got: %d", c.Pagination.MaxPageSize))
+	}
+	if c.Pagination.DefaultPageSize < 1 || c.Pagination.DefaultPageSize > c.Pagination.MaxPageSize {
+		errs = append(errs, fmt.Sprintf("DEFAULT_PAGE_SIZE must be between 1 and MAX_PAGE_SIZE (%d), got: %d",
+			c.Pagination.MaxPageSize, c.Pagination.DefaultPageSize))
+	}
+	return errs
+}
+
+func (c *Config) validateCORS() []string {
+	var errs []string
+	for _, origin := range c.CORS.Origins() {
+		if origin == "*" {
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.Path != "" || u.RawQuery != "" {
+			errs = append(errs, fmt.Sprintf("CORS_ALLOWED_ORIGINS entries must be \"*\" or scheme://host[:port] without a path, got: %q", origin))
+		}
+	}
+	if len(c.CORS.Methods()) == 0 {
+		errs = append(errs, "CORS_ALLOWED_METHODS must list at least one method")
+	}
+	if c.CORS.MaxAge < 0 {
+		errs = append(errs, fmt.Sprintf("CORS_MAX_AGE must be >= 0, got: %s", c.CORS.MaxAge))
+	}
+	return errs
+}
+
+func (c *Config) validateReconciliation() []string {
+	if !c.Reconciliation.Enabled {
+		return nil
+	}
+	var errs []string
+	if c.Reconciliation.Interval <= 0 {
+		errs = append(errs, "RECONCILE_INTERVAL must be a positive duration (e.g., '5m')")
+	}
+	if c.Reconciliation.Lookback <= 0 {
+		errs = append(errs, "RECONCILE_LOOKBACK must be a positive duration (e.g., '24h')")
 	}
 	return errs
 }
 
+// MissingDependencies returns the env var names of downstream service URLs that are not configured.
+// A missing shipping URL disables shipment aggregation; a missing cart URL disables cart clearing.
+func (c *Config) MissingDependencies() []string {
+	var missing []string
+	if strings.TrimSpace(c.ShippingServiceURL) == "" {
+		missing = append(missing, "SHIPPING_SERVICE_URL")
+	}
+	if strings.TrimSpace(c.CartServiceURL) == "" {
+		missing = append(missing, "CART_SERVICE_URL")
+	}
+	return missing
+}
+
 // IsDevelopment returns true if running in development environment
 func (c *Config) IsDevelopment() bool {
 	env := strings.ToLower(c.Service.Env)
@@ -293,6 +845,16 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getEnvAllowEmpty reads an environment variable, falling back to the default only when unset.
+// An explicitly empty value (e.g. SHIPPING_SERVICE_URL="") is returned as "" so operators
+// can disable an optional dependency.
+func getEnvAllowEmpty(key, defaultValue string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return strings.TrimSpace(value)
+	}
+	return defaultValue
+}
+
 // getEnvBool reads a boolean environment variable with a default fallback
 // Accepts: "true", "1", "yes" for true | "false", "0", "no" for false
 func getEnvBool(key string, defaultValue bool) bool {
@@ -332,6 +894,20 @@ func getEnvFloat(key string, defaultValue float64) float64 {
 	return floatValue
 }
 
+// getEnvDuration reads a Go duration environment variable (e.g., "30s", "5m") with a default fallback
+// Returns default if parsing fails
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return defaultValue
+	}
+	return d
+}
+
 // getEnvDurationSeconds reads a duration environment variable and returns seconds as int
 // Accepts Go duration format (e.g., "10s", "30s", "1m")
 // Default: 10 seconds
@@ -405,3 +981,14 @@ func contains(slice []string, item string) bool {
 	}
 	return false
 }
+
+// splitList splits a comma-separated list, trimming entries and dropping empty ones
+func splitList(list string) []string {
+	var items []string
+	for item := range strings.SplitSeq(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"maps"
+	"strings"
+	"testing"
+)
+
+func TestLoggingConfigParseRouteLevels(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    map[string]string
+		wantErr bool
+	}{
+		{name: "Unset", raw: "", want: map[string]string{}},
+		{
+			name: "Several routes",
+			raw:  "GET /order/v1/private/orders=debug, get /order/v1/private/orders/:id = DEBUG,POST /order/v1/private/orders=info,",
+			want: map[string]string{
+				"GET /order/v1/private/orders":     "debug",
+				"GET /order/v1/private/orders/:id": "debug",
+				"POST /order/v1/private/orders":    "info",
+			},
+		},
+		{name: "Missing level", raw: "GET /order/v1/private/orders", wantErr: true},
+		{name: "Missing method", raw: "/order/v1/private/orders=debug", wantErr: true},
+		{name: "Relative path", raw: "GET orders=debug", wantErr: true},
+		{name: "Level that would raise logs", raw: "GET /order/v1/private/orders=warn", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoggingConfig{RouteLevels: tt.raw}.ParseRouteLevels()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRouteLevels() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !maps.Equal(got, tt.want) {
+				t.Errorf("ParseRouteLevels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateOrderShippingThreshold(t *testing.T) {
+	tests := []struct {
+		strategy  string
+		threshold float64
+		wantErr   bool
+	}{
+		{strategy: "", threshold: 0},
+		{strategy: "", threshold: 50},
+		{strategy: "flat", threshold: 0},
+		{strategy: "flat", threshold: 50, wantErr: true},
+		{strategy: "per_item", threshold: 50, wantErr: true},
+		{strategy: "free_over", threshold: 50},
+		{strategy: "free_over", threshold: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		cfg := Load()
+		cfg.Order.ShippingStrategy = tt.strategy
+		cfg.Order.FreeShippingThreshold = tt.threshold
+
+		var thresholdErrs []string
+		for _, err := range cfg.validateOrder() {
+			if strings.Contains(err, "ORDER_FREE_SHIPPING_THRESHOLD") {
+				thresholdErrs = append(thresholdErrs, err)
+			}
+		}
+		if (len(thresholdErrs) > 0) != tt.wantErr {
+			t.Errorf("SHIPPING_STRATEGY=%q with threshold %v: errors %q, wantErr %v", tt.strategy, tt.threshold, thresholdErrs, tt.wantErr)
+		}
+	}
+}
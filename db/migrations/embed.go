@@ -0,0 +1,11 @@
+// Package migrations embeds the Flyway-style SQL migrations (sql/V{n}__{description}.sql)
+// so the service can apply them itself when RUN_MIGRATIONS=true.
+// The same files are shipped to the Flyway image built from this directory's Dockerfile.
+package migrations
+
+import "embed"
+
+// FS holds the embedded migration files under "sql/"
+//
+//go:embed sql/*.sql
+var FS embed.FS
@@ -0,0 +1,10 @@
+-- V10__order_weight.sql
+-- Optional per-unit item weight and the summed order weight, for weight-based shipping and customs
+-- Last Updated: 2026-10-16
+
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS weight DECIMAL(10, 3) NOT NULL DEFAULT 0 CHECK (weight >= 0);
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS total_weight DECIMAL(12, 3) NOT NULL DEFAULT 0 CHECK (total_weight >= 0);
+
+COMMENT ON COLUMN order_items.weight IS 'Per-unit weight in kg; 0 when unknown';
+COMMENT ON COLUMN orders.total_weight IS 'Sum of weight x quantity over active (non-cancelled) items, in kg';
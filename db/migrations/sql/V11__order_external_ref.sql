@@ -0,0 +1,10 @@
+-- V11__order_external_ref.sql
+-- Integrator-supplied order reference (e.g. a storefront order number), unique per user
+-- Last Updated: 2026-10-16
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS external_ref VARCHAR(100);
+
+-- NULLs never collide, so orders without a reference are unaffected
+CREATE UNIQUE INDEX IF NOT EXISTS orders_user_external_ref_key ON orders(user_id, external_ref);
+
+COMMENT ON COLUMN orders.external_ref IS 'Optional caller-supplied reference; NULL when absent';
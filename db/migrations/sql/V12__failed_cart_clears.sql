@@ -0,0 +1,15 @@
+-- V12__failed_cart_clears.sql
+-- Dead letters for post-order cart clears that failed after all retries; a reconciliation job retries them
+-- Last Updated: 2026-10-16
+
+CREATE TABLE IF NOT EXISTS failed_cart_clears (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,  -- References auth.users.id (cross-service reference, no FK)
+    order_id INTEGER NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    error TEXT NOT NULL DEFAULT '',  -- Last error returned by the cart service
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_failed_cart_clears_created ON failed_cart_clears(created_at);
+
+COMMENT ON TABLE failed_cart_clears IS 'Cart clears to retry; the order was committed, only the cart is stale';
@@ -0,0 +1,7 @@
+-- V13__order_estimated_delivery.sql
+-- Delivery date estimated at order time from the configured lead time; NULL for older orders
+-- Last Updated: 2026-10-16
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS estimated_delivery DATE;
+
+COMMENT ON COLUMN orders.estimated_delivery IS 'Order-time estimate (UTC date); the shipment estimate supersedes it once shipped';
@@ -0,0 +1,16 @@
+-- V14__order_tax.sql
+-- Per-item tax (rate and amount) and the order-level tax total, which now counts toward total
+-- Last Updated: 2026-10-16
+
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS tax_rate DECIMAL(6, 4) CHECK (tax_rate >= 0 AND tax_rate <= 1);
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS tax DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (tax >= 0);
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS tax DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (tax >= 0);
+
+-- Business rule: total should equal subtotal + shipping + tax
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS check_order_total;
+ALTER TABLE orders ADD CONSTRAINT check_order_total CHECK (total = subtotal + shipping + tax);
+
+COMMENT ON COLUMN order_items.tax_rate IS 'Tax rate applied to the item as a fraction (0.08 = 8%); NULL for orders placed before tax support';
+COMMENT ON COLUMN order_items.tax IS 'Tax on the item subtotal, rounded to cents';
+COMMENT ON COLUMN orders.tax IS 'Sum of tax over active (non-cancelled) items';
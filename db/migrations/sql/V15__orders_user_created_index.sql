@@ -0,0 +1,5 @@
+-- V15__orders_user_created_index.sql
+-- Serve per-user order lists (ORDER BY created_at DESC, id DESC) from one index, ties included
+-- Last Updated: 2026-10-16
+
+CREATE INDEX IF NOT EXISTS idx_orders_user_created ON orders(user_id, created_at DESC, id DESC);
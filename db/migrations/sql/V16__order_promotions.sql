@@ -0,0 +1,23 @@
+-- V16__order_promotions.sql
+-- Automatic promotions: the order-level discount, which now counts against total, and the
+-- promotions applied to each order
+-- Last Updated: 2026-10-16
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS discount DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (discount >= 0);
+
+-- Business rule: total should equal subtotal + shipping + tax - discount
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS check_order_total;
+ALTER TABLE orders ADD CONSTRAINT check_order_total CHECK (total = subtotal + shipping + tax - discount);
+
+CREATE TABLE IF NOT EXISTS order_promotions (
+    id SERIAL PRIMARY KEY,
+    order_id INTEGER NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    promotion_id VARCHAR(100) NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    amount DECIMAL(10, 2) NOT NULL CHECK (amount >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_promotions_order_id ON order_promotions(order_id);
+
+COMMENT ON COLUMN orders.discount IS 'Sum of automatic promotion amounts applied at order time';
+COMMENT ON TABLE order_promotions IS 'Automatic promotions applied to an order at creation';
@@ -0,0 +1,10 @@
+-- V17__purged_orders.sql
+-- Tombstones for hard-deleted (purged) orders, so a lookup can tell "purged" from "never existed"
+-- Last Updated: 2026-10-16
+
+CREATE TABLE IF NOT EXISTS purged_orders (
+    order_id INTEGER PRIMARY KEY,  -- No FK: the order row is gone
+    purged_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE purged_orders IS 'IDs of orders removed by the admin purge endpoint';
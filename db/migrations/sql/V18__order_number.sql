@@ -0,0 +1,35 @@
+-- V18__order_number.sql
+-- Human-friendly order numbers (ORD-2026-000123), independent of the internal ID
+-- Last Updated: 2026-10-16
+
+-- One sequence for all years: nextval never hands out a value twice, so concurrent inserts
+-- cannot collide without locking a counter row. Numbers grow past 6 digits instead of wrapping.
+CREATE SEQUENCE IF NOT EXISTS order_number_seq;
+
+CREATE OR REPLACE FUNCTION next_order_number(placed_at TIMESTAMP) RETURNS VARCHAR AS $$
+DECLARE
+    n BIGINT := nextval('order_number_seq');
+BEGIN
+    RETURN 'ORD-' || to_char(placed_at, 'YYYY') || '-' || lpad(n::text, greatest(6, length(n::text)), '0');
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS order_number VARCHAR(32);
+
+-- Backfill existing orders in ID order, numbered by the year they were placed
+DO $$
+DECLARE
+    o RECORD;
+BEGIN
+    FOR o IN SELECT id, created_at FROM orders WHERE order_number IS NULL ORDER BY id LOOP
+        UPDATE orders SET order_number = next_order_number(o.created_at) WHERE id = o.id;
+    END LOOP;
+END;
+$$;
+
+ALTER TABLE orders ALTER COLUMN order_number SET DEFAULT next_order_number((NOW() AT TIME ZONE 'UTC')::timestamp);
+ALTER TABLE orders ALTER COLUMN order_number SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS orders_order_number_key ON orders(order_number);
+
+COMMENT ON COLUMN orders.order_number IS 'Human-friendly order number ORD-<year>-<sequence>, assigned on insert';
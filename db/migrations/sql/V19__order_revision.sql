@@ -0,0 +1,8 @@
+-- V19__order_revision.sql
+-- Per-order revision counter, bumped whenever the order's items change; a cheap change token for
+-- clients and caches that does not collide like updated_at can
+-- Last Updated: 2026-10-16
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS revision INTEGER NOT NULL DEFAULT 1 CHECK (revision >= 1);
+
+COMMENT ON COLUMN orders.revision IS 'Starts at 1 on insert; incremented in the same transaction as every item change';
@@ -0,0 +1,7 @@
+-- V20__status_history_changed_by.sql
+-- Who made each status change, taken from the request's authenticated user
+-- Last Updated: 2026-10-16
+
+ALTER TABLE order_status_history ADD COLUMN IF NOT EXISTS changed_by VARCHAR(255);
+
+COMMENT ON COLUMN order_status_history.changed_by IS 'Authenticated user behind the change; NULL for system sources (payment_webhook, reconciliation) and rows before V20';
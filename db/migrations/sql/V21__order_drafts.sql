@@ -0,0 +1,6 @@
+-- V21__order_drafts.sql
+-- Draft orders (status 'draft') wait for their owner's confirmation and expire when left unconfirmed
+-- Last Updated: 2026-10-16
+
+-- The expiry worker scans old drafts; the partial index stays small since drafts are short-lived
+CREATE INDEX IF NOT EXISTS idx_orders_draft_created ON orders(created_at) WHERE status = 'draft';
@@ -0,0 +1,8 @@
+-- V22__order_item_sku.sql
+-- Optional stock-keeping unit per order line; inventory keys stock on SKU, not product ID
+-- Last Updated: 2026-10-16
+
+-- Nullable: lines ordered without a SKU (and all existing lines) keep NULL
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS sku VARCHAR(64);
+
+COMMENT ON COLUMN order_items.sku IS 'Stock-keeping unit of the line, NULL when the client sent none';
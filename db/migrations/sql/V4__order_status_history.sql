@@ -0,0 +1,17 @@
+-- V4__order_status_history.sql
+-- Audit trail of order status transitions (e.g. pending -> paid via payment webhook)
+-- Last Updated: 2026-10-16
+
+CREATE TABLE IF NOT EXISTS order_status_history (
+    id SERIAL PRIMARY KEY,
+    order_id INTEGER NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    from_status VARCHAR(50) NOT NULL,
+    to_status VARCHAR(50) NOT NULL,
+    source VARCHAR(50) NOT NULL,  -- Who/what triggered the change (e.g. 'payment_webhook', 'api')
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_status_history_order ON order_status_history(order_id, created_at);
+
+COMMENT ON TABLE order_status_history IS 'Append-only log of order status transitions';
+COMMENT ON COLUMN order_status_history.source IS 'Origin of the transition (payment_webhook, api, ...)';
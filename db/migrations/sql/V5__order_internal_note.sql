@@ -0,0 +1,7 @@
+-- V5__order_internal_note.sql
+-- Staff-only annotation on orders; exposed only through admin endpoints
+-- Last Updated: 2026-10-16
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS internal_note TEXT NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN orders.internal_note IS 'Internal staff note; never returned by customer-facing endpoints';
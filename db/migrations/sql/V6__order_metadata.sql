@@ -0,0 +1,7 @@
+-- V6__order_metadata.sql
+-- Free-form storefront key/value metadata on orders (extension point without new columns)
+-- Last Updated: 2026-10-16
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS metadata JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+COMMENT ON COLUMN orders.metadata IS 'String-to-string storefront metadata; size limits enforced by the service';
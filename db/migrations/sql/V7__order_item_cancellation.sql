@@ -0,0 +1,7 @@
+-- V7__order_item_cancellation.sql
+-- Partial cancellation: individual order items can be cancelled before the order ships
+-- Last Updated: 2026-10-16
+
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMP;
+
+COMMENT ON COLUMN order_items.cancelled_at IS 'When the item was cancelled; NULL for active items. Cancelled items are excluded from order totals';
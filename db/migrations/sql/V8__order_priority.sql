@@ -0,0 +1,7 @@
+-- V8__order_priority.sql
+-- Requested handling speed of an order (standard or express); express adds a shipping surcharge
+-- Last Updated: 2026-10-16
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS priority VARCHAR(20) NOT NULL DEFAULT 'standard';
+
+COMMENT ON COLUMN orders.priority IS 'standard | express; validated by the service';
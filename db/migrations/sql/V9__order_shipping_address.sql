@@ -0,0 +1,7 @@
+-- V9__order_shipping_address.sql
+-- Shipping address of an order; editable until the order is processed
+-- Last Updated: 2026-10-16
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS shipping_address JSONB;
+
+COMMENT ON COLUMN orders.shipping_address IS 'Shipping address (name, line1, line2, city, region, postal_code, country); NULL when not provided';
@@ -0,0 +1,58 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/multitracer"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DefaultAcquireTimeout is the default DB_ACQUIRE_TIMEOUT
+const DefaultAcquireTimeout = 5 * time.Second
+
+// SetAcquireTimeout bounds how long the pool waits for a free connection to timeout; 0 leaves
+// the wait bounded by the caller's context only. A timed-out acquire fails with
+// context.DeadlineExceeded while the caller's context is still live, which the repository
+// reports as domain.ErrDatabaseBusy. The statement run on the acquired connection is not bounded.
+//
+// pgxpool has no acquire timeout of its own; its AcquireTracer hook returns the context the
+// acquire waits with, which is where the deadline is set. An existing ConnConfig tracer is kept.
+func SetAcquireTimeout(poolCfg *pgxpool.Config, timeout time.Duration) {
+	if timeout <= 0 {
+		return
+	}
+	tracer := acquireTimeoutTracer{timeout: timeout}
+	if poolCfg.ConnConfig.Tracer != nil {
+		poolCfg.ConnConfig.Tracer = multitracer.New(poolCfg.ConnConfig.Tracer, tracer)
+		return
+	}
+	poolCfg.ConnConfig.Tracer = tracer
+}
+
+// acquireTimeoutTracer puts the acquire deadline on the context of each pool Acquire.
+// It implements pgx.QueryTracer (required by ConnConfig.Tracer) as a no-op and pgxpool.AcquireTracer.
+type acquireTimeoutTracer struct {
+	timeout time.Duration
+}
+
+// acquireCancelKey holds the CancelFunc of the acquire deadline, released in TraceAcquireEnd
+type acquireCancelKey struct{}
+
+func (t acquireTimeoutTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryStartData) context.Context {
+	return ctx
+}
+
+func (t acquireTimeoutTracer) TraceQueryEnd(context.Context, *pgx.Conn, pgx.TraceQueryEndData) {}
+
+func (t acquireTimeoutTracer) TraceAcquireStart(ctx context.Context, _ *pgxpool.Pool, _ pgxpool.TraceAcquireStartData) context.Context {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	return context.WithValue(ctx, acquireCancelKey{}, cancel)
+}
+
+func (t acquireTimeoutTracer) TraceAcquireEnd(ctx context.Context, _ *pgxpool.Pool, _ pgxpool.TraceAcquireEndData) {
+	if cancel, ok := ctx.Value(acquireCancelKey{}).(context.CancelFunc); ok {
+		cancel()
+	}
+}
@@ -0,0 +1,109 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgproto3"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// fakeServer accepts the startup of a client connection on conn and then ignores it until it closes.
+// Enough for pgxpool to open and hand out connections without a PostgreSQL server.
+func fakeServer(conn net.Conn) {
+	defer conn.Close()
+	backend := pgproto3.NewBackend(conn, conn)
+	if _, err := backend.ReceiveStartupMessage(); err != nil {
+		return
+	}
+	backend.Send(&pgproto3.AuthenticationOk{})
+	backend.Send(&pgproto3.BackendKeyData{ProcessID: 1, SecretKey: []byte{0, 0, 0, 1}})
+	backend.Send(&pgproto3.ReadyForQuery{TxStatus: 'I'})
+	if err := backend.Flush(); err != nil {
+		return
+	}
+	for {
+		if _, err := backend.Receive(); err != nil {
+			return
+		}
+	}
+}
+
+// newFakePool returns a pool of at most maxConns connections to fakeServer
+func newFakePool(t *testing.T, maxConns int, acquireTimeout time.Duration) *pgxpool.Pool {
+	t.Helper()
+	poolCfg, err := pgxpool.ParseConfig("postgres://app@127.0.0.1:5432/order?sslmode=disable")
+	if err != nil {
+		t.Fatal(err)
+	}
+	poolCfg.MaxConns = int32(maxConns) //nolint:gosec // small test constant
+	poolCfg.ConnConfig.DialFunc = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		client, server := net.Pipe()
+		go fakeServer(server)
+		return client, nil
+	}
+	SetAcquireTimeout(poolCfg, acquireTimeout)
+
+	pool, err := pgxpool.NewWithConfig(context.Background(), poolCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+	return pool
+}
+
+func TestSetAcquireTimeoutExhaustedPool(t *testing.T) {
+	const timeout = 50 * time.Millisecond
+	pool := newFakePool(t, 1, timeout)
+	ctx := context.Background()
+
+	held, err := pool.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+
+	start := time.Now()
+	_, err = pool.Acquire(ctx)
+	elapsed := time.Since(start)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Acquire() on an exhausted pool error = %v, want context.DeadlineExceeded", err)
+	}
+	if ctx.Err() != nil {
+		t.Fatalf("caller context was cancelled: %v", ctx.Err())
+	}
+	if elapsed < timeout || elapsed > 20*timeout {
+		t.Errorf("Acquire() gave up after %s, want about %s", elapsed, timeout)
+	}
+
+	// A connection released in time is handed out, and the deadline does not outlive the acquire
+	held.Release()
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("Acquire() after release error = %v", err)
+	}
+	defer conn.Release()
+	time.Sleep(2 * timeout)
+	if err := conn.Conn().PgConn().CheckConn(); err != nil {
+		t.Errorf("acquired connection unusable after the acquire timeout elapsed: %v", err)
+	}
+}
+
+func TestSetAcquireTimeoutDisabled(t *testing.T) {
+	pool := newFakePool(t, 1, 0)
+
+	held, err := pool.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	defer held.Release()
+
+	// Without a timeout the wait lasts as long as the caller's context
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	if _, err := pool.Acquire(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Acquire() error = %v, want context.Canceled from the caller", err)
+	}
+}
@@ -0,0 +1,90 @@
+// Package cache provides domain.OrderCache implementations.
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+)
+
+// MemoryOrderCache is an in-process domain.OrderCache with a TTL per entry and a bound on the
+// number of entries. Each replica has its own cache and only sees its own invalidations, so an
+// order changed through another replica can be served stale for up to the TTL.
+//
+// Orders are copied on the way in and out: neither the caller that stored an order nor one
+// that read it can change the cached entry.
+type MemoryOrderCache struct {
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+}
+
+type memoryEntry struct {
+	order     *domain.Order
+	expiresAt time.Time
+}
+
+// NewMemoryOrderCache returns a cache keeping each order for ttl, holding at most maxEntries orders
+func NewMemoryOrderCache(ttl time.Duration, maxEntries int) *MemoryOrderCache {
+	return &MemoryOrderCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		now:        time.Now,
+		entries:    make(map[string]memoryEntry),
+	}
+}
+
+// Get returns a copy of the cached order; expired entries are misses
+func (c *MemoryOrderCache) Get(ctx context.Context, id string) (*domain.Order, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[id]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(entry.expiresAt) {
+		delete(c.entries, id)
+		return nil, false
+	}
+	return entry.order.Clone(), true
+}
+
+// Set stores a copy of order. When the cache is full, expired entries are dropped first and
+// then an arbitrary one.
+func (c *MemoryOrderCache) Set(ctx context.Context, order *domain.Order) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if _, ok := c.entries[order.ID]; !ok && len(c.entries) >= c.maxEntries {
+		c.evict(now)
+	}
+	c.entries[order.ID] = memoryEntry{order: order.Clone(), expiresAt: now.Add(c.ttl)}
+}
+
+// Delete drops the order; deleting an order that is not cached is not an error
+func (c *MemoryOrderCache) Delete(ctx context.Context, id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, id)
+	return nil
+}
+
+// evict makes room for one entry; the caller holds c.mu
+func (c *MemoryOrderCache) evict(now time.Time) {
+	for id, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, id)
+		}
+	}
+	for id := range c.entries {
+		if len(c.entries) < c.maxEntries {
+			return
+		}
+		delete(c.entries, id)
+	}
+}
@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+)
+
+func TestMemoryOrderCache(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	cache := NewMemoryOrderCache(time.Minute, 10)
+	cache.now = func() time.Time { return now }
+
+	stored := &domain.Order{ID: "1", Status: domain.OrderStatusPending, Items: []domain.OrderItem{{ProductID: "101"}}}
+	cache.Set(ctx, stored)
+	stored.Status = domain.OrderStatusPaid
+
+	got, ok := cache.Get(ctx, "1")
+	if !ok {
+		t.Fatal("Get() missed a stored order")
+	}
+	if got.Status != domain.OrderStatusPending {
+		t.Errorf("Status = %q, want the status at Set time (pending)", got.Status)
+	}
+	got.Items[0].ProductID = "102"
+	if again, _ := cache.Get(ctx, "1"); again.Items[0].ProductID != "101" {
+		t.Errorf("changing a returned order changed the cached one: product %q", again.Items[0].ProductID)
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok := cache.Get(ctx, "1"); ok {
+		t.Error("Get() hit after the TTL expired")
+	}
+
+	cache.Set(ctx, &domain.Order{ID: "2"})
+	if err := cache.Delete(ctx, "2"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, ok := cache.Get(ctx, "2"); ok {
+		t.Error("Get() hit after Delete()")
+	}
+}
+
+func TestMemoryOrderCacheBounded(t *testing.T) {
+	ctx := context.Background()
+	cache := NewMemoryOrderCache(time.Minute, 2)
+
+	for _, id := range []string{"1", "2", "3"} {
+		cache.Set(ctx, &domain.Order{ID: id})
+	}
+	if got := len(cache.entries); got != 2 {
+		t.Errorf("entries = %d, want at most 2", got)
+	}
+	if _, ok := cache.Get(ctx, "3"); !ok {
+		t.Error("Get() missed the most recently stored order")
+	}
+}
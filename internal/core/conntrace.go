@@ -0,0 +1,64 @@
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// connectionTracer logs connection establishment at debug level (DB_TRACE_CONNECTIONS).
+// Through PgCat the backend PID identifies which server connection a client connection
+// was paired with, which is otherwise invisible to the application.
+// It implements pgx.QueryTracer (required by ConnConfig.Tracer) as a no-op and pgx.ConnectTracer.
+type connectionTracer struct {
+	logger *zap.Logger
+}
+
+func (t connectionTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryStartData) context.Context {
+	return ctx
+}
+
+func (t connectionTracer) TraceQueryEnd(context.Context, *pgx.Conn, pgx.TraceQueryEndData) {}
+
+func (t connectionTracer) TraceConnectStart(ctx context.Context, data pgx.TraceConnectStartData) context.Context {
+	t.logger.Debug("DB connection attempt",
+		zap.String("host", data.ConnConfig.Host),
+		zap.Uint16("port", data.ConnConfig.Port),
+		zap.Int("fallbacks", len(data.ConnConfig.Fallbacks)),
+	)
+	return ctx
+}
+
+func (t connectionTracer) TraceConnectEnd(_ context.Context, data pgx.TraceConnectEndData) {
+	if data.Err != nil {
+		t.logger.Debug("DB connection failed", zap.Error(data.Err))
+		return
+	}
+	t.logger.Debug("DB connection established", connFields(data.Conn)...)
+}
+
+// traceConnections installs connection logging on poolCfg: connects via the ConnConfig tracer,
+// acquire and release via pool hooks, each with the backend PID.
+func traceConnections(poolCfg *pgxpool.Config, logger *zap.Logger) {
+	poolCfg.ConnConfig.Tracer = connectionTracer{logger: logger}
+	poolCfg.PrepareConn = func(_ context.Context, conn *pgx.Conn) (bool, error) {
+		logger.Debug("DB connection acquired", connFields(conn)...)
+		return true, nil
+	}
+	poolCfg.AfterRelease = func(conn *pgx.Conn) bool {
+		logger.Debug("DB connection released", connFields(conn)...)
+		return true
+	}
+}
+
+// connFields describes conn for logs: backend PID and the address actually dialed
+func connFields(conn *pgx.Conn) []zap.Field {
+	pgConn := conn.PgConn()
+	fields := []zap.Field{zap.Uint32("backend_pid", pgConn.PID())}
+	if netConn := pgConn.Conn(); netConn != nil {
+		fields = append(fields, zap.String("remote_addr", netConn.RemoteAddr().String()))
+	}
+	return fields
+}
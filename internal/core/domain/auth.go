@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"context"
+	"slices"
+)
+
+// RoleAdmin is the auth-service role allowed to use admin operations
+const RoleAdmin = "admin"
+
+// AuthContext is the authenticated principal of a request, carried in its context.Context
+type AuthContext struct {
+	UserID string
+	Roles  []string
+}
+
+// HasRole reports whether the principal holds role
+func (a AuthContext) HasRole(role string) bool {
+	return slices.Contains(a.Roles, role)
+}
+
+// authContextKey is the context key of the request's AuthContext
+type authContextKey struct{}
+
+// WithAuthContext returns a copy of ctx carrying auth
+func WithAuthContext(ctx context.Context, auth AuthContext) context.Context {
+	return context.WithValue(ctx, authContextKey{}, auth)
+}
+
+// AuthFromContext returns the AuthContext stored in ctx; ok is false for unauthenticated
+// work such as background jobs
+func AuthFromContext(ctx context.Context) (auth AuthContext, ok bool) {
+	auth, ok = ctx.Value(authContextKey{}).(AuthContext)
+	return auth, ok
+}
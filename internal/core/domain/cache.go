@@ -0,0 +1,13 @@
+package domain
+
+import "context"
+
+// OrderCache is an optional read-through cache for single orders (e.g. backed by Redis).
+// Implementations must be safe for concurrent use and should expire entries (TTL) as a
+// backstop, but correctness relies on the service deleting an entry on every mutation.
+type OrderCache interface {
+	// Get returns the cached order; ok is false on a miss
+	Get(ctx context.Context, id string) (order *Order, ok bool)
+	Set(ctx context.Context, order *Order)
+	Delete(ctx context.Context, id string) error
+}
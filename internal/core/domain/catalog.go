@@ -0,0 +1,9 @@
+package domain
+
+import "context"
+
+// PriceCatalog looks up current catalog prices (e.g. in the product service).
+// Products the catalog does not know are absent from the returned map.
+type PriceCatalog interface {
+	Prices(ctx context.Context, productIDs []string) (map[string]float64, error)
+}
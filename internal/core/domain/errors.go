@@ -7,4 +7,10 @@ var (
 	ErrNotFound     = errors.New("resource not found")
 	ErrInvalidInput = errors.New("invalid input")
 	ErrConflict     = errors.New("resource conflict")
+	ErrGone         = errors.New("resource gone")     // existed but was permanently removed
+	ErrRetryable    = errors.New("transient failure") // the operation may succeed if run again from the start
+	ErrDatabaseBusy = errors.New("database busy")     // no pooled connection became free in time (DB_ACQUIRE_TIMEOUT)
+	// ErrCommitUnknown: the connection was lost during COMMIT, so the transaction may or may not have
+	// been applied. Unlike ErrRetryable, running the operation again could apply it twice.
+	ErrCommitUnknown = errors.New("commit outcome unknown")
 )
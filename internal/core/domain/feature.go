@@ -0,0 +1,22 @@
+package domain
+
+import "context"
+
+// FeatureFlags is the set of feature flags enabled for a request. Flags nothing consults are
+// carried along harmlessly, so a flag can be sent before the code reading it is deployed.
+type FeatureFlags map[string]bool
+
+// featureFlagsKey is the context key of the request's FeatureFlags
+type featureFlagsKey struct{}
+
+// WithFeatureFlags returns a copy of ctx carrying flags
+func WithFeatureFlags(ctx context.Context, flags FeatureFlags) context.Context {
+	return context.WithValue(ctx, featureFlagsKey{}, flags)
+}
+
+// IsEnabled reports whether flag is enabled for the request behind ctx. Work without flags in
+// its context (background jobs) has every flag disabled.
+func IsEnabled(ctx context.Context, flag string) bool {
+	flags, _ := ctx.Value(featureFlagsKey{}).(FeatureFlags)
+	return flags[flag]
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "context"
+
+// StockShortage is an order item the inventory cannot fill in full
+type StockShortage struct {
+	ProductID string `json:"product_id"`
+	SKU       string `json:"sku,omitempty"`
+	Requested int    `json:"requested"`
+	Available int    `json:"available"`
+}
+
+// InventoryChecker checks stock levels (e.g. in the inventory service) without reserving anything.
+// CheckAvailability returns every item whose quantity exceeds the stock of its product (and SKU,
+// when set); none when all items can be filled.
+type InventoryChecker interface {
+	CheckAvailability(ctx context.Context, items []OrderItem) ([]StockShortage, error)
+}
@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// StatusNotification describes a committed order status change the customer should hear about
+type StatusNotification struct {
+	OrderID    string      `json:"order_id"`
+	UserID     string      `json:"user_id"`
+	FromStatus OrderStatus `json:"from_status"`
+	ToStatus   OrderStatus `json:"to_status"`
+	ChangedAt  time.Time   `json:"changed_at"`
+}
+
+// Notifier delivers status change notifications to customers (email, SMS, push, ...).
+// Implementations must be safe for concurrent use. Notify is called outside any request
+// and may be retried, so delivery should tolerate duplicates.
+type Notifier interface {
+	Notify(ctx context.Context, n StatusNotification) error
+}
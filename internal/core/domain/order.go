@@ -1,30 +1,301 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// OrderStatus is the lifecycle state of an order.
+// It serializes to JSON and the database as its lowercase string value.
+type OrderStatus string
+
+// Order statuses
+const (
+	// OrderStatusDraft is an order created but not yet confirmed by its owner; drafts are not
+	// placed orders and expire if left unconfirmed
+	OrderStatusDraft      OrderStatus = "draft"
+	OrderStatusPending    OrderStatus = "pending"
+	OrderStatusPaid       OrderStatus = "paid"
+	OrderStatusProcessing OrderStatus = "processing"
+	OrderStatusShipped    OrderStatus = "shipped"
+	OrderStatusCompleted  OrderStatus = "completed"
+	OrderStatusCancelled  OrderStatus = "cancelled"
+)
+
+// Valid reports whether s is a known order status
+func (s OrderStatus) Valid() bool {
+	switch s {
+	case OrderStatusDraft,
+		OrderStatusPending,
+		OrderStatusPaid,
+		OrderStatusProcessing,
+		OrderStatusShipped,
+		OrderStatusCompleted,
+		OrderStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// String returns the status as a plain string
+func (s OrderStatus) String() string {
+	return string(s)
+}
+
+// ParseOrderStatus converts a raw string into an OrderStatus.
+// Input is trimmed and lowercased, so "Pending" parses as OrderStatusPending.
+// Returns ErrInvalidInput for unknown values.
+func ParseOrderStatus(raw string) (OrderStatus, error) {
+	status := OrderStatus(strings.ToLower(strings.TrimSpace(raw)))
+	if !status.Valid() {
+		return "", fmt.Errorf("unknown order status %q: %w", raw, ErrInvalidInput)
+	}
+	return status, nil
+}
+
+// OrderPriority is the handling speed requested for an order
+type OrderPriority string
+
+// Order priorities
+const (
+	OrderPriorityStandard OrderPriority = "standard"
+	OrderPriorityExpress  OrderPriority = "express"
+)
+
+// Valid reports whether p is a known order priority
+func (p OrderPriority) Valid() bool {
+	return p == OrderPriorityStandard || p == OrderPriorityExpress
+}
+
+// ParseOrderPriority converts a raw string into an OrderPriority.
+// Input is trimmed and lowercased; an empty value means OrderPriorityStandard.
+// Returns ErrInvalidInput for unknown values.
+func ParseOrderPriority(raw string) (OrderPriority, error) {
+	priority := OrderPriority(strings.ToLower(strings.TrimSpace(raw)))
+	if priority == "" {
+		return OrderPriorityStandard, nil
+	}
+	if !priority.Valid() {
+		return "", fmt.Errorf("unknown order priority %q: %w", raw, ErrInvalidInput)
+	}
+	return priority, nil
+}
 
 // Order represents an order aggregate
 type Order struct {
-	ID        string      `json:"id"`
-	UserID    string      `json:"user_id"`
-	Status    string      `json:"status"`
-	Items     []OrderItem `json:"items"`
-	Subtotal  float64     `json:"subtotal"`
-	Shipping  float64     `json:"shipping"`
-	Total     float64     `json:"total"`
-	CreatedAt time.Time   `json:"created_at"`
+	ID string `json:"id"`
+	// OrderNumber is the human-friendly number (ORD-2026-000123) assigned on insert
+	OrderNumber string        `json:"order_number"`
+	UserID      string        `json:"user_id"`
+	Status      OrderStatus   `json:"status"`
+	Priority    OrderPriority `json:"priority"`
+	Items       []OrderItem   `json:"items"`
+	Subtotal    float64       `json:"subtotal"`
+	Shipping    float64       `json:"shipping"`
+	// Tax is the summed tax of the active items
+	Tax float64 `json:"tax"`
+	// Discount is the summed amount of the automatic promotions applied at order time
+	Discount float64 `json:"discount"`
+	Total    float64 `json:"total"`
+	// Promotions lists the promotions behind Discount; only loaded for single-order reads
+	Promotions []AppliedPromotion `json:"promotions,omitempty"`
+	// TotalWeight is the summed weight (kg) of the active items; 0 when items carry no weight
+	TotalWeight float64   `json:"total_weight"`
+	CreatedAt   time.Time `json:"created_at"`
+	// Metadata holds storefront-specific key/value pairs (stored as JSONB)
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// ShippingAddress is nil for orders placed without one (stored as JSONB)
+	ShippingAddress *ShippingAddress `json:"shipping_address,omitempty"`
+	// ExternalRef is the caller's own reference for the order, unique per user ("" if none)
+	ExternalRef string `json:"external_ref,omitempty"`
+	// EstimatedDelivery is the delivery date (UTC midnight) estimated at order time; nil for
+	// orders placed before estimates existed
+	EstimatedDelivery *time.Time `json:"estimated_delivery,omitempty"`
+	// Revision starts at 1 and is incremented whenever the order's items change; clients can
+	// compare it to detect changes instead of updated_at
+	Revision int `json:"revision"`
+}
+
+// Clone returns a deep copy of o, so the copy can be changed without affecting o
+func (o *Order) Clone() *Order {
+	clone := *o
+	if o.Items != nil {
+		clone.Items = make([]OrderItem, len(o.Items))
+		for i, item := range o.Items {
+			if item.TaxRate != nil {
+				rate := *item.TaxRate
+				item.TaxRate = &rate
+			}
+			clone.Items[i] = item
+		}
+	}
+	clone.Promotions = slices.Clone(o.Promotions)
+	clone.Metadata = maps.Clone(o.Metadata)
+	if o.ShippingAddress != nil {
+		address := *o.ShippingAddress
+		clone.ShippingAddress = &address
+	}
+	if o.EstimatedDelivery != nil {
+		delivery := *o.EstimatedDelivery
+		clone.EstimatedDelivery = &delivery
+	}
+	return &clone
+}
+
+// ShippingAddress is where an order is delivered. Country is an ISO 3166-1 alpha-2 code.
+type ShippingAddress struct {
+	Name       string `json:"name"`
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2,omitempty"`
+	City       string `json:"city"`
+	Region     string `json:"region,omitempty"`
+	PostalCode string `json:"postal_code"`
+	Country    string `json:"country"`
+}
+
+// InternalNote is a staff-only annotation on an order.
+// It is deliberately not a field of Order so customer-facing responses can never include it.
+type InternalNote struct {
+	OrderID string `json:"order_id"`
+	Note    string `json:"internal_note"`
 }
 
 // OrderItem represents an item in an order
 type OrderItem struct {
-	ProductID   string  `json:"product_id"`
-	ProductName string  `json:"product_name"`
-	Quantity    int     `json:"quantity"`
-	Price       float64 `json:"price"`
-	Subtotal    float64 `json:"subtotal"`
+	ProductID   string `json:"product_id"`
+	ProductName string `json:"product_name"`
+	// SKU is the optional stock-keeping unit the inventory decrements; one product can have several
+	SKU      string  `json:"sku,omitempty"`
+	Quantity int     `json:"quantity"`
+	Price    float64 `json:"price"`
+	Subtotal float64 `json:"subtotal"`
+	// Weight is the optional per-unit weight in kg; it counts Quantity times toward the order weight
+	Weight float64 `json:"weight,omitempty"`
+	// TaxRate is the item's tax rate as a fraction (0.08 = 8%). On requests nil means the
+	// order-level default rate and 0 marks the item tax-exempt; priced items carry the rate applied.
+	TaxRate *float64 `json:"tax_rate,omitempty"`
+	// Tax is TaxRate times Subtotal, rounded to cents
+	Tax float64 `json:"tax,omitempty"`
+	// Cancelled items stay on the order for the record but are excluded from its totals
+	Cancelled bool `json:"cancelled,omitempty"`
+}
+
+// OrderQuote is the priced breakdown of a cart without a persisted order
+type OrderQuote struct {
+	Priority OrderPriority `json:"priority"`
+	Items    []OrderItem   `json:"items"`
+	Subtotal float64       `json:"subtotal"`
+	Shipping float64       `json:"shipping"`
+	Tax      float64       `json:"tax"`
+	Discount float64       `json:"discount"`
+	Total    float64       `json:"total"`
+	// TotalWeight is the summed weight (kg) of the items
+	TotalWeight float64 `json:"total_weight"`
+	// Promotions lists the automatic promotions behind Discount
+	Promotions []AppliedPromotion `json:"promotions,omitempty"`
+}
+
+// ShippingEstimate is the shipping charge and delivery date a cart would get if ordered now
+type ShippingEstimate struct {
+	Priority OrderPriority `json:"priority"`
+	Shipping float64       `json:"shipping"`
+	// EstimatedDelivery is the delivery date (UTC midnight), as CreateOrder would set it
+	EstimatedDelivery time.Time `json:"estimated_delivery"`
+}
+
+// AppliedPromotion is an automatic promotion that matched an order, with the amount it took off
+type AppliedPromotion struct {
+	ID          string  `json:"id"`
+	Description string  `json:"description"`
+	Amount      float64 `json:"amount"`
+}
+
+// OrderStatusInfo is the lightweight status of an order, for clients polling for changes
+type OrderStatusInfo struct {
+	UserID    string      `json:"-"` // owner, for access checks only
+	Status    OrderStatus `json:"status"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// OrderActions lists what can happen next to an order in its current status
+type OrderActions struct {
+	OrderID      string        `json:"order_id"`
+	Status       OrderStatus   `json:"status"`
+	NextStatuses []OrderStatus `json:"next_statuses"`
+	Actions      []string      `json:"actions"`
+}
+
+// Page describes offset-based pagination of a result set
+type Page struct {
+	Limit  int
+	Offset int
+}
+
+// OrderCursor is a keyset position in orders sorted by (CreatedAt, ID).
+// Scans resume strictly after it, so rows are never skipped or repeated across batches.
+type OrderCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// OrderUpdateCursor is a keyset position in orders sorted by (updated_at, ID), the order of
+// FindUpdatedSince. The zero cursor starts at the beginning.
+type OrderUpdateCursor struct {
+	UpdatedAt time.Time
+	ID        string
+}
+
+// OrderStats aggregates non-cancelled orders created at or after Since, for admin dashboards
+type OrderStats struct {
+	Since      time.Time `json:"since"`
+	OrderCount int       `json:"order_count"`
+	Revenue    float64   `json:"revenue"` // sum of order totals, shipping included
+}
+
+// PurgeResult reports an admin retention purge: Purged orders in Status last updated before Before
+// were deleted with their items and history
+type PurgeResult struct {
+	Status OrderStatus `json:"status"`
+	Before time.Time   `json:"before"`
+	Purged int         `json:"purged"`
+}
+
+// OrphanedItem is an order_items row whose order no longer exists, reported by the admin integrity check.
+// Such rows predate the ON DELETE CASCADE on order_items.order_id or were left by manual deletes.
+type OrphanedItem struct {
+	ID      string `json:"id"`
+	OrderID string `json:"order_id"` // the missing order
+	OrderItem
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// OrderSearchFilter narrows an admin search across all users
+type OrderSearchFilter struct {
+	UserID string
+}
+
+// StatusChange records a single order status transition
+type StatusChange struct {
+	OrderID    string      `json:"order_id"`
+	FromStatus OrderStatus `json:"from_status"`
+	ToStatus   OrderStatus `json:"to_status"`
+	Source     string      `json:"source"`
+	// ChangedBy is the authenticated user behind the change; empty for system sources (webhook, jobs)
+	ChangedBy string    `json:"changed_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 // CreateOrderRequest represents a request to create an order
 type CreateOrderRequest struct {
-	UserID string      `json:"user_id"`
-	Items  []OrderItem `json:"items" binding:"required"`
+	UserID   string            `json:"user_id"`
+	Items    []OrderItem       `json:"items" binding:"required"`
+	Metadata map[string]string `json:"metadata"`
+	// Priority is "standard" (default when empty) or "express"
+	Priority        string           `json:"priority"`
+	ShippingAddress *ShippingAddress `json:"shipping_address"`
+	// ExternalRef optionally correlates the order with the caller's system; reusing one is a conflict
+	ExternalRef string `json:"external_ref"`
 }
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseOrderStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    OrderStatus
+		wantErr bool
+	}{
+		{name: "pending", raw: "pending", want: OrderStatusPending},
+		{name: "processing", raw: "processing", want: OrderStatusProcessing},
+		{name: "shipped", raw: "shipped", want: OrderStatusShipped},
+		{name: "completed", raw: "completed", want: OrderStatusCompleted},
+		{name: "cancelled", raw: "cancelled", want: OrderStatusCancelled},
+		{name: "Mixed case is normalized", raw: "Pending", want: OrderStatusPending},
+		{name: "Surrounding whitespace is trimmed", raw: "  shipped ", want: OrderStatusShipped},
+		{name: "Typo", raw: "pendng", wantErr: true},
+		{name: "US spelling", raw: "canceled", wantErr: true},
+		{name: "Empty", raw: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseOrderStatus(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseOrderStatus(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidInput) {
+					t.Errorf("ParseOrderStatus(%q) error = %v, want ErrInvalidInput", tt.raw, err)
+				}
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseOrderStatus(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderStatusValid(t *testing.T) {
+	if !OrderStatusPending.Valid() {
+		t.Error("OrderStatusPending.Valid() = false, want true")
+	}
+	if OrderStatus("Pending").Valid() {
+		t.Error(`OrderStatus("Pending").Valid() = true, want false`)
+	}
+}
+
+func TestParseOrderPriority(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    OrderPriority
+		wantErr bool
+	}{
+		{name: "Empty defaults to standard", raw: "", want: OrderPriorityStandard},
+		{name: "standard", raw: "standard", want: OrderPriorityStandard},
+		{name: "Mixed case express", raw: " Express ", want: OrderPriorityExpress},
+		{name: "Unknown", raw: "overnight", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseOrderPriority(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseOrderPriority(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseOrderPriority(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderClone(t *testing.T) {
+	rate := 0.08
+	delivery := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	order := &Order{
+		ID:                "1",
+		Items:             []OrderItem{{ProductID: "101", Quantity: 1, TaxRate: &rate}},
+		Promotions:        []AppliedPromotion{{ID: "bulk", Amount: 1}},
+		Metadata:          map[string]string{"channel": "web"},
+		ShippingAddress:   &ShippingAddress{City: "Hanoi"},
+		EstimatedDelivery: &delivery,
+	}
+
+	clone := order.Clone()
+	clone.Items[0].Quantity = 5
+	*clone.Items[0].TaxRate = 0
+	clone.Promotions[0].Amount = 2
+	clone.Metadata["channel"] = "app"
+	clone.ShippingAddress.City = "Da Nang"
+	*clone.EstimatedDelivery = delivery.AddDate(0, 0, 1)
+
+	if order.Items[0].Quantity != 1 || *order.Items[0].TaxRate != 0.08 || order.Promotions[0].Amount != 1 ||
+		order.Metadata["channel"] != "web" || order.ShippingAddress.City != "Hanoi" || !order.EstimatedDelivery.Equal(delivery) {
+		t.Errorf("changing the clone changed the original: %+v", order)
+	}
+}
@@ -1,14 +1,77 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // OrderRepository defines the interface for order data access
 type OrderRepository interface {
+	// FindByID returns an order with its items; ErrNotFound if none, ErrGone if it was purged
 	FindByID(ctx context.Context, id string) (*Order, error)
-	FindByUserID(ctx context.Context, userID string) ([]Order, error)
+	// FindStatus returns an order's owner, status and last update without loading items; ErrNotFound if none
+	FindStatus(ctx context.Context, id string) (*OrderStatusInfo, error)
+	// FindItemsByOrderID returns an order's line items, cancelled ones included; none for an unknown order
+	FindItemsByOrderID(ctx context.Context, orderID string) ([]OrderItem, error)
+	// FindByOrderNumber returns the order with the given order number; ErrNotFound if none
+	FindByOrderNumber(ctx context.Context, number string) (*Order, error)
+	// FindByExternalRef returns the user's order with the given external reference; ErrNotFound if none
+	FindByExternalRef(ctx context.Context, userID, ref string) (*Order, error)
+	// FindByExternalRefs returns the user's orders whose external reference is one of refs, newest first.
+	// Items are not loaded; unknown references are simply absent.
+	FindByExternalRefs(ctx context.Context, userID string, refs []string) ([]Order, error)
+	// FindByUserID returns one page of the user's orders, newest first; drafts only with includeDrafts
+	FindByUserID(ctx context.Context, userID string, page Page, includeDrafts bool) ([]Order, error)
+	// CountByUserID counts the user's orders; drafts only with includeDrafts
+	CountByUserID(ctx context.Context, userID string, includeDrafts bool) (int, error)
+	// FindDraftsCreatedBefore returns the IDs of up to limit draft orders created before before, oldest first
+	FindDraftsCreatedBefore(ctx context.Context, before time.Time, limit int) ([]string, error)
+	// FindItemsByOrderIDs batch-loads items for several orders, keyed by order ID
+	FindItemsByOrderIDs(ctx context.Context, orderIDs []string) (map[string][]OrderItem, error)
 	Create(ctx context.Context, order *Order) error
-	UpdateStatus(ctx context.Context, id, status string) error
+	UpdateStatus(ctx context.Context, id string, status OrderStatus) error
+	// FindUpdatedSince returns up to limit orders in one of statuses updated at or after since that sort
+	// after cursor by (updated_at, id), in that order, and the cursor of the last one returned
+	FindUpdatedSince(ctx context.Context, since time.Time, statuses []OrderStatus, after OrderUpdateCursor, limit int) ([]Order, OrderUpdateCursor, error)
+	// FindInternalNote returns the staff-only note of an order ("" if unset)
+	FindInternalNote(ctx context.Context, id string) (string, error)
+	UpdateInternalNote(ctx context.Context, id string, note string) error
+	// FindCreatedBetween returns up to limit orders created in [from, to) that sort after cursor
+	// by (created_at, id), in that order. Items are not loaded.
+	FindCreatedBetween(ctx context.Context, from, to time.Time, after OrderCursor, limit int) ([]Order, error)
+	// AddFailedCartClear records a post-order cart clear that failed so it can be retried later
+	AddFailedCartClear(ctx context.Context, userID, orderID, reason string) error
+	// CountCreatedSince counts placed (not draft or cancelled) orders created at or after since
+	CountCreatedSince(ctx context.Context, since time.Time) (int, error)
+	// SumRevenueSince sums the totals of placed (not draft or cancelled) orders created at or after since
+	SumRevenueSince(ctx context.Context, since time.Time) (float64, error)
+	// Search returns one page of orders matching filter across all users, plus the total match count
+	Search(ctx context.Context, filter OrderSearchFilter, page Page) ([]Order, int, error)
+	// FindOrphanedItems returns one page of items whose order does not exist, oldest first, plus their total count
+	FindOrphanedItems(ctx context.Context, page Page) ([]OrphanedItem, int, error)
 
 	// Transaction support
+	// CreateWithTx returns ErrConflict when the user already has an order with order.ExternalRef
 	CreateWithTx(ctx context.Context, tx Transaction, order *Order) error
+	// LockOrderWithTx serializes mutations of one order: it waits for and takes a per-order lock held until tx ends
+	LockOrderWithTx(ctx context.Context, tx Transaction, id string) error
+	// FindStatusForUpdateWithTx returns the current status and locks the order row until tx ends
+	FindStatusForUpdateWithTx(ctx context.Context, tx Transaction, id string) (OrderStatus, error)
+	UpdateStatusWithTx(ctx context.Context, tx Transaction, id string, status OrderStatus) error
+	AddStatusHistoryWithTx(ctx context.Context, tx Transaction, change *StatusChange) error
+	FindItemsWithTx(ctx context.Context, tx Transaction, orderID string) ([]OrderItem, error)
+	// CancelItemWithTx marks the order's active items of productID cancelled; ErrNotFound if there are none
+	CancelItemWithTx(ctx context.Context, tx Transaction, orderID, productID string) error
+	// IncrementRevisionWithTx bumps the order's revision and returns the new value; ErrNotFound if no order
+	IncrementRevisionWithTx(ctx context.Context, tx Transaction, id string) (int, error)
+	UpdateShippingAddressWithTx(ctx context.Context, tx Transaction, id string, address ShippingAddress) error
+	UpdateTotalsWithTx(ctx context.Context, tx Transaction, id string, subtotal, shipping, tax, discount, total, totalWeight float64) error
+	// ReplacePromotionsWithTx replaces the promotions recorded for an order with promotions
+	ReplacePromotionsWithTx(ctx context.Context, tx Transaction, id string, promotions []AppliedPromotion) error
+	// FindStatusHistory returns an order's status transitions, oldest first
+	FindStatusHistory(ctx context.Context, orderID string) ([]StatusChange, error)
+	// PurgeWithTx hard-deletes up to limit orders in status last updated before before, together with
+	// their items and status history, and returns the deleted order IDs. Purged IDs are remembered
+	// so FindByID reports them as ErrGone.
+	PurgeWithTx(ctx context.Context, tx Transaction, status OrderStatus, before time.Time, limit int) ([]string, error)
 }
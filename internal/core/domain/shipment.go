@@ -0,0 +1,9 @@
+package domain
+
+import "context"
+
+// ShipmentStatusProvider looks up an order's shipment status in the shipping service.
+// found is false when the order has no shipment yet.
+type ShipmentStatusProvider interface {
+	ShipmentStatus(ctx context.Context, orderID string) (status string, found bool, err error)
+}
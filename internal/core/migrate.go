@@ -0,0 +1,229 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// migrationFilePattern matches Flyway versioned migrations: V{version}__{description}.sql
+var migrationFilePattern = regexp.MustCompile(`^V(\d+)__(\w+)\.sql$`)
+
+// Migration is a single versioned SQL migration
+type Migration struct {
+	Version     int
+	Description string
+	// Script is the file name, as Flyway records it
+	Script string
+	SQL    string
+	// Seed marks demo data (V{n}__seed_*.sql): applied by the Flyway job for local/dev/demo
+	// databases but never by the embedded runner unless WithSeedData is given
+	Seed bool
+}
+
+// LoadMigrations reads Flyway-named migrations from dir in fsys, sorted by version.
+// Files not matching V{n}__{description}.sql are ignored; duplicate versions are an error.
+func LoadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("read migrations dir: %w", err)
+	}
+
+	var migrations []Migration
+	seen := make(map[int]string)
+	for _, entry := range entries {
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.Atoi(match[1])
+		if err != nil {
+			return nil, fmt.Errorf("parse migration version %q: %w", entry.Name(), err)
+		}
+		if prev, dup := seen[version]; dup {
+			return nil, fmt.Errorf("duplicate migration version %d: %s and %s", version, prev, entry.Name())
+		}
+		seen[version] = entry.Name()
+
+		sql, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("read migration %q: %w", entry.Name(), err)
+		}
+		migrations = append(migrations, Migration{
+			Version:     version,
+			Description: match[2],
+			Script:      entry.Name(),
+			SQL:         string(sql),
+			Seed:        strings.HasPrefix(match[2], "seed"),
+		})
+	}
+
+	slices.SortFunc(migrations, func(a, b Migration) int { return a.Version - b.Version })
+	return migrations, nil
+}
+
+// flywayChecksum is the checksum Flyway records for m: the CRC32 of the script's lines without
+// their line breaks (and without a leading BOM), as a signed 32-bit integer. Flyway validates
+// applied migrations against it, so rows written here must carry the same value.
+func (m Migration) flywayChecksum() int32 {
+	sql := strings.TrimPrefix(m.SQL, "\uFEFF")
+	sql = strings.NewReplacer("\r", "", "\n", "").Replace(sql)
+	return int32(crc32.ChecksumIEEE([]byte(sql)))
+}
+
+// flywayDescription is the description Flyway records for m: underscores read as spaces
+func (m Migration) flywayDescription() string {
+	return strings.ReplaceAll(m.Description, "_", " ")
+}
+
+// MigrateOption customizes RunMigrations
+type MigrateOption func(*migrateOptions)
+
+type migrateOptions struct {
+	seedData bool
+}
+
+// WithSeedData applies seed migrations too, for test and demo databases. Without it they are
+// recorded as applied without being run, as Flyway's skipExecutingMigrations does, so a later
+// Flyway run does not stop on them as missing.
+func WithSeedData() MigrateOption {
+	return func(o *migrateOptions) {
+		o.seedData = true
+	}
+}
+
+// RunMigrations applies pending migrations in version order and returns the versions applied.
+// Seed migrations are skipped unless WithSeedData is given.
+//
+// Applied versions are read from and recorded in Flyway's flyway_schema_history table (created
+// if missing, in Flyway's layout), so the embedded runner and the Flyway job can be used on the
+// same database and neither re-applies what the other did. Each migration runs in its own
+// transaction holding pg_advisory_xact_lock and a lock on the history table, so concurrent
+// replicas starting together, and a Flyway run, apply each version exactly once. Transaction-
+// scoped locks are used (not a session lock) because PgCat runs in transaction mode and does not
+// pin sessions. Re-running is a no-op.
+func RunMigrations(ctx context.Context, pool *pgxpool.Pool, migrations []Migration, opts ...MigrateOption) ([]int, error) {
+	var options migrateOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if err := createHistoryTable(ctx, pool); err != nil {
+		return nil, fmt.Errorf("create flyway_schema_history: %w", err)
+	}
+
+	var applied []int
+	for _, m := range migrations {
+		ok, err := applyMigration(ctx, pool, m, !m.Seed || options.seedData)
+		if err != nil {
+			return applied, fmt.Errorf("apply migration %s: %w", m.Script, err)
+		}
+		if ok {
+			applied = append(applied, m.Version)
+		}
+	}
+	return applied, nil
+}
+
+// migrationLockKey is the advisory lock key shared by all order-service migrators
+const migrationLockKey = 0x6f726465 // "orde"
+
+// createHistoryTable creates flyway_schema_history as Flyway would for PostgreSQL. The advisory
+// lock keeps replicas starting together from racing on CREATE TABLE IF NOT EXISTS.
+func createHistoryTable(ctx context.Context, pool *pgxpool.Pool) error {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }() // Rollback if not committed
+
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", migrationLockKey); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	_, err = tx.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS flyway_schema_history (
+			installed_rank INT NOT NULL CONSTRAINT flyway_schema_history_pk PRIMARY KEY,
+			version VARCHAR(50),
+			description VARCHAR(200) NOT NULL,
+			type VARCHAR(20) NOT NULL,
+			script VARCHAR(1000) NOT NULL,
+			checksum INT,
+			installed_by VARCHAR(100) NOT NULL,
+			installed_on TIMESTAMP NOT NULL DEFAULT now(),
+			execution_time INT NOT NULL,
+			success BOOLEAN NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, "CREATE INDEX IF NOT EXISTS flyway_schema_history_s_idx ON flyway_schema_history (success)"); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// applyMigration runs m unless Flyway's history already has it (or a baseline at or above it)
+// and records it; with execute false m is only recorded. Returns true if m was run now.
+// A failed migration left in the history by Flyway is an error: it needs a flyway repair first.
+func applyMigration(ctx context.Context, pool *pgxpool.Pool, m Migration, execute bool) (bool, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }() // Rollback if not committed
+
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", migrationLockKey); err != nil {
+		return false, fmt.Errorf("acquire migration lock: %w", err)
+	}
+	// Flyway inserts its history rows under its own lock; this one makes it wait for ours
+	if _, err := tx.Exec(ctx, "LOCK TABLE flyway_schema_history IN SHARE ROW EXCLUSIVE MODE"); err != nil {
+		return false, fmt.Errorf("lock flyway_schema_history: %w", err)
+	}
+
+	var rows int
+	var succeeded bool
+	err = tx.QueryRow(ctx, `
+		SELECT count(*), COALESCE(bool_and(success), true)
+		FROM flyway_schema_history
+		WHERE version = $1
+		   OR (type = 'BASELINE' AND CASE WHEN version ~ '^[0-9]+$' THEN version::int END >= $2)
+	`, strconv.Itoa(m.Version), m.Version).Scan(&rows, &succeeded)
+	if err != nil {
+		return false, err
+	}
+	if rows > 0 {
+		if !succeeded {
+			return false, errors.New("recorded as failed in flyway_schema_history; run flyway repair")
+		}
+		return false, nil
+	}
+
+	start := time.Now()
+	if execute {
+		if _, err := tx.Exec(ctx, m.SQL); err != nil {
+			return false, err
+		}
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO flyway_schema_history
+			(installed_rank, version, description, type, script, checksum, installed_by, execution_time, success)
+		SELECT COALESCE(MAX(installed_rank), 0) + 1, $1, $2, 'SQL', $3, $4, current_user, $5, true
+		FROM flyway_schema_history
+	`, strconv.Itoa(m.Version), m.flywayDescription(), m.Script, m.flywayChecksum(), time.Since(start).Milliseconds())
+	if err != nil {
+		return false, err
+	}
+
+	return execute, tx.Commit(ctx)
+}
@@ -0,0 +1,72 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/duynhne/order-service/db/migrations"
+	database "github.com/duynhne/order-service/internal/core"
+	"github.com/duynhne/order-service/internal/testutil/pgtest"
+)
+
+func TestRunMigrationsSharesFlywayHistory(t *testing.T) {
+	db := pgtest.New(t)
+	ctx := context.Background()
+	loaded, err := database.LoadMigrations(migrations.FS, "sql")
+	if err != nil {
+		t.Fatalf("LoadMigrations() error = %v", err)
+	}
+
+	var rows int
+	var seedDescription string
+	err = db.Pool.QueryRow(ctx, `
+		SELECT count(*) FILTER (WHERE success AND type = 'SQL'), max(description) FILTER (WHERE version = '2')
+		FROM flyway_schema_history
+	`).Scan(&rows, &seedDescription)
+	if err != nil {
+		t.Fatalf("read flyway_schema_history: %v", err)
+	}
+	if rows != len(loaded) || seedDescription != "seed orders" {
+		t.Errorf("history = %d rows, V2 %q; want %d rows, V2 \"seed orders\"", rows, seedDescription, len(loaded))
+	}
+
+	// Forget the seed as if the history came from a database migrated without it: it is recorded
+	// again but not run (its fixed IDs would clash with the rows already there)
+	if _, err := db.Pool.Exec(ctx, "DELETE FROM flyway_schema_history WHERE version = '2'"); err != nil {
+		t.Fatalf("delete V2 history row: %v", err)
+	}
+	applied, err := database.RunMigrations(ctx, db.Pool, loaded)
+	if err != nil {
+		t.Fatalf("RunMigrations() error = %v", err)
+	}
+	if len(applied) != 0 {
+		t.Errorf("RunMigrations() applied %v, want nothing", applied)
+	}
+	var recorded bool
+	if err := db.Pool.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM flyway_schema_history WHERE version = '2' AND success)").Scan(&recorded); err != nil {
+		t.Fatalf("read V2 history row: %v", err)
+	}
+	if !recorded {
+		t.Error("skipped seed migration not recorded in flyway_schema_history")
+	}
+}
+
+func TestRunMigrationsStopsOnFailedFlywayMigration(t *testing.T) {
+	db := pgtest.New(t)
+	ctx := context.Background()
+
+	_, err := db.Pool.Exec(ctx, `
+		INSERT INTO flyway_schema_history
+			(installed_rank, version, description, type, script, checksum, installed_by, execution_time, success)
+		SELECT max(installed_rank) + 1, '999', 'broken', 'SQL', 'V999__broken.sql', 0, current_user, 0, false
+		FROM flyway_schema_history
+	`)
+	if err != nil {
+		t.Fatalf("insert failed history row: %v", err)
+	}
+
+	broken := database.Migration{Version: 999, Description: "broken", Script: "V999__broken.sql", SQL: "SELECT 1"}
+	if applied, err := database.RunMigrations(ctx, db.Pool, []database.Migration{broken}); err == nil {
+		t.Errorf("RunMigrations() = %v, want an error for a migration Flyway recorded as failed", applied)
+	}
+}
@@ -0,0 +1,78 @@
+package database
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/duynhne/order-service/db/migrations"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"sql/V10__add_index.sql":  {Data: []byte("CREATE INDEX x;")},
+		"sql/V2__seed_orders.sql": {Data: []byte("INSERT 2;")},
+		"sql/V1__init_schema.sql": {Data: []byte("CREATE TABLE 1;")},
+		"sql/README.md":           {Data: []byte("ignored")},
+	}
+
+	got, err := LoadMigrations(fsys, "sql")
+	if err != nil {
+		t.Fatalf("LoadMigrations() error = %v", err)
+	}
+
+	wantVersions := []int{1, 2, 10}
+	if len(got) != len(wantVersions) {
+		t.Fatalf("LoadMigrations() returned %d migrations, want %d", len(got), len(wantVersions))
+	}
+	for i, v := range wantVersions {
+		if got[i].Version != v {
+			t.Errorf("migration[%d].Version = %d, want %d", i, got[i].Version, v)
+		}
+	}
+	if got[0].Description != "init_schema" || got[0].Script != "V1__init_schema.sql" || got[0].SQL != "CREATE TABLE 1;" {
+		t.Errorf("migration[0] = %+v, want init_schema with file contents", got[0])
+	}
+	if got[0].Seed || !got[1].Seed || got[2].Seed {
+		t.Errorf("Seed = %v, %v, %v, want only V2__seed_orders marked", got[0].Seed, got[1].Seed, got[2].Seed)
+	}
+}
+
+func TestMigrationFlywayHistoryFields(t *testing.T) {
+	m := Migration{Version: 4, Description: "order_status_history", SQL: "SELECT 1;\nSELECT 2;\n"}
+	if got := m.flywayDescription(); got != "order status history" {
+		t.Errorf("flywayDescription() = %q, want %q", got, "order status history")
+	}
+
+	// CRC32 of the lines without their breaks
+	const want = int32(-1665099012)
+	if got := m.flywayChecksum(); got != want {
+		t.Errorf("flywayChecksum() = %d, want %d", got, want)
+	}
+	for _, sql := range []string{"SELECT 1;\r\nSELECT 2;", "\uFEFFSELECT 1;\nSELECT 2;", "SELECT 1;\rSELECT 2;\r\n"} {
+		other := Migration{SQL: sql}
+		if got := other.flywayChecksum(); got != want {
+			t.Errorf("flywayChecksum(%q) = %d, want %d as for LF line breaks", sql, got, want)
+		}
+	}
+}
+
+func TestLoadMigrationsDuplicateVersion(t *testing.T) {
+	fsys := fstest.MapFS{
+		"sql/V1__a.sql": {Data: []byte("a")},
+		"sql/V1__b.sql": {Data: []byte("b")},
+	}
+
+	if _, err := LoadMigrations(fsys, "sql"); err == nil {
+		t.Fatal("LoadMigrations() error = nil, want duplicate version error")
+	}
+}
+
+func TestEmbeddedMigrationsLoad(t *testing.T) {
+	got, err := LoadMigrations(migrations.FS, "sql")
+	if err != nil {
+		t.Fatalf("LoadMigrations(embedded) error = %v", err)
+	}
+	if len(got) == 0 || got[0].Version != 1 {
+		t.Fatalf("LoadMigrations(embedded) = %d migrations, want V1 first", len(got))
+	}
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// isConnClosed reports whether err means the server connection was lost under the statement.
+// PgCat can drop a server connection between two statements of a session; pgx then reports
+// "conn closed", an unexpected EOF or a connection_exception (SQLSTATE class 08).
+func isConnClosed(err error) bool {
+	if err == nil {
+		return false
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// 08xxx connection_exception; 57P01 admin_shutdown is sent when the server side is terminated
+		return strings.HasPrefix(pgErr.Code, "08") || pgErr.Code == "57P01"
+	}
+	if pgconn.SafeToRetry(err) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, net.ErrClosed) {
+		return true
+	}
+	return strings.Contains(err.Error(), "conn closed")
+}
+
+// retryableTxError marks an error that lost the transaction's connection with domain.ErrRetryable:
+// the transaction is gone and cannot continue, but re-running it from Begin may succeed
+func retryableTxError(err error) error {
+	if isConnClosed(err) {
+		return fmt.Errorf("%w: %w", domain.ErrRetryable, err)
+	}
+	return err
+}
+
+// commitError marks a COMMIT that lost its connection with domain.ErrCommitUnknown, never ErrRetryable:
+// the server may have committed before the connection dropped, so re-running could apply it twice
+func commitError(err error) error {
+	if isConnClosed(err) {
+		return fmt.Errorf("%w: %w", domain.ErrCommitUnknown, err)
+	}
+	return err
+}
+
+// busyError marks an error from waiting on a pooled connection with domain.ErrDatabaseBusy.
+// database.Connect bounds the pool's acquire with DB_ACQUIRE_TIMEOUT, the only deadline set below
+// the caller's: a deadline error while ctx itself is still live means no connection became free.
+func busyError(ctx context.Context, err error) error {
+	if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+		return fmt.Errorf("%w: %w", domain.ErrDatabaseBusy, err)
+	}
+	return err
+}
+
+// readQuerier is the part of the pool read-only queries use
+type readQuerier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// retryingReader runs read-only queries, repeating a query once when its connection turned out
+// to be closed. The pool discards a closed connection, so the second attempt runs on a fresh one.
+// Writes must not go through it: a statement may have been applied before the connection dropped.
+type retryingReader struct {
+	q readQuerier
+}
+
+// Query retries when sending the query fails; errors while reading rows are returned as they are
+func (r retryingReader) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	rows, err := r.q.Query(ctx, sql, args...)
+	if isConnClosed(err) && ctx.Err() == nil {
+		rows, err = r.q.Query(ctx, sql, args...)
+	}
+	return rows, busyError(ctx, err)
+}
+
+// QueryRow defers the query to Scan, where pgx reports its errors, so it can be retried there
+func (r retryingReader) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return retryingRow{ctx: ctx, q: r.q, sql: sql, args: args}
+}
+
+type retryingRow struct {
+	ctx  context.Context
+	q    readQuerier
+	sql  string
+	args []any
+}
+
+func (row retryingRow) Scan(dest ...any) error {
+	err := row.q.QueryRow(row.ctx, row.sql, row.args...).Scan(dest...)
+	if isConnClosed(err) && row.ctx.Err() == nil {
+		err = row.q.QueryRow(row.ctx, row.sql, row.args...).Scan(dest...)
+	}
+	return busyError(row.ctx, err)
+}
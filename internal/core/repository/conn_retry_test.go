@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// errConnClosed is what pgx returns for a statement on a connection PgCat has dropped
+var errConnClosed = errors.New("conn closed")
+
+func TestIsConnClosed(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "conn closed", err: errConnClosed, want: true},
+		{name: "wrapped conn closed", err: fmt.Errorf("find order: %w", errConnClosed), want: true},
+		{name: "unexpected EOF", err: fmt.Errorf("read: %w", io.ErrUnexpectedEOF), want: true},
+		{name: "connection exception", err: &pgconn.PgError{Code: "08006"}, want: true},
+		{name: "admin shutdown", err: &pgconn.PgError{Code: "57P01"}, want: true},
+		{name: "unique violation", err: &pgconn.PgError{Code: "23505"}, want: false},
+		{name: "no rows", err: pgx.ErrNoRows, want: false},
+		{name: "canceled", err: context.Canceled, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isConnClosed(tt.err); got != tt.want {
+				t.Errorf("isConnClosed(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeRow returns err from Scan
+type fakeRow struct{ err error }
+
+func (r fakeRow) Scan(dest ...any) error { return r.err }
+
+// fakeQuerier answers the i-th query with errs[i] (nil once errs runs out)
+type fakeQuerier struct {
+	errs  []error
+	calls int
+}
+
+func (f *fakeQuerier) next() error {
+	i := f.calls
+	f.calls++
+	if i < len(f.errs) {
+		return f.errs[i]
+	}
+	return nil
+}
+
+func (f *fakeQuerier) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return nil, f.next()
+}
+
+func (f *fakeQuerier) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return fakeRow{err: f.next()}
+}
+
+func TestRetryingReader(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		errs      []error
+		wantErr   error
+		wantCalls int
+	}{
+		{name: "Success", ctx: context.Background(), wantCalls: 1},
+		{name: "Closed once is retried", ctx: context.Background(), errs: []error{errConnClosed}, wantCalls: 2},
+		{name: "Closed twice fails", ctx: context.Background(), errs: []error{errConnClosed, errConnClosed}, wantErr: errConnClosed, wantCalls: 2},
+		{name: "Other errors are not retried", ctx: context.Background(), errs: []error{pgx.ErrNoRows}, wantErr: pgx.ErrNoRows, wantCalls: 1},
+		{name: "Cancelled context is not retried", ctx: cancelled, errs: []error{errConnClosed}, wantErr: errConnClosed, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+"/QueryRow", func(t *testing.T) {
+			q := &fakeQuerier{errs: tt.errs}
+			err := retryingReader{q: q}.QueryRow(tt.ctx, "SELECT 1").Scan()
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("Scan() error = %v, want %v", err, tt.wantErr)
+			}
+			if q.calls != tt.wantCalls {
+				t.Errorf("queries = %d, want %d", q.calls, tt.wantCalls)
+			}
+		})
+		t.Run(tt.name+"/Query", func(t *testing.T) {
+			q := &fakeQuerier{errs: tt.errs}
+			_, err := retryingReader{q: q}.Query(tt.ctx, "SELECT 1")
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("Query() error = %v, want %v", err, tt.wantErr)
+			}
+			if q.calls != tt.wantCalls {
+				t.Errorf("queries = %d, want %d", q.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+// fakeTx fails every statement with err; methods it does not override panic
+type fakeTx struct {
+	pgx.Tx
+	err error
+}
+
+func (f fakeTx) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, f.err
+}
+
+func (f fakeTx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return fakeRow{err: f.err}
+}
+
+func (f fakeTx) Commit(ctx context.Context) error { return f.err }
+
+func TestPostgresTransactionMarksClosedConnRetryable(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		wantRetryable bool
+	}{
+		{name: "Closed connection", err: errConnClosed, wantRetryable: true},
+		{name: "Constraint violation", err: &pgconn.PgError{Code: "23505"}},
+		{name: "No rows", err: pgx.ErrNoRows},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			tx := &PostgresTransaction{tx: fakeTx{err: tt.err}}
+
+			errs := map[string]error{
+				"Exec": tx.Exec(ctx, "UPDATE orders SET status = 'paid'"),
+				"Scan": tx.QueryRow(ctx, "SELECT status FROM orders").Scan(),
+			}
+			_, errs["ExecRows"] = tx.ExecRows(ctx, "UPDATE orders SET status = 'paid'")
+
+			for op, err := range errs {
+				if !errors.Is(err, tt.err) {
+					t.Errorf("%s() error = %v, want it to wrap %v", op, err, tt.err)
+				}
+				if got := errors.Is(err, domain.ErrRetryable); got != tt.wantRetryable {
+					t.Errorf("%s() retryable = %v, want %v", op, got, tt.wantRetryable)
+				}
+			}
+		})
+	}
+}
+
+func TestPostgresTransactionCommitOutcomeUnknown(t *testing.T) {
+	ctx := context.Background()
+
+	// The commit may have been applied before the connection dropped: never ask for a re-run
+	err := (&PostgresTransaction{tx: fakeTx{err: errConnClosed}}).Commit(ctx)
+	if !errors.Is(err, domain.ErrCommitUnknown) || errors.Is(err, domain.ErrRetryable) {
+		t.Errorf("Commit() on closed conn error = %v, want ErrCommitUnknown and not ErrRetryable", err)
+	}
+
+	violation := &pgconn.PgError{Code: "23505"}
+	err = (&PostgresTransaction{tx: fakeTx{err: violation}}).Commit(ctx)
+	if !errors.Is(err, violation) || errors.Is(err, domain.ErrCommitUnknown) {
+		t.Errorf("Commit() constraint violation error = %v, want it unchanged", err)
+	}
+}
+
+func TestBusyError(t *testing.T) {
+	cancelled, cancel := context.WithTimeout(context.Background(), -1)
+	defer cancel()
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		err      error
+		wantBusy bool
+	}{
+		{name: "nil", ctx: context.Background(), err: nil},
+		{name: "Acquire timeout", ctx: context.Background(), err: context.DeadlineExceeded, wantBusy: true},
+		{name: "Wrapped acquire timeout", ctx: context.Background(), err: fmt.Errorf("batch statement 0: %w", context.DeadlineExceeded), wantBusy: true},
+		{name: "Request deadline", ctx: cancelled, err: context.DeadlineExceeded},
+		{name: "Other error", ctx: context.Background(), err: pgx.ErrNoRows},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := busyError(tt.ctx, tt.err)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("busyError() = %v, want it to wrap %v", err, tt.err)
+			}
+			if got := errors.Is(err, domain.ErrDatabaseBusy); got != tt.wantBusy {
+				t.Errorf("busyError() busy = %v, want %v", got, tt.wantBusy)
+			}
+		})
+	}
+
+	// Reads report the acquire timeout the same way
+	q := &fakeQuerier{errs: []error{context.DeadlineExceeded}}
+	if err := (retryingReader{q: q}).QueryRow(context.Background(), "SELECT 1").Scan(); !errors.Is(err, domain.ErrDatabaseBusy) {
+		t.Errorf("Scan() error = %v, want ErrDatabaseBusy", err)
+	}
+}
@@ -0,0 +1,413 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"github.com/duynhne/order-service/internal/testutil/pgtest"
+)
+
+func TestPostgresOrderRepositoryRoundTrip(t *testing.T) {
+	db := pgtest.New(t)
+	ctx := context.Background()
+
+	created := pgtest.NewOrder("42").
+		WithItem("101", 2, 19.99).WithSKU("SKU-101-RED").
+		WithItem("102", 1, 5.00).
+		WithMetadata("channel", "web").
+		Create(t, db)
+
+	got, err := db.Orders.FindByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("FindByID(%s) error = %v", created.ID, err)
+	}
+
+	if got.UserID != "42" || got.Status != domain.OrderStatusPending || got.Priority != domain.OrderPriorityStandard {
+		t.Errorf("FindByID() = %+v, want user 42, pending, standard", got)
+	}
+	if got.Subtotal != created.Subtotal || got.Total != created.Total {
+		t.Errorf("totals = %v/%v, want %v/%v", got.Subtotal, got.Total, created.Subtotal, created.Total)
+	}
+	if got.Metadata["channel"] != "web" {
+		t.Errorf("metadata = %v, want channel=web", got.Metadata)
+	}
+	if len(got.Items) != 2 || got.Items[0].ProductID != "101" || got.Items[0].Quantity != 2 {
+		t.Errorf("items = %+v, want 101 x2 then 102 x1", got.Items)
+	}
+	if len(got.Items) == 2 && (got.Items[0].SKU != "SKU-101-RED" || got.Items[1].SKU != "") {
+		t.Errorf("item SKUs = %q, %q, want SKU-101-RED and none", got.Items[0].SKU, got.Items[1].SKU)
+	}
+}
+
+func TestPostgresOrderRepositoryCreateWithTxRollback(t *testing.T) {
+	db := pgtest.New(t)
+	ctx := context.Background()
+
+	tx, err := db.TxManager.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+	order := pgtest.NewOrder("42").WithItem("101", 1, 10).Build()
+	if err := db.Orders.CreateWithTx(ctx, tx, order); err != nil {
+		t.Fatalf("CreateWithTx() error = %v", err)
+	}
+	if err := tx.Rollback(ctx); err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+
+	if _, err := db.Orders.FindByID(ctx, order.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("FindByID() after rollback error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestPostgresOrderRepositoryRevision(t *testing.T) {
+	db := pgtest.New(t)
+	ctx := context.Background()
+
+	order := pgtest.NewOrder("42").WithItem("101", 1, 10).WithItem("102", 1, 10).Create(t, db)
+	if order.Revision != 1 {
+		t.Fatalf("Create() revision = %d, want 1", order.Revision)
+	}
+
+	tx, err := db.TxManager.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+	if err := db.Orders.CancelItemWithTx(ctx, tx, order.ID, "101"); err != nil {
+		t.Fatalf("CancelItemWithTx() error = %v", err)
+	}
+	revision, err := db.Orders.IncrementRevisionWithTx(ctx, tx, order.ID)
+	if err != nil || revision != 2 {
+		t.Fatalf("IncrementRevisionWithTx() = %d, %v, want 2", revision, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+
+	got, err := db.Orders.FindByID(ctx, order.ID)
+	if err != nil || got.Revision != 2 {
+		t.Errorf("FindByID() revision = %+v, %v, want 2", got, err)
+	}
+}
+
+func TestPostgresOrderRepositoryLockOrderSerializes(t *testing.T) {
+	db := pgtest.New(t)
+	ctx := context.Background()
+	order := pgtest.NewOrder("42").WithItem("101", 1, 10).Create(t, db)
+
+	first, err := db.TxManager.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+	defer func() { _ = first.Rollback(ctx) }()
+	if err := db.Orders.LockOrderWithTx(ctx, first, order.ID); err != nil {
+		t.Fatalf("LockOrderWithTx(first) error = %v", err)
+	}
+
+	// Another order is not blocked by the first order's lock
+	other, err := db.TxManager.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+	if err := db.Orders.LockOrderWithTx(ctx, other, order.ID+"0"); err != nil {
+		t.Fatalf("LockOrderWithTx(other order) error = %v", err)
+	}
+	_ = other.Rollback(ctx)
+
+	// A concurrent transaction on the same order waits until the first one ends
+	var released atomic.Bool
+	acquired := make(chan error, 1)
+	go func() {
+		second, err := db.TxManager.Begin(ctx)
+		if err != nil {
+			acquired <- err
+			return
+		}
+		defer func() { _ = second.Rollback(ctx) }()
+		err = db.Orders.LockOrderWithTx(ctx, second, order.ID)
+		if err == nil && !released.Load() {
+			err = errors.New("second lock acquired while the first transaction still held it")
+		}
+		acquired <- err
+	}()
+
+	select {
+	case err := <-acquired:
+		t.Fatalf("second LockOrderWithTx() returned before the first transaction ended: %v", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+	released.Store(true)
+	if err := first.Commit(ctx); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	if err := <-acquired; err != nil {
+		t.Errorf("second LockOrderWithTx() error = %v", err)
+	}
+}
+
+func TestPostgresOrderRepositoryExternalRef(t *testing.T) {
+	db := pgtest.New(t)
+	ctx := context.Background()
+
+	first := pgtest.NewOrder("42").WithItem("101", 1, 10).Build()
+	first.ExternalRef = "shop-1001"
+	if err := db.Orders.Create(ctx, first); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	duplicate := pgtest.NewOrder("42").WithItem("102", 1, 10).Build()
+	duplicate.ExternalRef = "shop-1001"
+	if err := db.Orders.Create(ctx, duplicate); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("Create(duplicate ref) error = %v, want ErrConflict", err)
+	}
+
+	otherUser := pgtest.NewOrder("43").WithItem("101", 1, 10).Build()
+	otherUser.ExternalRef = "shop-1001"
+	if err := db.Orders.Create(ctx, otherUser); err != nil {
+		t.Errorf("Create(same ref, other user) error = %v", err)
+	}
+
+	got, err := db.Orders.FindByExternalRef(ctx, "42", "shop-1001")
+	if err != nil || got.ID != first.ID || got.ExternalRef != "shop-1001" {
+		t.Errorf("FindByExternalRef() = %+v, %v, want order %s", got, err, first.ID)
+	}
+	if _, err := db.Orders.FindByExternalRef(ctx, "42", "missing"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("FindByExternalRef(missing) error = %v, want ErrNotFound", err)
+	}
+
+	orders, err := db.Orders.FindByExternalRefs(ctx, "42", []string{"shop-1001", "missing"})
+	if err != nil || len(orders) != 1 || orders[0].ID != first.ID {
+		t.Errorf("FindByExternalRefs() = %+v, %v, want only order %s", orders, err, first.ID)
+	}
+}
+
+func TestPostgresOrderRepositoryOrderNumber(t *testing.T) {
+	db := pgtest.New(t)
+	ctx := context.Background()
+
+	const n = 10
+	orders := make([]*domain.Order, n)
+	var wg sync.WaitGroup
+	for i := range orders {
+		orders[i] = pgtest.NewOrder("42").WithItem("101", 1, 10).Build()
+		wg.Go(func() {
+			if err := db.Orders.Create(ctx, orders[i]); err != nil {
+				t.Errorf("Create() error = %v", err)
+			}
+		})
+	}
+	wg.Wait()
+
+	pattern := regexp.MustCompile(`^ORD-\d{4}-\d{6,}$`)
+	seen := make(map[string]bool, n)
+	for _, order := range orders {
+		if !pattern.MatchString(order.OrderNumber) {
+			t.Errorf("OrderNumber = %q, want ORD-<year>-<6+ digits>", order.OrderNumber)
+		}
+		if seen[order.OrderNumber] {
+			t.Errorf("OrderNumber %q assigned twice", order.OrderNumber)
+		}
+		seen[order.OrderNumber] = true
+
+		got, err := db.Orders.FindByOrderNumber(ctx, order.OrderNumber)
+		if err != nil || got.ID != order.ID || got.OrderNumber != order.OrderNumber {
+			t.Errorf("FindByOrderNumber(%q) = %+v, %v, want order %s", order.OrderNumber, got, err, order.ID)
+		}
+	}
+	if _, err := db.Orders.FindByOrderNumber(ctx, "ORD-1999-000001"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("FindByOrderNumber(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestPostgresOrderRepositoryFindOrphanedItems(t *testing.T) {
+	db := pgtest.New(t)
+	ctx := context.Background()
+
+	kept := pgtest.NewOrder("42").WithItem("101", 1, 10).Create(t, db)
+	deleted := pgtest.NewOrder("42").WithItem("102", 2, 5).WithSKU("SKU-102").WithItem("103", 1, 1).Create(t, db)
+
+	// Recreate the state left by deletes from before the cascade existed
+	if _, err := db.Pool.Exec(ctx, `ALTER TABLE order_items DROP CONSTRAINT order_items_order_id_fkey`); err != nil {
+		t.Fatalf("drop foreign key: %v", err)
+	}
+	if _, err := db.Pool.Exec(ctx, `DELETE FROM orders WHERE id = $1`, deleted.ID); err != nil {
+		t.Fatalf("delete order: %v", err)
+	}
+
+	items, total, err := db.Orders.FindOrphanedItems(ctx, domain.Page{Limit: 1})
+	if err != nil {
+		t.Fatalf("FindOrphanedItems() error = %v", err)
+	}
+	if total != 2 || len(items) != 1 {
+		t.Fatalf("FindOrphanedItems() = %d items of %d, want 1 of 2", len(items), total)
+	}
+	if items[0].OrderID != deleted.ID || items[0].ProductID != "102" || items[0].SKU != "SKU-102" || items[0].Quantity != 2 {
+		t.Errorf("first orphan = %+v, want order %s product 102 x2", items[0], deleted.ID)
+	}
+
+	items, _, err = db.Orders.FindOrphanedItems(ctx, domain.Page{Limit: 10, Offset: 1})
+	if err != nil || len(items) != 1 || items[0].ProductID != "103" {
+		t.Errorf("FindOrphanedItems(offset 1) = %+v, %v, want product 103", items, err)
+	}
+	for _, item := range items {
+		if item.OrderID == kept.ID {
+			t.Errorf("item of existing order %s reported as orphaned", kept.ID)
+		}
+	}
+}
+
+func TestPostgresOrderRepositoryFindByUserIDStableOrder(t *testing.T) {
+	db := pgtest.New(t)
+	ctx := context.Background()
+
+	for range 5 {
+		pgtest.NewOrder("77").WithItem("101", 1, 10).Create(t, db)
+	}
+	// Same created_at for every order: only the id tiebreaker orders them
+	if _, err := db.Pool.Exec(ctx, `UPDATE orders SET created_at = '2026-01-01 00:00:00' WHERE user_id = '77'`); err != nil {
+		t.Fatalf("set created_at: %v", err)
+	}
+
+	var ids []int
+	for offset := 0; offset < 6; offset += 2 {
+		page, err := db.Orders.FindByUserID(ctx, "77", domain.Page{Limit: 2, Offset: offset}, false)
+		if err != nil {
+			t.Fatalf("FindByUserID(offset %d) error = %v", offset, err)
+		}
+		for _, order := range page {
+			id, _ := strconv.Atoi(order.ID)
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) != 5 {
+		t.Fatalf("pages returned %d orders %v, want all 5 exactly once", len(ids), ids)
+	}
+	for i := 1; i < len(ids); i++ {
+		if ids[i] >= ids[i-1] {
+			t.Errorf("ids across pages = %v, want strictly descending", ids)
+			break
+		}
+	}
+}
+
+func TestPostgresOrderRepositoryFindUpdatedSincePages(t *testing.T) {
+	db := pgtest.New(t)
+	ctx := context.Background()
+
+	seedUserOrders(t, db, "78", 5)
+	// Same updated_at for every order: only the id tiebreaker moves the cursor on
+	if _, err := db.Pool.Exec(ctx, `UPDATE orders SET updated_at = NOW() - INTERVAL '1 minute' WHERE user_id = '78'`); err != nil {
+		t.Fatalf("set updated_at: %v", err)
+	}
+
+	since := time.Now().Add(-time.Hour)
+	statuses := []domain.OrderStatus{domain.OrderStatusPaid}
+	seen := make(map[string]bool)
+	var cursor domain.OrderUpdateCursor
+	for {
+		page, next, err := db.Orders.FindUpdatedSince(ctx, since, statuses, cursor, 2)
+		if err != nil {
+			t.Fatalf("FindUpdatedSince(%+v) error = %v", cursor, err)
+		}
+		for _, order := range page {
+			if order.UserID != "78" {
+				continue
+			}
+			if seen[order.ID] {
+				t.Errorf("order %s returned twice", order.ID)
+			}
+			seen[order.ID] = true
+		}
+		if len(page) < 2 {
+			break
+		}
+		cursor = next
+	}
+
+	if len(seen) != 5 {
+		t.Errorf("pages returned %d distinct orders, want all 5", len(seen))
+	}
+}
+
+// seedUserOrders inserts n paid orders for userID, one minute apart, in a single statement
+func seedUserOrders(tb testing.TB, db *pgtest.DB, userID string, n int) {
+	tb.Helper()
+	_, err := db.Pool.Exec(context.Background(), `
+		INSERT INTO orders (user_id, status, subtotal, shipping, total, created_at)
+		SELECT $1, 'paid', 10, 5, 15, NOW() - g * INTERVAL '1 minute'
+		FROM generate_series(1, $2::int) AS g
+	`, userID, n)
+	if err != nil {
+		tb.Fatalf("seed %d orders: %v", n, err)
+	}
+}
+
+func TestPostgresOrderRepositoryFindByUserIDUsesIndex(t *testing.T) {
+	db := pgtest.New(t)
+	ctx := context.Background()
+	seedUserOrders(t, db, "88", 1000)
+
+	tx, err := db.Pool.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+	// The test table is tiny; keep the planner from preferring a sequential scan regardless
+	if _, err := tx.Exec(ctx, `SET LOCAL enable_seqscan = off`); err != nil {
+		t.Fatalf("disable seqscan: %v", err)
+	}
+	if _, err := tx.Exec(ctx, `ANALYZE orders`); err != nil {
+		t.Fatalf("analyze: %v", err)
+	}
+
+	// Same predicate and order as FindByUserID
+	rows, err := tx.Query(ctx, `
+		EXPLAIN SELECT id FROM orders
+		WHERE user_id = '88' AND (false OR status <> 'draft')
+		ORDER BY created_at DESC, id DESC
+		LIMIT 100 OFFSET 0
+	`)
+	if err != nil {
+		t.Fatalf("explain: %v", err)
+	}
+	var plan []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			t.Fatalf("scan plan: %v", err)
+		}
+		plan = append(plan, line)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("read plan: %v", err)
+	}
+
+	text := strings.Join(plan, "\n")
+	if !strings.Contains(text, "idx_orders_user_created") || strings.Contains(text, "Sort") {
+		t.Errorf("FindByUserID plan does not walk idx_orders_user_created without sorting:\n%s", text)
+	}
+}
+
+// BenchmarkPostgresFindByUserID reads 1000-order pages of a user with a long history
+func BenchmarkPostgresFindByUserID(b *testing.B) {
+	db := pgtest.New(b)
+	ctx := context.Background()
+	seedUserOrders(b, db, "88", 5000)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		orders, err := db.Orders.FindByUserID(ctx, "88", domain.Page{Limit: 1000}, false)
+		if err != nil || len(orders) != 1000 {
+			b.Fatalf("FindByUserID() = %d orders, %v; want 1000", len(orders), err)
+		}
+	}
+}
@@ -2,89 +2,301 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
 	"strconv"
 	"time"
 
 	"github.com/duynhne/order-service/internal/core/domain"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
 )
 
 // PostgresOrderRepository implements OrderRepository using PostgreSQL with pgx
 type PostgresOrderRepository struct {
-	pool *pgxpool.Pool
+	pool   *pgxpool.Pool
+	reads  retryingReader // read-only queries; retried once on a closed connection
+	logger *zap.Logger
+
+	verifyOnRead bool // FindByID compares the stored subtotal with its items
+}
+
+// RepositoryOption configures optional PostgresOrderRepository behavior
+type RepositoryOption func(*PostgresOrderRepository)
+
+// WithLogger sets the logger for data-quality warnings (default: no-op)
+func WithLogger(logger *zap.Logger) RepositoryOption {
+	return func(r *PostgresOrderRepository) {
+		if logger != nil {
+			r.logger = logger
+		}
+	}
+}
+
+// WithVerifyOnRead makes FindByID compare the stored subtotal with the sum of the loaded
+// active items' subtotals and log a warning on mismatch. The read still succeeds;
+// the check only surfaces corruption left by past writes (ORDER_VERIFY_ON_READ).
+func WithVerifyOnRead(enabled bool) RepositoryOption {
+	return func(r *PostgresOrderRepository) {
+		r.verifyOnRead = enabled
+	}
 }
 
 // NewPostgresOrderRepository creates a new PostgreSQL order repository
-func NewPostgresOrderRepository(pool *pgxpool.Pool) *PostgresOrderRepository {
-	return &PostgresOrderRepository{pool: pool}
+func NewPostgresOrderRepository(pool *pgxpool.Pool, opts ...RepositoryOption) *PostgresOrderRepository {
+	r := &PostgresOrderRepository{pool: pool, reads: retryingReader{q: pool}, logger: zap.NewNop()}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// parseOrderID converts an order ID to the orders.id column type (INTEGER). Non-numeric or
+// out-of-range IDs return domain.ErrInvalidInput instead of reaching PostgreSQL as a cast error.
+func parseOrderID(id string) (int, error) {
+	n, err := strconv.ParseInt(id, 10, 32)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("order id %q: %w", id, domain.ErrInvalidInput)
+	}
+	return int(n), nil
 }
 
-// FindByID retrieves an order by ID
+// FindByID retrieves an order by ID; domain.ErrInvalidInput if id is not an integer order ID,
+// domain.ErrGone if the order was purged
 func (r *PostgresOrderRepository) FindByID(ctx context.Context, id string) (*domain.Order, error) {
+	orderID, err := parseOrderID(id)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-		SELECT id, user_id, status, subtotal, shipping, total, created_at
+		SELECT id, user_id, status, subtotal, shipping, total, created_at, metadata, priority, shipping_address, total_weight,
+			COALESCE(external_ref, ''), estimated_delivery, tax, discount, order_number, revision
 		FROM orders
 		WHERE id = $1
 	`
 
 	var order domain.Order
 	var idInt int
-	err := r.pool.QueryRow(ctx, query, id).Scan(
+	var subtotal, shipping *float64
+	err = r.reads.QueryRow(ctx, query, orderID).Scan(
 		&idInt,
 		&order.UserID,
 		&order.Status,
-		&order.Subtotal,
-		&order.Shipping,
+		&subtotal,
+		&shipping,
 		&order.Total,
 		&order.CreatedAt,
+		&order.Metadata,
+		&order.Priority, &order.ShippingAddress, &order.TotalWeight, &order.ExternalRef,
+		&order.EstimatedDelivery, &order.Tax, &order.Discount, &order.OrderNumber, &order.Revision,
 	)
 
 	if errors.Is(err, pgx.ErrNoRows) {
-		return nil, domain.ErrNotFound
+		return nil, r.missingOrderError(ctx, orderID)
 	}
 	if err != nil {
 		return nil, err
 	}
 
 	order.ID = strconv.Itoa(idInt)
+	normalizeTimestamps(&order)
+	order.Subtotal = r.amountOrZero(order.ID, "subtotal", subtotal)
+	order.Shipping = r.amountOrZero(order.ID, "shipping", shipping)
+
+	if order.Items, err = r.findItems(ctx, idInt); err != nil {
+		return nil, err
+	}
+
+	if order.Discount > 0 {
+		if order.Promotions, err = r.findPromotions(ctx, idInt); err != nil {
+			return nil, err
+		}
+	}
+
+	if r.verifyOnRead {
+		if itemsSubtotal, ok := subtotalMatchesItems(&order); !ok {
+			r.logger.Warn("Order subtotal does not match its items",
+				zap.String("order_id", order.ID),
+				zap.Float64("stored_subtotal", order.Subtotal),
+				zap.Float64("items_subtotal", itemsSubtotal),
+			)
+		}
+	}
+
+	return &order, nil
+}
+
+// FindItemsByOrderID returns an order's line items (cancelled ones included) in insertion order;
+// domain.ErrInvalidInput if orderID is not an integer order ID. An unknown order has no items.
+func (r *PostgresOrderRepository) FindItemsByOrderID(ctx context.Context, orderID string) ([]domain.OrderItem, error) {
+	id, err := parseOrderID(orderID)
+	if err != nil {
+		return nil, err
+	}
+	return r.findItems(ctx, id)
+}
 
-	// Get order items
-	itemsQuery := `
-		SELECT product_id, product_name, quantity, price, subtotal
+// findItems loads the line items of one order in insertion order
+func (r *PostgresOrderRepository) findItems(ctx context.Context, orderID int) ([]domain.OrderItem, error) {
+	query := `
+		SELECT product_id, product_name, COALESCE(sku, ''), quantity, price, subtotal, weight, tax_rate, tax, cancelled_at IS NOT NULL
 		FROM order_items
 		WHERE order_id = $1
+		ORDER BY id
 	`
 
-	rows, err := r.pool.Query(ctx, itemsQuery, idInt)
+	rows, err := r.reads.Query(ctx, query, orderID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
+	var items []domain.OrderItem
 	for rows.Next() {
 		var item domain.OrderItem
-		err := rows.Scan(&item.ProductID, &item.ProductName, &item.Quantity, &item.Price, &item.Subtotal)
+		err := rows.Scan(&item.ProductID, &item.ProductName, &item.SKU, &item.Quantity, &item.Price, &item.Subtotal, &item.Weight, &item.TaxRate, &item.Tax, &item.Cancelled)
 		if err != nil {
 			continue
 		}
-		order.Items = append(order.Items, item)
+		items = append(items, item)
 	}
+	return items, rows.Err()
+}
 
-	return &order, nil
+// missingOrderError returns domain.ErrGone when orderID was purged, domain.ErrNotFound otherwise
+func (r *PostgresOrderRepository) missingOrderError(ctx context.Context, orderID int) error {
+	var purged bool
+	err := r.reads.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM purged_orders WHERE order_id = $1)`, orderID).Scan(&purged)
+	if err != nil {
+		return err
+	}
+	if purged {
+		return domain.ErrGone
+	}
+	return domain.ErrNotFound
+}
+
+// findPromotions returns the promotions applied to an order, in the order they were applied
+func (r *PostgresOrderRepository) findPromotions(ctx context.Context, orderID int) ([]domain.AppliedPromotion, error) {
+	query := `
+		SELECT promotion_id, description, amount
+		FROM order_promotions
+		WHERE order_id = $1
+		ORDER BY id
+	`
+
+	rows, err := r.reads.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var promotions []domain.AppliedPromotion
+	for rows.Next() {
+		var promo domain.AppliedPromotion
+		if err := rows.Scan(&promo.ID, &promo.Description, &promo.Amount); err != nil {
+			return nil, err
+		}
+		promotions = append(promotions, promo)
+	}
+	return promotions, rows.Err()
+}
+
+// normalizeTimestamps converts the scanned timestamps of order to UTC so created_at always serializes
+// with a "Z" offset, whatever zone the driver attached. EstimatedDelivery is a DATE, already UTC midnight.
+func normalizeTimestamps(order *domain.Order) {
+	order.CreatedAt = order.CreatedAt.UTC()
+}
+
+// amountOrZero returns the scanned money column, or 0 with a warning when a legacy row holds NULL
+// (rows written before the NOT NULL constraints), so one bad row does not fail the whole read
+func (r *PostgresOrderRepository) amountOrZero(orderID, column string, amount *float64) float64 {
+	if amount == nil {
+		r.logger.Warn("Order amount is NULL, reading it as 0",
+			zap.String("order_id", orderID),
+			zap.String("column", column),
+		)
+		return 0
+	}
+	return *amount
+}
+
+// subtotalTolerance absorbs float rounding of DECIMAL(10, 2) amounts
+const subtotalTolerance = 0.005
+
+// subtotalMatchesItems sums the subtotals of order's active (non-cancelled) items and reports
+// whether the sum equals the stored order subtotal
+func subtotalMatchesItems(order *domain.Order) (float64, bool) {
+	var sum float64
+	for _, item := range order.Items {
+		if !item.Cancelled {
+			sum += item.Subtotal
+		}
+	}
+	return sum, math.Abs(sum-order.Subtotal) < subtotalTolerance
 }
 
-// FindByUserID retrieves all orders for a user
-func (r *PostgresOrderRepository) FindByUserID(ctx context.Context, userID string) ([]domain.Order, error) {
+// FindByExternalRef retrieves a user's order by its external reference
+func (r *PostgresOrderRepository) FindByExternalRef(ctx context.Context, userID, ref string) (*domain.Order, error) {
 	query := `
-		SELECT id, user_id, status, subtotal, shipping, total, created_at
+		SELECT id
 		FROM orders
-		WHERE user_id = $1
-		ORDER BY created_at DESC
+		WHERE user_id = $1 AND external_ref = $2
+	`
+
+	var id int
+	err := r.reads.QueryRow(ctx, query, userID, ref).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.FindByID(ctx, strconv.Itoa(id))
+}
+
+// FindByOrderNumber retrieves an order by its human-friendly order number
+func (r *PostgresOrderRepository) FindByOrderNumber(ctx context.Context, number string) (*domain.Order, error) {
+	query := `
+		SELECT id
+		FROM orders
+		WHERE order_number = $1
+	`
+
+	var id int
+	err := r.reads.QueryRow(ctx, query, number).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.FindByID(ctx, strconv.Itoa(id))
+}
+
+// FindByExternalRefs retrieves a user's orders whose external reference is in refs, newest first
+func (r *PostgresOrderRepository) FindByExternalRefs(ctx context.Context, userID string, refs []string) ([]domain.Order, error) {
+	if len(refs) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT id, user_id, status, subtotal, shipping, total, created_at, metadata, priority, shipping_address, total_weight,
+			COALESCE(external_ref, ''), estimated_delivery, tax, discount, order_number, revision
+		FROM orders
+		WHERE user_id = $1 AND external_ref = ANY($2)
+		ORDER BY created_at DESC, id DESC
 	`
 
-	rows, err := r.pool.Query(ctx, query, userID)
+	rows, err := r.reads.Query(ctx, query, userID, refs)
 	if err != nil {
 		return nil, err
 	}
@@ -94,116 +306,1039 @@ func (r *PostgresOrderRepository) FindByUserID(ctx context.Context, userID strin
 	for rows.Next() {
 		var order domain.Order
 		var idInt int
-		err := rows.Scan(&idInt, &order.UserID, &order.Status, &order.Subtotal, &order.Shipping, &order.Total, &order.CreatedAt)
+		err := rows.Scan(
+			&idInt, &order.UserID, &order.Status, &order.Subtotal, &order.Shipping, &order.Total, &order.CreatedAt,
+			&order.Metadata,
+			&order.Priority, &order.ShippingAddress, &order.TotalWeight, &order.ExternalRef,
+			&order.EstimatedDelivery, &order.Tax, &order.Discount, &order.OrderNumber, &order.Revision,
+		)
 		if err != nil {
-			continue
+			return nil, err
 		}
 		order.ID = strconv.Itoa(idInt)
+		normalizeTimestamps(&order)
 		orders = append(orders, order)
 	}
 
-	return orders, nil
+	return orders, rows.Err()
 }
 
-// Create creates a new order
-func (r *PostgresOrderRepository) Create(ctx context.Context, order *domain.Order) error {
+// FindByUserID retrieves one page of orders for a user, newest first.
+// id breaks created_at ties so orders created in the same instant never move between pages.
+// Drafts are left out unless includeDrafts is set.
+func (r *PostgresOrderRepository) FindByUserID(
+	ctx context.Context, userID string, page domain.Page, includeDrafts bool,
+) ([]domain.Order, error) {
 	query := `
-		INSERT INTO orders (user_id, status, subtotal, shipping, total, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id
+		SELECT id, user_id, status, subtotal, shipping, total, created_at, metadata, priority, shipping_address, total_weight,
+			COALESCE(external_ref, ''), estimated_delivery, tax, discount, order_number, revision
+		FROM orders
+		WHERE user_id = $1 AND ($4 OR status <> 'draft')
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3
 	`
 
-	var id int
-	err := r.pool.QueryRow(ctx, query,
-		order.UserID,
-		order.Status,
-		order.Subtotal,
-		order.Shipping,
-		order.Total,
-		time.Now(),
-	).Scan(&id)
-
+	rows, err := r.reads.Query(ctx, query, userID, page.Limit, page.Offset, includeDrafts)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer rows.Close()
 
-	order.ID = strconv.Itoa(id)
+	return r.scanUserOrders(rows, page.Limit), nil
+}
 
-	// Insert order items
-	for _, item := range order.Items {
-		itemQuery := `
-			INSERT INTO order_items (order_id, product_id, product_name, quantity, price, subtotal)
-			VALUES ($1, $2, $3, $4, $5, $6)
-		`
-		_, err := r.pool.Exec(ctx, itemQuery, id, item.ProductID, item.ProductName, item.Quantity, item.Price, item.Subtotal)
+// scanUserOrders reads the rows of the FindByUserID query; rows that fail to scan are skipped.
+// sizeHint (the page limit) pre-sizes the result so it never regrows. The scan targets are
+// declared once and reused for every row: passing fresh locals to Scan would move each of them
+// to the heap per row (see BenchmarkScanUserOrders).
+func (r *PostgresOrderRepository) scanUserOrders(rows pgx.Rows, sizeHint int) []domain.Order {
+	orders := make([]domain.Order, 0, max(sizeHint, 0))
+
+	var (
+		order              domain.Order
+		idInt              int
+		subtotal, shipping pgtype.Float8 // NULL-able without a pointer allocation per row
+	)
+	dest := []any{
+		&idInt, &order.UserID, &order.Status, &subtotal, &shipping, &order.Total, &order.CreatedAt,
+		&order.Metadata,
+		&order.Priority, &order.ShippingAddress, &order.TotalWeight, &order.ExternalRef,
+		&order.EstimatedDelivery, &order.Tax, &order.Discount, &order.OrderNumber, &order.Revision,
+	}
+	for rows.Next() {
+		// Start every row empty: pgx decodes into an existing metadata map or pointed-to value,
+		// which the previous order (already appended) still references
+		order = domain.Order{}
+		if err := rows.Scan(dest...); err != nil {
+			continue
+		}
+		order.ID = strconv.Itoa(idInt)
+		normalizeTimestamps(&order)
+		order.Subtotal = r.amountOrZero(order.ID, "subtotal", float8Ptr(subtotal))
+		order.Shipping = r.amountOrZero(order.ID, "shipping", float8Ptr(shipping))
+		orders = append(orders, order)
+	}
+	return orders
+}
+
+// float8Ptr returns the value of f, or nil for NULL
+func float8Ptr(f pgtype.Float8) *float64 {
+	if !f.Valid {
+		return nil
+	}
+	return &f.Float64
+}
+
+// FindItemsByOrderIDs loads the items of several orders with a single query, keyed by order ID.
+// Orders without items are absent from the map.
+func (r *PostgresOrderRepository) FindItemsByOrderIDs(ctx context.Context, orderIDs []string) (map[string][]domain.OrderItem, error) {
+	items := make(map[string][]domain.OrderItem, len(orderIDs))
+	if len(orderIDs) == 0 {
+		return items, nil
+	}
+
+	ids := make([]int, 0, len(orderIDs))
+	for _, id := range orderIDs {
+		idInt, err := strconv.Atoi(id)
 		if err != nil {
-			return err
+			return nil, domain.ErrInvalidInput
 		}
+		ids = append(ids, idInt)
 	}
 
-	return nil
+	query := `
+		SELECT order_id, product_id, product_name, COALESCE(sku, ''), quantity, price, subtotal, weight, tax_rate, tax, cancelled_at IS NOT NULL
+		FROM order_items
+		WHERE order_id = ANY($1)
+		ORDER BY order_id, id
+	`
+
+	rows, err := r.reads.Query(ctx, query, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var orderID int
+		var item domain.OrderItem
+		err := rows.Scan(
+			&orderID, &item.ProductID, &item.ProductName, &item.SKU, &item.Quantity, &item.Price, &item.Subtotal, &item.Weight, &item.TaxRate, &item.Tax, &item.Cancelled,
+		)
+		if err != nil {
+			return nil, err
+		}
+		key := strconv.Itoa(orderID)
+		items[key] = append(items[key], item)
+	}
+
+	return items, rows.Err()
 }
 
-// CreateWithTx creates a new order within a transaction
-func (r *PostgresOrderRepository) CreateWithTx(ctx context.Context, tx domain.Transaction, order *domain.Order) error {
-	pgxTx, ok := tx.(*PostgresTransaction)
-	if !ok {
-		return errors.New("invalid transaction type")
+// CountByUserID returns the total number of orders for a user; drafts are counted only with includeDrafts
+func (r *PostgresOrderRepository) CountByUserID(ctx context.Context, userID string, includeDrafts bool) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM orders
+		WHERE user_id = $1 AND ($2 OR status <> 'draft')
+	`
+
+	var total int
+	err := r.reads.QueryRow(ctx, query, userID, includeDrafts).Scan(&total)
+	return total, err
+}
+
+// FindDraftsCreatedBefore returns the IDs of up to limit draft orders created before before, oldest
+// first (uses idx_orders_draft_created)
+func (r *PostgresOrderRepository) FindDraftsCreatedBefore(ctx context.Context, before time.Time, limit int) ([]string, error) {
+	query := `
+		SELECT id
+		FROM orders
+		WHERE status = 'draft' AND created_at < $1
+		ORDER BY created_at ASC
+		LIMIT $2
+	`
+
+	rows, err := r.reads.Query(ctx, query, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, strconv.Itoa(id))
 	}
+	return ids, rows.Err()
+}
 
+// FindUpdatedSince retrieves recently-active orders in the given statuses (used by reconciliation)
+// after the keyset cursor, ordered by (updated_at, id). An empty cursor ID starts at since.
+func (r *PostgresOrderRepository) FindUpdatedSince(
+	ctx context.Context, since time.Time, statuses []domain.OrderStatus, after domain.OrderUpdateCursor, limit int,
+) ([]domain.Order, domain.OrderUpdateCursor, error) {
 	query := `
-		INSERT INTO orders (user_id, status, subtotal, shipping, total, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id
+		SELECT id, user_id, status, subtotal, shipping, total, created_at, metadata, priority, shipping_address, total_weight,
+			COALESCE(external_ref, ''), estimated_delivery, tax, discount, order_number, revision, updated_at
+		FROM orders
+		WHERE updated_at >= $1 AND status = ANY($2) AND (updated_at, id) > ($3, $4)
+		ORDER BY updated_at, id
+		LIMIT $5
 	`
 
-	var id int
-	err := pgxTx.QueryRow(ctx, query,
-		order.UserID,
-		order.Status,
-		order.Subtotal,
-		order.Shipping,
-		order.Total,
-		time.Now(),
-	).Scan(&id)
+	afterUpdatedAt, afterID := since, 0
+	if after.ID != "" {
+		id, err := strconv.Atoi(after.ID)
+		if err != nil {
+			return nil, after, domain.ErrInvalidInput
+		}
+		afterUpdatedAt, afterID = after.UpdatedAt, id
+	}
+
+	statusValues := make([]string, len(statuses))
+	for i, status := range statuses {
+		statusValues[i] = status.String()
+	}
 
+	rows, err := r.reads.Query(ctx, query, since, statusValues, afterUpdatedAt, afterID, limit)
 	if err != nil {
-		return err
+		return nil, after, err
 	}
+	defer rows.Close()
 
-	order.ID = strconv.Itoa(id)
+	var orders []domain.Order
+	next := after
+	for rows.Next() {
+		var order domain.Order
+		var idInt int
+		err := rows.Scan(
+			&idInt, &order.UserID, &order.Status, &order.Subtotal, &order.Shipping, &order.Total, &order.CreatedAt,
+			&order.Metadata,
+			&order.Priority, &order.ShippingAddress, &order.TotalWeight, &order.ExternalRef,
+			&order.EstimatedDelivery, &order.Tax, &order.Discount, &order.OrderNumber, &order.Revision,
+			&next.UpdatedAt,
+		)
+		if err != nil {
+			return nil, after, err
+		}
+		order.ID = strconv.Itoa(idInt)
+		next.ID = order.ID
+		normalizeTimestamps(&order)
+		orders = append(orders, order)
+	}
 
-	// Insert order items
-	for _, item := range order.Items {
-		itemQuery := `
-			INSERT INTO order_items (order_id, product_id, product_name, quantity, price, subtotal)
-			VALUES ($1, $2, $3, $4, $5, $6)
-		`
-		err := pgxTx.Exec(ctx, itemQuery, id, item.ProductID, item.ProductName, item.Quantity, item.Price, item.Subtotal)
+	return orders, next, rows.Err()
+}
+
+// FindCreatedBetween retrieves up to limit orders created in [from, to) after the keyset cursor,
+// ordered by (created_at, id). An empty cursor ID starts at from.
+func (r *PostgresOrderRepository) FindCreatedBetween(
+	ctx context.Context, from, to time.Time, after domain.OrderCursor, limit int,
+) ([]domain.Order, error) {
+	query := `
+		SELECT id, user_id, status, subtotal, shipping, total, created_at, metadata, priority, shipping_address, total_weight,
+			COALESCE(external_ref, ''), estimated_delivery, tax, discount, order_number, revision
+		FROM orders
+		WHERE created_at >= $1 AND created_at < $2 AND (created_at, id) > ($3, $4)
+		ORDER BY created_at, id
+		LIMIT $5
+	`
+
+	afterCreatedAt, afterID := from, 0
+	if after.ID != "" {
+		id, err := strconv.Atoi(after.ID)
 		if err != nil {
-			return err
+			return nil, domain.ErrInvalidInput
 		}
+		afterCreatedAt, afterID = after.CreatedAt, id
 	}
 
-	return nil
+	rows, err := r.reads.Query(ctx, query, from, to, afterCreatedAt, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orders []domain.Order
+	for rows.Next() {
+		var order domain.Order
+		var idInt int
+		err := rows.Scan(
+			&idInt, &order.UserID, &order.Status, &order.Subtotal, &order.Shipping, &order.Total, &order.CreatedAt,
+			&order.Metadata, &order.Priority, &order.ShippingAddress, &order.TotalWeight, &order.ExternalRef,
+			&order.EstimatedDelivery, &order.Tax, &order.Discount, &order.OrderNumber, &order.Revision,
+		)
+		if err != nil {
+			return nil, err
+		}
+		order.ID = strconv.Itoa(idInt)
+		normalizeTimestamps(&order)
+		orders = append(orders, order)
+	}
+
+	return orders, rows.Err()
 }
 
-// UpdateStatus updates the status of an order
-func (r *PostgresOrderRepository) UpdateStatus(ctx context.Context, id, status string) error {
+// CountCreatedSince counts placed (not draft or cancelled) orders created at or after since
+// (uses idx_orders_created_at)
+func (r *PostgresOrderRepository) CountCreatedSince(ctx context.Context, since time.Time) (int, error) {
 	query := `
-		UPDATE orders
-		SET status = $1, updated_at = NOW()
-		WHERE id = $2
+		SELECT COUNT(*)
+		FROM orders
+		WHERE created_at >= $1 AND status NOT IN ('draft', 'cancelled')
 	`
 
-	result, err := r.pool.Exec(ctx, query, status, id)
+	var count int
+	err := r.reads.QueryRow(ctx, query, since).Scan(&count)
+	return count, err
+}
+
+// SumRevenueSince sums the totals of placed (not draft or cancelled) orders created at or after
+// since (uses idx_orders_created_at); 0 when there are none
+func (r *PostgresOrderRepository) SumRevenueSince(ctx context.Context, since time.Time) (float64, error) {
+	query := `
+		SELECT COALESCE(SUM(total), 0)
+		FROM orders
+		WHERE created_at >= $1 AND status NOT IN ('draft', 'cancelled')
+	`
+
+	var revenue float64
+	err := r.reads.QueryRow(ctx, query, since).Scan(&revenue)
+	return revenue, err
+}
+
+// Search retrieves a page of orders matching the filter across all users (admin use),
+// together with the total number of matching orders.
+func (r *PostgresOrderRepository) Search(
+	ctx context.Context, filter domain.OrderSearchFilter, page domain.Page,
+) ([]domain.Order, int, error) {
+	countQuery := `
+		SELECT COUNT(*)
+		FROM orders
+		WHERE user_id = $1
+	`
+
+	var total int
+	if err := r.reads.QueryRow(ctx, countQuery, filter.UserID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT id, user_id, status, subtotal, shipping, total, created_at, metadata, priority, shipping_address, total_weight,
+			COALESCE(external_ref, ''), estimated_delivery, tax, discount, order_number, revision
+		FROM orders
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.reads.Query(ctx, query, filter.UserID, page.Limit, page.Offset)
 	if err != nil {
-		return err
+		return nil, 0, err
 	}
+	defer rows.Close()
 
-	if result.RowsAffected() == 0 {
-		return domain.ErrNotFound
+	var orders []domain.Order
+	for rows.Next() {
+		var order domain.Order
+		var idInt int
+		err := rows.Scan(
+			&idInt, &order.UserID, &order.Status, &order.Subtotal, &order.Shipping, &order.Total, &order.CreatedAt,
+			&order.Metadata,
+			&order.Priority, &order.ShippingAddress, &order.TotalWeight, &order.ExternalRef,
+			&order.EstimatedDelivery, &order.Tax, &order.Discount, &order.OrderNumber, &order.Revision,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+		order.ID = strconv.Itoa(idInt)
+		normalizeTimestamps(&order)
+		orders = append(orders, order)
 	}
 
-	return nil
+	return orders, total, rows.Err()
+}
+
+// FindOrphanedItems retrieves a page of order_items rows without a parent order (integrity check for
+// rows left from before the cascade), ordered by item ID, together with the total number of such rows
+func (r *PostgresOrderRepository) FindOrphanedItems(ctx context.Context, page domain.Page) ([]domain.OrphanedItem, int, error) {
+	countQuery := `
+		SELECT COUNT(*)
+		FROM order_items i
+		LEFT JOIN orders o ON o.id = i.order_id
+		WHERE o.id IS NULL
+	`
+
+	var total int
+	if err := r.reads.QueryRow(ctx, countQuery).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT i.id, i.order_id, i.product_id, i.product_name, COALESCE(i.sku, ''), i.quantity, i.price, i.subtotal,
+			i.weight, i.tax_rate, i.tax, i.cancelled_at IS NOT NULL, i.created_at
+		FROM order_items i
+		LEFT JOIN orders o ON o.id = i.order_id
+		WHERE o.id IS NULL
+		ORDER BY i.id
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.reads.Query(ctx, query, page.Limit, page.Offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var items []domain.OrphanedItem
+	for rows.Next() {
+		var item domain.OrphanedItem
+		var id, orderID int
+		var createdAt *time.Time
+		err := rows.Scan(
+			&id, &orderID, &item.ProductID, &item.ProductName, &item.SKU, &item.Quantity, &item.Price, &item.Subtotal,
+			&item.Weight, &item.TaxRate, &item.Tax, &item.Cancelled, &createdAt,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+		item.ID = strconv.Itoa(id)
+		item.OrderID = strconv.Itoa(orderID)
+		if createdAt != nil {
+			item.CreatedAt = createdAt.UTC()
+		}
+		items = append(items, item)
+	}
+
+	return items, total, rows.Err()
+}
+
+// Create creates a new order
+func (r *PostgresOrderRepository) Create(ctx context.Context, order *domain.Order) error {
+	query := `
+		INSERT INTO orders (
+			user_id, status, subtotal, shipping, total, created_at, metadata, priority, shipping_address, total_weight,
+			external_ref, estimated_delivery, tax, discount
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7::jsonb, $8, $9::jsonb, $10, NULLIF($11, ''), $12::date, $13, $14)
+		RETURNING id, order_number, revision
+	`
+
+	metadata, err := encodeMetadata(order.Metadata)
+	if err != nil {
+		return err
+	}
+	address, err := encodeShippingAddress(order.ShippingAddress)
+	if err != nil {
+		return err
+	}
+
+	var id int
+	err = r.pool.QueryRow(ctx, query,
+		order.UserID,
+		order.Status,
+		order.Subtotal,
+		order.Shipping,
+		order.Total,
+		time.Now().UTC(),
+		metadata,
+		order.Priority,
+		address,
+		order.TotalWeight,
+		order.ExternalRef,
+		encodeDate(order.EstimatedDelivery),
+		order.Tax,
+		order.Discount,
+	).Scan(&id, &order.OrderNumber, &order.Revision)
+	if err != nil {
+		return busyError(ctx, mapInsertOrderError(err, order))
+	}
+
+	order.ID = strconv.Itoa(id)
+
+	// Insert order items and applied promotions in one round trip
+	batch := newOrderItemsBatch(id, order.Items)
+	queuePromotions(batch, id, order.Promotions)
+	return busyError(ctx, execBatch(r.pool.SendBatch(ctx, batch), batch.Len()))
+}
+
+// CreateWithTx creates a new order within a transaction
+func (r *PostgresOrderRepository) CreateWithTx(ctx context.Context, tx domain.Transaction, order *domain.Order) error {
+	pgxTx, ok := tx.(*PostgresTransaction)
+	if !ok {
+		return errors.New("invalid transaction type")
+	}
+
+	query := `
+		INSERT INTO orders (
+			user_id, status, subtotal, shipping, total, created_at, metadata, priority, shipping_address, total_weight,
+			external_ref, estimated_delivery, tax, discount
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7::jsonb, $8, $9::jsonb, $10, NULLIF($11, ''), $12::date, $13, $14)
+		RETURNING id, order_number, revision
+	`
+
+	metadata, err := encodeMetadata(order.Metadata)
+	if err != nil {
+		return err
+	}
+	address, err := encodeShippingAddress(order.ShippingAddress)
+	if err != nil {
+		return err
+	}
+
+	var id int
+	err = pgxTx.QueryRow(ctx, query,
+		order.UserID,
+		order.Status,
+		order.Subtotal,
+		order.Shipping,
+		order.Total,
+		time.Now().UTC(),
+		metadata,
+		order.Priority,
+		address,
+		order.TotalWeight,
+		order.ExternalRef,
+		encodeDate(order.EstimatedDelivery),
+		order.Tax,
+		order.Discount,
+	).Scan(&id, &order.OrderNumber, &order.Revision)
+	if err != nil {
+		return mapInsertOrderError(err, order)
+	}
+
+	order.ID = strconv.Itoa(id)
+
+	// Insert order items and applied promotions in one round trip; any failed insert fails the transaction
+	batch := newOrderItemsBatch(id, order.Items)
+	queuePromotions(batch, id, order.Promotions)
+	return retryableTxError(execBatch(pgxTx.SendBatch(ctx, batch), batch.Len()))
+}
+
+// orderLockNamespace is the first key of the per-order advisory locks taken by LockOrderWithTx,
+// keeping them apart from other advisory lock users
+const orderLockNamespace = 0x6f726472 // "ordr"
+
+// LockOrderWithTx takes a transaction-scoped advisory lock on order id
+// (pg_advisory_xact_lock(namespace, hashtext(id))), waiting for any other transaction holding it.
+// The lock is released when tx ends. It does not require the order to exist.
+func (r *PostgresOrderRepository) LockOrderWithTx(ctx context.Context, tx domain.Transaction, id string) error {
+	pgxTx, ok := tx.(*PostgresTransaction)
+	if !ok {
+		return errors.New("invalid transaction type")
+	}
+	return pgxTx.Exec(ctx, "SELECT pg_advisory_xact_lock($1, hashtext($2))", orderLockNamespace, id)
+}
+
+// FindStatusForUpdateWithTx returns the order status and takes a row lock (SELECT ... FOR UPDATE)
+// so concurrent transitions on the same order are serialized until the transaction ends.
+func (r *PostgresOrderRepository) FindStatusForUpdateWithTx(
+	ctx context.Context, tx domain.Transaction, id string,
+) (domain.OrderStatus, error) {
+	pgxTx, ok := tx.(*PostgresTransaction)
+	if !ok {
+		return "", errors.New("invalid transaction type")
+	}
+
+	query := `
+		SELECT status
+		FROM orders
+		WHERE id = $1
+		FOR UPDATE
+	`
+
+	var status domain.OrderStatus
+	err := pgxTx.QueryRow(ctx, query, id).Scan(&status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", domain.ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return status, nil
+}
+
+// UpdateStatusWithTx updates the status of an order within a transaction
+func (r *PostgresOrderRepository) UpdateStatusWithTx(
+	ctx context.Context, tx domain.Transaction, id string, status domain.OrderStatus,
+) error {
+	pgxTx, ok := tx.(*PostgresTransaction)
+	if !ok {
+		return errors.New("invalid transaction type")
+	}
+
+	query := `
+		UPDATE orders
+		SET status = $1, updated_at = NOW()
+		WHERE id = $2
+	`
+
+	rowsAffected, err := pgxTx.ExecRows(ctx, query, status, id)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// UpdateShippingAddressWithTx replaces an order's shipping address within a transaction
+func (r *PostgresOrderRepository) UpdateShippingAddressWithTx(
+	ctx context.Context, tx domain.Transaction, id string, address domain.ShippingAddress,
+) error {
+	pgxTx, ok := tx.(*PostgresTransaction)
+	if !ok {
+		return errors.New("invalid transaction type")
+	}
+
+	encoded, err := encodeShippingAddress(&address)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE orders
+		SET shipping_address = $1::jsonb, updated_at = NOW()
+		WHERE id = $2
+	`
+
+	rowsAffected, err := pgxTx.ExecRows(ctx, query, encoded, id)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// FindItemsWithTx retrieves an order's items within a transaction
+func (r *PostgresOrderRepository) FindItemsWithTx(
+	ctx context.Context, tx domain.Transaction, orderID string,
+) ([]domain.OrderItem, error) {
+	pgxTx, ok := tx.(*PostgresTransaction)
+	if !ok {
+		return nil, errors.New("invalid transaction type")
+	}
+
+	query := `
+		SELECT product_id, product_name, COALESCE(sku, ''), quantity, price, subtotal, weight, tax_rate, tax, cancelled_at IS NOT NULL
+		FROM order_items
+		WHERE order_id = $1
+		ORDER BY id
+	`
+
+	rows, err := pgxTx.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []domain.OrderItem
+	for rows.Next() {
+		var item domain.OrderItem
+		err := rows.Scan(&item.ProductID, &item.ProductName, &item.SKU, &item.Quantity, &item.Price, &item.Subtotal, &item.Weight, &item.TaxRate, &item.Tax, &item.Cancelled)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// CancelItemWithTx marks the active items of productID in an order as cancelled within a transaction
+func (r *PostgresOrderRepository) CancelItemWithTx(
+	ctx context.Context, tx domain.Transaction, orderID, productID string,
+) error {
+	pgxTx, ok := tx.(*PostgresTransaction)
+	if !ok {
+		return errors.New("invalid transaction type")
+	}
+
+	query := `
+		UPDATE order_items
+		SET cancelled_at = NOW()
+		WHERE order_id = $1 AND product_id = $2 AND cancelled_at IS NULL
+	`
+
+	rowsAffected, err := pgxTx.ExecRows(ctx, query, orderID, productID)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// IncrementRevisionWithTx bumps an order's revision within a transaction and returns the new value.
+// Call it in the same transaction as the item change it records.
+func (r *PostgresOrderRepository) IncrementRevisionWithTx(ctx context.Context, tx domain.Transaction, id string) (int, error) {
+	pgxTx, ok := tx.(*PostgresTransaction)
+	if !ok {
+		return 0, errors.New("invalid transaction type")
+	}
+
+	query := `
+		UPDATE orders
+		SET revision = revision + 1
+		WHERE id = $1
+		RETURNING revision
+	`
+
+	var revision int
+	if err := pgxTx.QueryRow(ctx, query, id).Scan(&revision); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, domain.ErrNotFound
+		}
+		return 0, err
+	}
+	return revision, nil
+}
+
+// UpdateTotalsWithTx overwrites an order's subtotal, shipping, tax, discount, total and total weight
+// within a transaction
+func (r *PostgresOrderRepository) UpdateTotalsWithTx(
+	ctx context.Context, tx domain.Transaction, id string, subtotal, shipping, tax, discount, total, totalWeight float64,
+) error {
+	pgxTx, ok := tx.(*PostgresTransaction)
+	if !ok {
+		return errors.New("invalid transaction type")
+	}
+
+	query := `
+		UPDATE orders
+		SET subtotal = $1, shipping = $2, tax = $3, discount = $4, total = $5, total_weight = $6, updated_at = NOW()
+		WHERE id = $7
+	`
+
+	rowsAffected, err := pgxTx.ExecRows(ctx, query, subtotal, shipping, tax, discount, total, totalWeight, id)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// PurgeWithTx hard-deletes up to limit orders in status last updated before before within a transaction.
+// order_items, order_status_history and failed_cart_clears rows go with them (ON DELETE CASCADE);
+// the IDs are recorded in purged_orders.
+func (r *PostgresOrderRepository) PurgeWithTx(
+	ctx context.Context, tx domain.Transaction, status domain.OrderStatus, before time.Time, limit int,
+) ([]string, error) {
+	pgxTx, ok := tx.(*PostgresTransaction)
+	if !ok {
+		return nil, errors.New("invalid transaction type")
+	}
+
+	// Each purged ID leaves a tombstone so later lookups report it as gone rather than not found
+	query := `
+		WITH purged AS (
+			DELETE FROM orders
+			WHERE id IN (
+				SELECT id
+				FROM orders
+				WHERE status = $1 AND updated_at < $2
+				ORDER BY updated_at, id
+				LIMIT $3
+			)
+			RETURNING id
+		)
+		INSERT INTO purged_orders (order_id)
+		SELECT id FROM purged
+		RETURNING order_id
+	`
+
+	rows, err := pgxTx.Query(ctx, query, status, before.UTC(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, strconv.Itoa(id))
+	}
+
+	return ids, rows.Err()
+}
+
+// AddStatusHistoryWithTx appends a status transition to order_status_history within a transaction
+func (r *PostgresOrderRepository) AddStatusHistoryWithTx(
+	ctx context.Context, tx domain.Transaction, change *domain.StatusChange,
+) error {
+	pgxTx, ok := tx.(*PostgresTransaction)
+	if !ok {
+		return errors.New("invalid transaction type")
+	}
+
+	query := `
+		INSERT INTO order_status_history (order_id, from_status, to_status, source, created_at, changed_by)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''))
+		RETURNING created_at
+	`
+
+	err := pgxTx.QueryRow(ctx, query,
+		change.OrderID,
+		change.FromStatus,
+		change.ToStatus,
+		change.Source,
+		time.Now().UTC(),
+		change.ChangedBy,
+	).Scan(&change.CreatedAt)
+	change.CreatedAt = change.CreatedAt.UTC()
+	return err
+}
+
+// AddFailedCartClear dead-letters a cart clear that failed after all retries
+func (r *PostgresOrderRepository) AddFailedCartClear(ctx context.Context, userID, orderID, reason string) error {
+	query := `
+		INSERT INTO failed_cart_clears (user_id, order_id, error, created_at)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	_, err := r.pool.Exec(ctx, query, userID, orderID, reason, time.Now().UTC())
+	return busyError(ctx, err)
+}
+
+// FindStatusHistory retrieves an order's status transitions, oldest first
+func (r *PostgresOrderRepository) FindStatusHistory(ctx context.Context, orderID string) ([]domain.StatusChange, error) {
+	query := `
+		SELECT from_status, to_status, source, created_at, COALESCE(changed_by, '')
+		FROM order_status_history
+		WHERE order_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := r.reads.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var changes []domain.StatusChange
+	for rows.Next() {
+		change := domain.StatusChange{OrderID: orderID}
+		if err := rows.Scan(&change.FromStatus, &change.ToStatus, &change.Source, &change.CreatedAt, &change.ChangedBy); err != nil {
+			return nil, err
+		}
+		change.CreatedAt = change.CreatedAt.UTC()
+		changes = append(changes, change)
+	}
+
+	return changes, rows.Err()
+}
+
+// FindStatus retrieves an order's owner, status and last update time (no items query)
+func (r *PostgresOrderRepository) FindStatus(ctx context.Context, id string) (*domain.OrderStatusInfo, error) {
+	orderID, err := parseOrderID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT user_id, status, COALESCE(updated_at, created_at)
+		FROM orders
+		WHERE id = $1
+	`
+
+	var info domain.OrderStatusInfo
+	err = r.reads.QueryRow(ctx, query, orderID).Scan(&info.UserID, &info.Status, &info.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	info.UpdatedAt = info.UpdatedAt.UTC()
+	return &info, nil
+}
+
+// FindInternalNote retrieves the staff-only note of an order
+func (r *PostgresOrderRepository) FindInternalNote(ctx context.Context, id string) (string, error) {
+	query := `
+		SELECT internal_note
+		FROM orders
+		WHERE id = $1
+	`
+
+	var note string
+	err := r.reads.QueryRow(ctx, query, id).Scan(&note)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", domain.ErrNotFound
+	}
+	return note, err
+}
+
+// UpdateInternalNote replaces the staff-only note of an order
+func (r *PostgresOrderRepository) UpdateInternalNote(ctx context.Context, id string, note string) error {
+	query := `
+		UPDATE orders
+		SET internal_note = $1, updated_at = NOW()
+		WHERE id = $2
+	`
+
+	result, err := r.pool.Exec(ctx, query, note, id)
+	if err != nil {
+		return busyError(ctx, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// UpdateStatus updates the status of an order
+func (r *PostgresOrderRepository) UpdateStatus(ctx context.Context, id string, status domain.OrderStatus) error {
+	query := `
+		UPDATE orders
+		SET status = $1, updated_at = NOW()
+		WHERE id = $2
+	`
+
+	result, err := r.pool.Exec(ctx, query, status, id)
+	if err != nil {
+		return busyError(ctx, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// insertOrderItemQuery inserts one order line
+const insertOrderItemQuery = `
+	INSERT INTO order_items (order_id, product_id, product_name, sku, quantity, price, subtotal, weight, tax_rate, tax)
+	VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8, $9, $10)
+`
+
+// newOrderItemsBatch queues one insert per item so all items are sent in a single round trip
+func newOrderItemsBatch(orderID int, items []domain.OrderItem) *pgx.Batch {
+	batch := &pgx.Batch{}
+	for _, item := range items {
+		batch.Queue(insertOrderItemQuery,
+			orderID, item.ProductID, item.ProductName, item.SKU, item.Quantity, item.Price, item.Subtotal, item.Weight, item.TaxRate, item.Tax,
+		)
+	}
+	return batch
+}
+
+// ReplacePromotionsWithTx deletes the order's recorded promotions and inserts promotions in their place
+func (r *PostgresOrderRepository) ReplacePromotionsWithTx(
+	ctx context.Context, tx domain.Transaction, id string, promotions []domain.AppliedPromotion,
+) error {
+	pgxTx, ok := tx.(*PostgresTransaction)
+	if !ok {
+		return errors.New("invalid transaction type")
+	}
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return domain.ErrInvalidInput
+	}
+
+	batch := &pgx.Batch{}
+	batch.Queue(`DELETE FROM order_promotions WHERE order_id = $1`, idInt)
+	queuePromotions(batch, idInt, promotions)
+	return retryableTxError(execBatch(pgxTx.SendBatch(ctx, batch), batch.Len()))
+}
+
+const insertOrderPromotionQuery = `
+	INSERT INTO order_promotions (order_id, promotion_id, description, amount)
+	VALUES ($1, $2, $3, $4)
+`
+
+// queuePromotions adds one insert per applied promotion to batch
+func queuePromotions(batch *pgx.Batch, orderID int, promotions []domain.AppliedPromotion) {
+	for _, promo := range promotions {
+		batch.Queue(insertOrderPromotionQuery, orderID, promo.ID, promo.Description, promo.Amount)
+	}
+}
+
+// execBatch reads the result of each of n queued statements and closes results.
+// It returns the first failure so the caller's transaction is rolled back.
+func execBatch(results pgx.BatchResults, n int) error {
+	for i := range n {
+		if _, err := results.Exec(); err != nil {
+			_ = results.Close()
+			return fmt.Errorf("batch statement %d: %w", i, err)
+		}
+	}
+	return results.Close()
+}
+
+// externalRefConstraint is the unique index on orders(user_id, external_ref)
+const externalRefConstraint = "orders_user_external_ref_key"
+
+// mapInsertOrderError turns a duplicate external reference into domain.ErrConflict
+func mapInsertOrderError(err error, order *domain.Order) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == externalRefConstraint {
+		return fmt.Errorf("external ref %q of user %q: %w", order.ExternalRef, order.UserID, domain.ErrConflict)
+	}
+	return err
+}
+
+// encodeDate renders t as a YYYY-MM-DD literal for a ::date parameter (nil stays NULL).
+// Passing a time.Time would be converted through timestamptz in the session time zone,
+// which can shift the date.
+func encodeDate(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	date := t.Format(time.DateOnly)
+	return &date
+}
+
+// encodeMetadata renders order metadata as JSON text for a ::jsonb parameter.
+// Reads scan JSONB straight into map[string]string, but under the simple protocol
+// (required by PgCat) pgx cannot infer a type for a bare map argument, so writes pass text.
+func encodeMetadata(metadata map[string]string) (string, error) {
+	if len(metadata) == 0 {
+		return "{}", nil
+	}
+	b, err := json.Marshal(metadata)
+	if err != nil {
+		return "", fmt.Errorf("encode order metadata: %w", err)
+	}
+	return string(b), nil
+}
+
+// encodeShippingAddress renders an address as JSON text for a ::jsonb parameter
+// (see encodeMetadata); a nil address becomes SQL NULL.
+func encodeShippingAddress(address *domain.ShippingAddress) (*string, error) {
+	if address == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(address)
+	if err != nil {
+		return nil, fmt.Errorf("encode shipping address: %w", err)
+	}
+	text := string(b)
+	return &text, nil
 }
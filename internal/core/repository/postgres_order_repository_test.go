@@ -0,0 +1,261 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// fakeBatchResults returns errs[i] for the i-th Exec
+type fakeBatchResults struct {
+	errs   []error
+	execs  int
+	closed bool
+}
+
+func (f *fakeBatchResults) Exec() (pgconn.CommandTag, error) {
+	i := f.execs
+	f.execs++
+	if i < len(f.errs) && f.errs[i] != nil {
+		return pgconn.CommandTag{}, f.errs[i]
+	}
+	return pgconn.NewCommandTag("INSERT 0 1"), nil
+}
+func (f *fakeBatchResults) Query() (pgx.Rows, error) { return nil, errors.New("not implemented") }
+func (f *fakeBatchResults) QueryRow() pgx.Row        { return nil }
+func (f *fakeBatchResults) Close() error {
+	f.closed = true
+	return nil
+}
+
+func TestNewOrderItemsBatch(t *testing.T) {
+	items := []domain.OrderItem{
+		{ProductID: "101", ProductName: "One", Quantity: 1, Price: 10, Subtotal: 10},
+		{ProductID: "102", ProductName: "Two", SKU: "TWO-BLUE", Quantity: 2, Price: 5, Subtotal: 10},
+		{ProductID: "103", ProductName: "Three", Quantity: 3, Price: 1, Subtotal: 3},
+	}
+
+	batch := newOrderItemsBatch(42, items)
+
+	if batch.Len() != len(items) {
+		t.Fatalf("batch.Len() = %d, want %d", batch.Len(), len(items))
+	}
+	for i, q := range batch.QueuedQueries {
+		if q.SQL != insertOrderItemQuery {
+			t.Errorf("query %d SQL = %q, want item insert", i, q.SQL)
+		}
+		if q.Arguments[0] != 42 || q.Arguments[1] != items[i].ProductID || q.Arguments[3] != items[i].SKU || q.Arguments[4] != items[i].Quantity {
+			t.Errorf("query %d args = %v, want order 42 and item %+v", i, q.Arguments, items[i])
+		}
+	}
+}
+
+func TestExecBatch(t *testing.T) {
+	insertErr := errors.New("violates check constraint")
+
+	tests := []struct {
+		name      string
+		errs      []error
+		wantErr   bool
+		wantExecs int
+	}{
+		{name: "All inserts succeed", wantExecs: 3},
+		{name: "Second insert fails", errs: []error{nil, insertErr}, wantErr: true, wantExecs: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := &fakeBatchResults{errs: tt.errs}
+
+			err := execBatch(results, 3)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("execBatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, insertErr) {
+				t.Errorf("execBatch() error = %v, want wrapped insert error", err)
+			}
+			if results.execs != tt.wantExecs || !results.closed {
+				t.Errorf("execs = %d, closed = %v; want %d execs and closed", results.execs, results.closed, tt.wantExecs)
+			}
+		})
+	}
+}
+
+func TestSubtotalMatchesItems(t *testing.T) {
+	tests := []struct {
+		name    string
+		order   domain.Order
+		wantSum float64
+		wantOK  bool
+	}{
+		{
+			name: "Consistent",
+			order: domain.Order{Subtotal: 30.3, Items: []domain.OrderItem{
+				{Subtotal: 10.1}, {Subtotal: 20.2},
+			}},
+			wantSum: 30.3, wantOK: true,
+		},
+		{
+			name: "Cancelled items excluded",
+			order: domain.Order{Subtotal: 10, Items: []domain.OrderItem{
+				{Subtotal: 10}, {Subtotal: 5, Cancelled: true},
+			}},
+			wantSum: 10, wantOK: true,
+		},
+		{
+			name: "Mismatch",
+			order: domain.Order{Subtotal: 25, Items: []domain.OrderItem{
+				{Subtotal: 10}, {Subtotal: 10},
+			}},
+			wantSum: 20, wantOK: false,
+		},
+		{name: "No items", order: domain.Order{Subtotal: 0}, wantSum: 0, wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sum, ok := subtotalMatchesItems(&tt.order)
+			if ok != tt.wantOK || math.Abs(sum-tt.wantSum) > 1e-9 {
+				t.Errorf("subtotalMatchesItems() = %v, %v, want %v, %v", sum, ok, tt.wantSum, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestAmountOrZero(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	r := &PostgresOrderRepository{logger: zap.New(core)}
+
+	amount := 12.5
+	if got := r.amountOrZero("1", "subtotal", &amount); got != 12.5 {
+		t.Errorf("amountOrZero(12.5) = %v, want 12.5", got)
+	}
+	if logs.Len() != 0 {
+		t.Errorf("amountOrZero(12.5) logged %d warnings, want 0", logs.Len())
+	}
+
+	if got := r.amountOrZero("2", "shipping", nil); got != 0 {
+		t.Errorf("amountOrZero(NULL) = %v, want 0", got)
+	}
+	entries := logs.All()
+	if len(entries) != 1 || entries[0].ContextMap()["order_id"] != "2" || entries[0].ContextMap()["column"] != "shipping" {
+		t.Errorf("amountOrZero(NULL) logs = %+v, want one warning for order 2 shipping", entries)
+	}
+}
+
+func TestNormalizeTimestamps(t *testing.T) {
+	ict := time.FixedZone("ICT", 7*60*60)
+	order := domain.Order{CreatedAt: time.Date(2026, 3, 1, 9, 30, 0, 0, ict)}
+
+	normalizeTimestamps(&order)
+
+	body, err := json.Marshal(order)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if want := `"created_at":"2026-03-01T02:30:00Z"`; !strings.Contains(string(body), want) {
+		t.Errorf("json = %s, want it to contain %s", body, want)
+	}
+}
+
+func TestFindByIDInvalidID(t *testing.T) {
+	// No pool: invalid IDs must be rejected before any query is sent
+	r := NewPostgresOrderRepository(nil)
+
+	for _, id := range []string{"abc", "", "1.5", "-3", "0", "99999999999"} {
+		if _, err := r.FindByID(context.Background(), id); !errors.Is(err, domain.ErrInvalidInput) {
+			t.Errorf("FindByID(%q) error = %v, want ErrInvalidInput", id, err)
+		}
+	}
+}
+
+// fakeOrderRows serves n identical rows of the FindByUserID query. Scan fills the destinations
+// the way pgx does, including allocating for pointer targets and decoding metadata into a map.
+type fakeOrderRows struct {
+	pgx.Rows
+	n, i int
+}
+
+func (f *fakeOrderRows) Next() bool {
+	f.i++
+	return f.i <= f.n
+}
+
+func (f *fakeOrderRows) Close()     {}
+func (f *fakeOrderRows) Err() error { return nil }
+
+func (f *fakeOrderRows) Scan(dest ...any) error {
+	created := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	for i, d := range dest {
+		switch d := d.(type) {
+		case *int:
+			*d = f.i
+		case *string:
+			*d = "ORD-2026-000123"
+		case *domain.OrderStatus:
+			*d = domain.OrderStatusPaid
+		case *domain.OrderPriority:
+			*d = domain.OrderPriorityStandard
+		case *float64:
+			*d = 10
+		case *pgtype.Float8:
+			*d = pgtype.Float8{Float64: 10, Valid: true}
+		case *time.Time:
+			*d = created
+		case **time.Time:
+			*d = nil
+		case *map[string]string:
+			if *d == nil {
+				*d = map[string]string{}
+			}
+			(*d)["channel"] = "web"
+		case **domain.ShippingAddress:
+			*d = nil
+		default:
+			return fmt.Errorf("fakeOrderRows: unsupported destination %d: %T", i, d)
+		}
+	}
+	return nil
+}
+
+func TestScanUserOrders(t *testing.T) {
+	r := &PostgresOrderRepository{logger: zap.NewNop()}
+
+	orders := r.scanUserOrders(&fakeOrderRows{n: 3}, 2)
+	if len(orders) != 3 {
+		t.Fatalf("scanUserOrders() = %d orders, want 3", len(orders))
+	}
+	for i, order := range orders {
+		if order.ID != strconv.Itoa(i+1) || order.Subtotal != 10 || order.Shipping != 10 {
+			t.Errorf("order %d = %+v, want id %d with subtotal and shipping 10", i, order, i+1)
+		}
+	}
+	// Scan targets are reused between rows; every order must still own its metadata map
+	orders[0].Metadata["channel"] = "app"
+	if orders[1].Metadata["channel"] != "web" {
+		t.Errorf("orders share their metadata map")
+	}
+}
+
+// BenchmarkScanUserOrders measures the per-row cost of FindByUserID's scan loop for a
+// 1000-order page; run with -benchmem to watch allocations
+func BenchmarkScanUserOrders(b *testing.B) {
+	r := &PostgresOrderRepository{logger: zap.NewNop()}
+	b.ReportAllocs()
+	for b.Loop() {
+		r.scanUserOrders(&fakeOrderRows{n: 1000}, 1000)
+	}
+}
@@ -18,13 +18,18 @@ func NewPostgresTransactionManager(pool *pgxpool.Pool) *PostgresTransactionManag
 	return &PostgresTransactionManager{pool: pool}
 }
 
-// Begin starts a new database transaction
+// Begin starts a new database transaction.
+//
+// Errors caused by a lost server connection (see isConnClosed), here or from any statement of the
+// transaction, wrap domain.ErrRetryable: the transaction is aborted, and the caller may run it again.
+// A connection lost during Commit wraps domain.ErrCommitUnknown instead.
+// Waiting longer than DB_ACQUIRE_TIMEOUT for a connection wraps domain.ErrDatabaseBusy.
 func (tm *PostgresTransactionManager) Begin(ctx context.Context) (domain.Transaction, error) {
 	// Revert to standard Begin() to leverage PgCat routing.
 	// Explicit ReadWrite mode can cause 0A000 error on replicas if not handled correctly by the pooler.
 	tx, err := tm.pool.Begin(ctx)
 	if err != nil {
-		return nil, err
+		return nil, retryableTxError(busyError(ctx, err))
 	}
 	return &PostgresTransaction{tx: tx}, nil
 }
@@ -34,9 +39,10 @@ type PostgresTransaction struct {
 	tx pgx.Tx
 }
 
-// Commit commits the transaction
+// Commit commits the transaction. A lost connection here is not retryable: the commit may have been
+// applied, so the error wraps domain.ErrCommitUnknown.
 func (t *PostgresTransaction) Commit(ctx context.Context) error {
-	return t.tx.Commit(ctx)
+	return commitError(t.tx.Commit(ctx))
 }
 
 // Rollback rolls back the transaction
@@ -46,11 +52,40 @@ func (t *PostgresTransaction) Rollback(ctx context.Context) error {
 
 // QueryRow executes a query that returns a single row
 func (t *PostgresTransaction) QueryRow(ctx context.Context, query string, args ...interface{}) pgx.Row {
-	return t.tx.QueryRow(ctx, query, args...)
+	return txRow{row: t.tx.QueryRow(ctx, query, args...)}
+}
+
+// txRow marks a Scan error from a lost connection as retryable
+type txRow struct {
+	row pgx.Row
+}
+
+func (r txRow) Scan(dest ...any) error {
+	return retryableTxError(r.row.Scan(dest...))
+}
+
+// Query executes a query that returns rows
+func (t *PostgresTransaction) Query(ctx context.Context, query string, args ...interface{}) (pgx.Rows, error) {
+	rows, err := t.tx.Query(ctx, query, args...)
+	return rows, retryableTxError(err)
 }
 
 // Exec executes a query that doesn't return rows
 func (t *PostgresTransaction) Exec(ctx context.Context, query string, args ...interface{}) error {
 	_, err := t.tx.Exec(ctx, query, args...)
-	return err
+	return retryableTxError(err)
+}
+
+// ExecRows executes a query that doesn't return rows and reports the number of rows affected
+func (t *PostgresTransaction) ExecRows(ctx context.Context, query string, args ...interface{}) (int64, error) {
+	tag, err := t.tx.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, retryableTxError(err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// SendBatch sends all queued statements of b in a single round trip within the transaction
+func (t *PostgresTransaction) SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults {
+	return t.tx.SendBatch(ctx, b)
 }
@@ -0,0 +1,97 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"github.com/duynhne/order-service/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// maxAddressFieldLength caps every shipping address field
+const maxAddressFieldLength = 200
+
+// countryCodePattern matches an ISO 3166-1 alpha-2 code
+var countryCodePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// normalizeShippingAddress trims every field, uppercases Country and validates the result:
+// name, line1, city, postal_code and country are required, fields are at most
+// maxAddressFieldLength characters without control characters, and country is an
+// ISO 3166-1 alpha-2 code. Returns ErrInvalidAddress on failure.
+func normalizeShippingAddress(address domain.ShippingAddress) (domain.ShippingAddress, error) {
+	fields := []*string{
+		&address.Name, &address.Line1, &address.Line2, &address.City,
+		&address.Region, &address.PostalCode, &address.Country,
+	}
+	for _, field := range fields {
+		*field = strings.TrimSpace(*field)
+		if utf8.RuneCountInString(*field) > maxAddressFieldLength || !printable(*field) {
+			return address, fmt.Errorf("field too long or not printable: %w", ErrInvalidAddress)
+		}
+	}
+	address.Country = strings.ToUpper(address.Country)
+
+	if address.Name == "" || address.Line1 == "" || address.City == "" || address.PostalCode == "" {
+		return address, fmt.Errorf("missing a required field: %w", ErrInvalidAddress)
+	}
+	if !countryCodePattern.MatchString(address.Country) {
+		return address, fmt.Errorf("country %q is not an ISO 3166-1 alpha-2 code: %w", address.Country, ErrInvalidAddress)
+	}
+	return address, nil
+}
+
+// addressEditable reports whether an order's shipping address may still change (not yet processing/shipped)
+func addressEditable(status domain.OrderStatus) bool {
+	return status == domain.OrderStatusDraft || status == domain.OrderStatusPending || status == domain.OrderStatusPaid
+}
+
+// UpdateShippingAddress replaces the shipping address of userID's order while it is pending or paid.
+// The status is checked under the order row lock so the change cannot race with shipping.
+// Returns ErrInvalidAddress for an invalid address, ErrInvalidOrderState once the order is being
+// processed, shipped or closed, and ErrUnauthorized for another user's order.
+func (s *OrderService) UpdateShippingAddress(
+	ctx context.Context, id, userID string, address domain.ShippingAddress,
+) (*domain.Order, error) {
+	ctx, span := middleware.StartSpan(ctx, "order.update_address", trace.WithAttributes(
+		attribute.String("layer", "logic"),
+		attribute.String("order.id", id),
+	))
+	defer span.End()
+
+	address, err := normalizeShippingAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.GetUserOrder(ctx, id, userID); err != nil {
+		return nil, err
+	}
+
+	err = s.inTx(ctx, func(tx domain.Transaction) error {
+		if err := s.orderRepo.LockOrderWithTx(ctx, tx, id); err != nil {
+			return err
+		}
+		status, err := s.orderRepo.FindStatusForUpdateWithTx(ctx, tx, id)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return fmt.Errorf("update address of order %q: %w", id, ErrOrderNotFound)
+			}
+			return err
+		}
+		if !addressEditable(status) {
+			return fmt.Errorf("update address of %s order %q: %w", status, id, ErrInvalidOrderState)
+		}
+		return s.orderRepo.UpdateShippingAddressWithTx(ctx, tx, id, address)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.invalidateOrder(ctx, id)
+
+	return s.GetOrder(ctx, id)
+}
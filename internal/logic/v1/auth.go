@@ -0,0 +1,25 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+)
+
+// requireAdmin returns ErrUnauthorized when ctx carries an authenticated principal without the
+// admin role. Work without an AuthContext (background jobs, internal callers) is trusted, so the
+// routes keep middleware.RequireRole as their first line of defense.
+func requireAdmin(ctx context.Context, op string) error {
+	auth, ok := domain.AuthFromContext(ctx)
+	if ok && !auth.HasRole(domain.RoleAdmin) {
+		return fmt.Errorf("%s by user %q: admin role required: %w", op, auth.UserID, ErrUnauthorized)
+	}
+	return nil
+}
+
+// actorID returns the authenticated user behind ctx, or "" for background work
+func actorID(ctx context.Context) string {
+	auth, _ := domain.AuthFromContext(ctx)
+	return auth.UserID
+}
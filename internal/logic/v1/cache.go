@@ -0,0 +1,31 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WithOrderCache enables read-through caching of GetOrder results.
+// Every mutation of an order invalidates its entry so stale statuses are never served.
+func WithOrderCache(cache domain.OrderCache) Option {
+	return func(s *OrderService) {
+		s.cache = cache
+	}
+}
+
+// invalidateOrder drops order id from the cache after a committed mutation.
+// A failed delete is recorded on the span but does not fail the mutation, which has
+// already been committed; the cache TTL bounds how long the stale entry can live.
+func (s *OrderService) invalidateOrder(ctx context.Context, id string) {
+	if s.cache == nil {
+		return
+	}
+	span := trace.SpanFromContext(ctx)
+	if err := s.cache.Delete(ctx, id); err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.Bool("cache.invalidate_failed", true))
+	}
+}
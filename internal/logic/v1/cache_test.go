@@ -0,0 +1,119 @@
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+)
+
+// mockOrderCache records cache calls
+type mockOrderCache struct {
+	orders  map[string]*domain.Order
+	deleted []string
+}
+
+func (m *mockOrderCache) Get(ctx context.Context, id string) (*domain.Order, bool) {
+	order, ok := m.orders[id]
+	return order, ok
+}
+func (m *mockOrderCache) Set(ctx context.Context, order *domain.Order) {
+	m.orders[order.ID] = order
+}
+func (m *mockOrderCache) Delete(ctx context.Context, id string) error {
+	m.deleted = append(m.deleted, id)
+	delete(m.orders, id)
+	return nil
+}
+
+func TestGetOrderReadThroughCache(t *testing.T) {
+	ctx := context.Background()
+	repo := &MockOrderRepository{}
+	cache := &mockOrderCache{orders: map[string]*domain.Order{}}
+	service := NewOrderService(repo, &MockTransactionManager{}, WithOrderCache(cache))
+
+	for range 2 {
+		if _, err := service.GetOrder(ctx, "7"); err != nil {
+			t.Fatalf("GetOrder() error = %v", err)
+		}
+	}
+	if repo.findByIDCalls != 1 {
+		t.Errorf("repository FindByID calls = %d, want 1 (second read served from cache)", repo.findByIDCalls)
+	}
+}
+
+func TestOrderMutationsInvalidateCache(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		mutate  func(s *OrderService) error
+		current domain.OrderStatus
+		want    []string
+	}{
+		{
+			name:    "UpdateOrderStatus",
+			mutate:  func(s *OrderService) error { return s.UpdateOrderStatus(ctx, "7", "shipped", false) },
+			current: domain.OrderStatusPaid,
+			want:    []string{"7"},
+		},
+		{
+			name: "MarkOrderPaid",
+			mutate: func(s *OrderService) error {
+				_, err := s.MarkOrderPaid(ctx, "7")
+				return err
+			},
+			current: domain.OrderStatusPending,
+			want:    []string{"7"},
+		},
+		{
+			name: "MarkOrderPaid no-op keeps entry",
+			mutate: func(s *OrderService) error {
+				_, err := s.MarkOrderPaid(ctx, "7")
+				return err
+			},
+			current: domain.OrderStatusPaid,
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockOrderRepository{
+				findStatusFunc: func(ctx context.Context, id string) (domain.OrderStatus, error) {
+					return tt.current, nil
+				},
+			}
+			cache := &mockOrderCache{orders: map[string]*domain.Order{"7": {ID: "7"}}}
+			service := NewOrderService(repo, &MockTransactionManager{}, WithOrderCache(cache))
+
+			if err := tt.mutate(service); err != nil {
+				t.Fatalf("mutation error = %v", err)
+			}
+			if len(cache.deleted) != len(tt.want) || (len(tt.want) > 0 && cache.deleted[0] != tt.want[0]) {
+				t.Errorf("cache deletes = %v, want %v", cache.deleted, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetOrderDoesNotShareCachedOrder(t *testing.T) {
+	ctx := context.Background()
+	cache := &mockOrderCache{orders: map[string]*domain.Order{}}
+	service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{}, WithOrderCache(cache))
+
+	fromRepo, err := service.GetOrder(ctx, "7")
+	if err != nil {
+		t.Fatalf("GetOrder() error = %v", err)
+	}
+	fromRepo.Status = domain.OrderStatusShipped
+	fromCache, err := service.GetOrder(ctx, "7")
+	if err != nil {
+		t.Fatalf("GetOrder() error = %v", err)
+	}
+	fromCache.Status = domain.OrderStatusCancelled
+
+	if got := cache.orders["7"].Status; got == domain.OrderStatusShipped || got == domain.OrderStatusCancelled {
+		t.Errorf("cached status = %q, changed through an order GetOrder returned", got)
+	}
+}
@@ -0,0 +1,30 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/duynhne/order-service/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RecordFailedCartClear dead-letters the cart clear that follows order creation once every retry
+// has failed, so a reconciliation job can clear the cart later. The order itself is unaffected.
+func (s *OrderService) RecordFailedCartClear(ctx context.Context, userID, orderID string, cause error) error {
+	ctx, span := middleware.StartSpan(ctx, "order.record_failed_cart_clear", trace.WithAttributes(
+		attribute.String("layer", "logic"),
+		attribute.String("order.id", orderID),
+		attribute.String("user.id", userID),
+	))
+	defer span.End()
+
+	reason := ""
+	if cause != nil {
+		reason = cause.Error()
+	}
+	if err := s.orderRepo.AddFailedCartClear(ctx, userID, orderID, reason); err != nil {
+		span.RecordError(err)
+		return err
+	}
+	return nil
+}
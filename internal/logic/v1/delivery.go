@@ -0,0 +1,33 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+)
+
+// Default delivery lead time used when WithDeliveryLeadTime is not given
+const (
+	DefaultDeliveryBaseDays          = 5
+	DefaultExpressDeliveryAdjustDays = -3
+)
+
+// WithDeliveryLeadTime sets the order-time delivery estimate: baseDays after the order date,
+// plus expressAdjustDays (usually negative) for express orders. The estimate is never before
+// the order date.
+func WithDeliveryLeadTime(baseDays, expressAdjustDays int) Option {
+	return func(s *OrderService) {
+		s.deliveryBaseDays = baseDays
+		s.expressAdjustDays = expressAdjustDays
+	}
+}
+
+// estimateDelivery returns the estimated delivery date (UTC midnight) of an order placed at placed
+func (s *OrderService) estimateDelivery(placed time.Time, priority domain.OrderPriority) time.Time {
+	days := s.deliveryBaseDays
+	if priority == domain.OrderPriorityExpress {
+		days += s.expressAdjustDays
+	}
+	y, m, d := placed.UTC().Date()
+	return time.Date(y, m, d+max(days, 0), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,53 @@
+package v1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+)
+
+func TestEstimateDelivery(t *testing.T) {
+	// Late evening west of UTC is already the next day in UTC
+	placed := time.Date(2026, 10, 16, 22, 30, 0, 0, time.FixedZone("UTC-5", -5*3600))
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		priority domain.OrderPriority
+		want     time.Time
+	}{
+		{name: "Default standard", priority: domain.OrderPriorityStandard, want: time.Date(2026, 10, 22, 0, 0, 0, 0, time.UTC)},
+		{name: "Default express", priority: domain.OrderPriorityExpress, want: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+		{name: "Configured lead time", opts: []Option{WithDeliveryLeadTime(10, -7)}, priority: domain.OrderPriorityExpress, want: time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)},
+		{name: "Never before order date", opts: []Option{WithDeliveryLeadTime(1, -3)}, priority: domain.OrderPriorityExpress, want: time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{}, tt.opts...)
+			if got := service.estimateDelivery(placed, tt.priority); !got.Equal(tt.want) {
+				t.Errorf("estimateDelivery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateOrderEstimatedDelivery(t *testing.T) {
+	service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{}, WithDeliveryLeadTime(4, -2))
+
+	order, err := service.CreateOrder(context.Background(), domain.CreateOrderRequest{
+		UserID:   "user1",
+		Items:    []domain.OrderItem{{ProductID: "101", Quantity: 1, Price: 10.0}},
+		Priority: "express",
+	})
+	if err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+	y, m, d := time.Now().UTC().Date()
+	want := time.Date(y, m, d+2, 0, 0, 0, 0, time.UTC)
+	if order.EstimatedDelivery == nil || !order.EstimatedDelivery.Equal(want) {
+		t.Errorf("EstimatedDelivery = %v, want %v", order.EstimatedDelivery, want)
+	}
+}
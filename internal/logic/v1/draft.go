@@ -0,0 +1,151 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"github.com/duynhne/order-service/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// DefaultDraftTTL is how long a draft waits for confirmation before it expires
+const DefaultDraftTTL = 30 * time.Minute
+
+// draftExpiryBatchSize caps the drafts expired per run; the rest wait for the next run
+const draftExpiryBatchSize = 500
+
+// WithDraftOrders makes CreateOrder create drafts: the owner reviews the totals and places the
+// order with ConfirmOrder, and unconfirmed drafts are cancelled by RunDraftExpiry. Without it
+// (the default) orders are placed as pending right away.
+func WithDraftOrders(enabled bool) Option {
+	return func(s *OrderService) {
+		s.drafts = enabled
+	}
+}
+
+// initialStatus is the status CreateOrder gives a new order
+func (s *OrderService) initialStatus() domain.OrderStatus {
+	if s.drafts {
+		return domain.OrderStatusDraft
+	}
+	return domain.OrderStatusPending
+}
+
+// ConfirmOrder places userID's draft order: it moves from draft to pending, where payment picks
+// it up. It is idempotent: confirming an order that is already placed changes nothing and
+// alreadyConfirmed is true. Returns ErrUnauthorized for another user's order and
+// ErrInvalidOrderState for a cancelled (or expired) draft.
+func (s *OrderService) ConfirmOrder(ctx context.Context, id, userID string) (order *domain.Order, alreadyConfirmed bool, err error) {
+	ctx, span := middleware.StartSpan(ctx, "order.confirm", trace.WithAttributes(
+		attribute.String("layer", "logic"),
+		attribute.String("order.id", id),
+	))
+	defer span.End()
+
+	if _, err := s.GetOrderStatus(ctx, id, userID); err != nil {
+		return nil, false, err
+	}
+
+	from, changed, err := s.transitionStatus(ctx, id, domain.OrderStatusPending, StatusSourceConfirm, false)
+	switch {
+	case err == nil:
+		alreadyConfirmed = !changed
+	case errors.Is(err, ErrInvalidOrderState) && from != domain.OrderStatusDraft && from != domain.OrderStatusCancelled:
+		// Placed and already moving on (paid, shipped, ...)
+		alreadyConfirmed = true
+	case errors.Is(err, ErrInvalidOrderState):
+		return nil, false, fmt.Errorf("confirm order %q in status %q: %w", id, from, ErrInvalidOrderState)
+	default:
+		span.RecordError(err)
+		return nil, false, err
+	}
+	span.SetAttributes(attribute.Bool("order.already_confirmed", alreadyConfirmed))
+
+	order, err = s.GetOrder(ctx, id)
+	if err != nil {
+		return nil, false, err
+	}
+	return order, alreadyConfirmed, nil
+}
+
+// ExpireDrafts cancels the drafts created before `before`, at most draftExpiryBatchSize per call.
+// A draft confirmed meanwhile is skipped. Returns the number of drafts cancelled.
+func (s *OrderService) ExpireDrafts(ctx context.Context, before time.Time, logger *zap.Logger) (int, error) {
+	ctx, span := middleware.StartSpan(ctx, "order.expire_drafts", trace.WithAttributes(
+		attribute.String("layer", "logic"),
+	))
+	defer span.End()
+
+	ids, err := s.orderRepo.FindDraftsCreatedBefore(ctx, before, draftExpiryBatchSize)
+	if err != nil {
+		span.RecordError(err)
+		return 0, err
+	}
+
+	expired := 0
+	for _, id := range ids {
+		changed, err := s.expireDraft(ctx, id)
+		if err != nil {
+			logger.Warn("Draft expiry: order not cancelled", zap.Error(err), zap.String("order_id", id))
+			continue
+		}
+		if changed {
+			expired++
+		}
+	}
+
+	span.SetAttributes(attribute.Int("drafts.expired", expired))
+	return expired, nil
+}
+
+// expireDraft cancels order id if it is still a draft. Unlike transitionStatus it checks the
+// current status under the order lock first: pending -> cancelled is a valid move too, and a
+// draft confirmed since it was found must stay placed.
+func (s *OrderService) expireDraft(ctx context.Context, id string) (bool, error) {
+	var expired bool
+	err := s.inTx(ctx, func(tx domain.Transaction) error {
+		if err := s.orderRepo.LockOrderWithTx(ctx, tx, id); err != nil {
+			return err
+		}
+		status, err := s.orderRepo.FindStatusForUpdateWithTx(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if expired = status == domain.OrderStatusDraft; !expired {
+			return nil
+		}
+		return s.applyTransitionWithTx(ctx, tx, id, status, domain.OrderStatusCancelled, StatusSourceDraftExpiry)
+	})
+	if err != nil || !expired {
+		return false, err
+	}
+	s.invalidateOrder(ctx, id)
+	return true, nil
+}
+
+// RunDraftExpiry runs ExpireDrafts every interval for drafts older than ttl, until ctx is cancelled
+func (s *OrderService) RunDraftExpiry(ctx context.Context, ttl, interval time.Duration, logger *zap.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			expired, err := s.ExpireDrafts(ctx, time.Now().Add(-ttl), logger)
+			if err != nil {
+				logger.Error("Draft expiry run failed", zap.Error(err))
+				continue
+			}
+			if expired > 0 {
+				logger.Info("Draft expiry run complete", zap.Int("expired", expired))
+			}
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+func TestCreateOrderInitialStatus(t *testing.T) {
+	req := domain.CreateOrderRequest{
+		UserID: "user1",
+		Items:  []domain.OrderItem{{ProductID: "1", ProductName: "Widget", Quantity: 1, Price: 10}},
+	}
+
+	for _, drafts := range []bool{false, true} {
+		service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{}, WithDraftOrders(drafts))
+		order, err := service.CreateOrder(context.Background(), req)
+		if err != nil {
+			t.Fatalf("CreateOrder(drafts=%v) error = %v", drafts, err)
+		}
+		want := domain.OrderStatusPending
+		if drafts {
+			want = domain.OrderStatusDraft
+		}
+		if order.Status != want {
+			t.Errorf("CreateOrder(drafts=%v) status = %q, want %q", drafts, order.Status, want)
+		}
+	}
+}
+
+func TestConfirmOrder(t *testing.T) {
+	tests := []struct {
+		name          string
+		current       domain.OrderStatus
+		userID        string
+		wantErr       error
+		wantAlready   bool
+		wantConfirmed bool // a draft -> pending entry was recorded
+	}{
+		{name: "Draft", current: domain.OrderStatusDraft, userID: "user1", wantConfirmed: true},
+		{name: "Already pending", current: domain.OrderStatusPending, userID: "user1", wantAlready: true},
+		{name: "Already paid", current: domain.OrderStatusPaid, userID: "user1", wantAlready: true},
+		{name: "Expired draft", current: domain.OrderStatusCancelled, userID: "user1", wantErr: ErrInvalidOrderState},
+		{name: "Another user's draft", current: domain.OrderStatusDraft, userID: "user2", wantErr: ErrUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockOrderRepository{
+				ownerID: "user1",
+				findStatusFunc: func(ctx context.Context, id string) (domain.OrderStatus, error) {
+					return tt.current, nil
+				},
+			}
+			service := NewOrderService(repo, &MockTransactionManager{})
+
+			order, already, err := service.ConfirmOrder(context.Background(), "1", tt.userID)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ConfirmOrder() error = %v, want %v", err, tt.wantErr)
+				}
+				if len(repo.updatedStatuses) != 0 {
+					t.Errorf("ConfirmOrder() wrote statuses %v despite error", repo.updatedStatuses)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ConfirmOrder() error = %v", err)
+			}
+			if order == nil || order.ID != "1" {
+				t.Errorf("ConfirmOrder() order = %+v, want order 1", order)
+			}
+			if already != tt.wantAlready {
+				t.Errorf("ConfirmOrder() alreadyConfirmed = %v, want %v", already, tt.wantAlready)
+			}
+
+			confirmed := len(repo.history) == 1 && repo.history[0].FromStatus == domain.OrderStatusDraft &&
+				repo.history[0].ToStatus == domain.OrderStatusPending && repo.history[0].Source == StatusSourceConfirm
+			if confirmed != tt.wantConfirmed {
+				t.Errorf("history = %+v, want confirmation recorded: %v", repo.history, tt.wantConfirmed)
+			}
+		})
+	}
+}
+
+func TestExpireDrafts(t *testing.T) {
+	// Order 2 was confirmed after the drafts were listed and must stay placed
+	statuses := map[string]domain.OrderStatus{
+		"1": domain.OrderStatusDraft,
+		"2": domain.OrderStatusPending,
+		"3": domain.OrderStatusDraft,
+	}
+	repo := &MockOrderRepository{
+		draftIDs: []string{"1", "2", "3"},
+		findStatusFunc: func(ctx context.Context, id string) (domain.OrderStatus, error) {
+			return statuses[id], nil
+		},
+	}
+	service := NewOrderService(repo, &MockTransactionManager{})
+
+	expired, err := service.ExpireDrafts(context.Background(), time.Now().Add(-DefaultDraftTTL), zap.NewNop())
+	if err != nil {
+		t.Fatalf("ExpireDrafts() error = %v", err)
+	}
+	if expired != 2 {
+		t.Errorf("ExpireDrafts() = %d, want 2", expired)
+	}
+	want := []domain.OrderStatus{domain.OrderStatusCancelled, domain.OrderStatusCancelled}
+	if !slices.Equal(repo.updatedStatuses, want) {
+		t.Errorf("updated statuses = %v, want %v", repo.updatedStatuses, want)
+	}
+	for _, change := range repo.history {
+		if change.FromStatus != domain.OrderStatusDraft || change.Source != StatusSourceDraftExpiry {
+			t.Errorf("history entry = %+v, want draft -> cancelled by %s", change, StatusSourceDraftExpiry)
+		}
+	}
+	if !slices.Equal(repo.lockedIDs, repo.draftIDs) {
+		t.Errorf("locked orders = %v, want %v", repo.lockedIDs, repo.draftIDs)
+	}
+}
+
+func TestListOrdersIncludeDrafts(t *testing.T) {
+	for _, include := range []bool{false, true} {
+		repo := &MockOrderRepository{}
+		service := NewOrderService(repo, &MockTransactionManager{})
+
+		_, _, err := service.ListOrders(context.Background(), "user1", domain.Page{Limit: 10}, ListOptions{IncludeDrafts: include})
+		if err != nil {
+			t.Fatalf("ListOrders() error = %v", err)
+		}
+		if want := []bool{include, include}; !slices.Equal(repo.includeDrafts, want) {
+			t.Errorf("includeDrafts passed to find and count = %v, want %v", repo.includeDrafts, want)
+		}
+	}
+}
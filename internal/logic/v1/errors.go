@@ -15,7 +15,12 @@
 //	}
 package v1
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+)
 
 // Sentinel errors for order operations.
 var (
@@ -23,6 +28,11 @@ var (
 	// HTTP Status: 404 Not Found
 	ErrOrderNotFound = errors.New("order not found")
 
+	// ErrOrderGone indicates the order existed but was purged. It wraps ErrOrderNotFound so callers
+	// that do not distinguish the two treat it as not found.
+	// HTTP Status: 410 Gone (or 404 Not Found, see HandlerConfig.GoneForPurged)
+	ErrOrderGone = fmt.Errorf("order purged: %w", ErrOrderNotFound)
+
 	// ErrInvalidOrderState indicates the order is in an invalid state for the requested operation.
 	// HTTP Status: 400 Bad Request
 	ErrInvalidOrderState = errors.New("invalid order state")
@@ -30,11 +40,51 @@ var (
 	// ErrInvalidOrder is an alias for ErrInvalidOrderState (backward compatibility)
 	ErrInvalidOrder = ErrInvalidOrderState
 
+	// ErrEmptyCart indicates an order was requested from a cart holding no items. It wraps ErrInvalidOrder.
+	// HTTP Status: 400 Bad Request
+	ErrEmptyCart = fmt.Errorf("cart is empty: %w", ErrInvalidOrder)
+
+	// ErrItemNotFound indicates the order has no active item for the requested product.
+	// HTTP Status: 404 Not Found
+	ErrItemNotFound = errors.New("order item not found")
+
 	// ErrPaymentFailed indicates the payment processing failed.
 	// HTTP Status: 402 Payment Required
 	ErrPaymentFailed = errors.New("payment failed")
 
+	// ErrInvalidInput indicates a malformed or missing request parameter.
+	// HTTP Status: 400 Bad Request
+	ErrInvalidInput = errors.New("invalid input")
+
+	// ErrInvalidAddress indicates a shipping address failed validation. It wraps ErrInvalidInput.
+	// HTTP Status: 400 Bad Request
+	ErrInvalidAddress = fmt.Errorf("invalid shipping address: %w", ErrInvalidInput)
+
+	// ErrDuplicateExternalRef indicates the user already has an order with the given external reference.
+	// HTTP Status: 409 Conflict
+	ErrDuplicateExternalRef = errors.New("duplicate external reference")
+
+	// ErrInsufficientStock indicates the inventory cannot fill one or more items of a new order;
+	// *InsufficientStockError lists them.
+	// HTTP Status: 409 Conflict
+	ErrInsufficientStock = errors.New("insufficient stock")
+
 	// ErrUnauthorized indicates the user is not authorized to access the order.
 	// HTTP Status: 403 Forbidden
 	ErrUnauthorized = errors.New("unauthorized access")
+
+	// ErrDatabaseBusy indicates no database connection became free within DB_ACQUIRE_TIMEOUT.
+	// Repository errors are passed through wrapped, so it is the domain error itself.
+	// HTTP Status: 503 Service Unavailable (with Retry-After)
+	ErrDatabaseBusy = domain.ErrDatabaseBusy
+
+	// ErrCommitUnknown indicates the database connection was lost while committing: the change may or
+	// may not have been applied, so the caller must check before repeating it.
+	// HTTP Status: 500 Internal Server Error (with a message saying so)
+	ErrCommitUnknown = domain.ErrCommitUnknown
+
+	// ErrRetryable indicates the database connection was lost inside a transaction and every
+	// re-run of it failed the same way; nothing was committed.
+	// HTTP Status: 503 Service Unavailable (with Retry-After)
+	ErrRetryable = domain.ErrRetryable
 )
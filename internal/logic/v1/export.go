@@ -0,0 +1,73 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"github.com/duynhne/order-service/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// MaxExportRange caps the created_at window of one export request
+	MaxExportRange = 31 * 24 * time.Hour
+	// exportBatchSize is the number of orders fetched (and items batch-loaded) per keyset query
+	exportBatchSize = 500
+)
+
+// ExportOrders streams every order created in [from, to), items included, to emit in
+// (created_at, id) order. Orders are read in keyset batches of exportBatchSize, so memory
+// stays bounded regardless of the range size. Stops at the first error from emit.
+// Returns the number of orders emitted, and ErrInvalidInput if the range is empty, inverted, or longer than MaxExportRange.
+func (s *OrderService) ExportOrders(
+	ctx context.Context, from, to time.Time, emit func(domain.Order) error,
+) (int, error) {
+	ctx, span := middleware.StartSpan(ctx, "order.export", trace.WithAttributes(
+		attribute.String("layer", "logic"),
+		attribute.String("export.from", from.UTC().Format(time.RFC3339)),
+		attribute.String("export.to", to.UTC().Format(time.RFC3339)),
+	))
+	defer span.End()
+
+	if !to.After(from) || to.Sub(from) > MaxExportRange {
+		return 0, fmt.Errorf("export range %s - %s (max %s): %w", from, to, MaxExportRange, ErrInvalidInput)
+	}
+
+	var (
+		cursor   domain.OrderCursor
+		exported int
+	)
+	for {
+		orders, err := s.orderRepo.FindCreatedBetween(ctx, from, to, cursor, exportBatchSize)
+		if err != nil {
+			span.RecordError(err)
+			return exported, err
+		}
+		if len(orders) == 0 {
+			break
+		}
+
+		if err := s.attachItems(ctx, orders); err != nil {
+			span.RecordError(err)
+			return exported, err
+		}
+		for _, order := range orders {
+			if err := emit(order); err != nil {
+				return exported, err
+			}
+			exported++
+		}
+
+		if len(orders) < exportBatchSize {
+			break
+		}
+		last := orders[len(orders)-1]
+		cursor = domain.OrderCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	span.SetAttributes(attribute.Int("export.count", exported))
+	return exported, nil
+}
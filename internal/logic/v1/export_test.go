@@ -0,0 +1,81 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+)
+
+func TestExportOrders(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	// Two full keyset batches plus a partial one
+	total := 2*exportBatchSize + 7
+	repo := &MockOrderRepository{
+		itemsByOrder: map[string][]domain.OrderItem{"1": {{ProductID: "101", Quantity: 1}}},
+	}
+	for i := 1; i <= total; i++ {
+		repo.createdBetween = append(repo.createdBetween, domain.Order{
+			ID:        strconv.Itoa(i),
+			CreatedAt: from.Add(time.Duration(i) * time.Second),
+		})
+	}
+	service := NewOrderService(repo, &MockTransactionManager{})
+
+	var ids []string
+	n, err := service.ExportOrders(ctx, from, to, func(order domain.Order) error {
+		if order.ID == "1" && len(order.Items) != 1 {
+			t.Errorf("order 1 items = %v, want 1 item", order.Items)
+		}
+		ids = append(ids, order.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ExportOrders() error = %v", err)
+	}
+	if n != total || len(ids) != total || ids[total-1] != strconv.Itoa(total) {
+		t.Errorf("ExportOrders() exported %d (%d emitted), want %d in order", n, len(ids), total)
+	}
+	if len(repo.exportCursors) != 3 || repo.exportCursors[1].ID != strconv.Itoa(exportBatchSize) {
+		t.Errorf("keyset cursors = %+v, want 3 batches resuming after the last ID", repo.exportCursors)
+	}
+
+	// emit errors stop the export
+	stop := errors.New("client gone")
+	n, err = service.ExportOrders(ctx, from, to, func(domain.Order) error { return stop })
+	if !errors.Is(err, stop) || n != 0 {
+		t.Errorf("ExportOrders() with failing emit = %d, %v, want 0, %v", n, err, stop)
+	}
+}
+
+func TestExportOrdersRange(t *testing.T) {
+	ctx := context.Background()
+	service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{})
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		to      time.Time
+		wantErr error
+	}{
+		{name: "Max range", to: from.Add(MaxExportRange)},
+		{name: "Over max range", to: from.Add(MaxExportRange + time.Second), wantErr: ErrInvalidInput},
+		{name: "Empty range", to: from, wantErr: ErrInvalidInput},
+		{name: "Inverted range", to: from.Add(-time.Hour), wantErr: ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.ExportOrders(ctx, from, tt.to, func(domain.Order) error { return nil })
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ExportOrders() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"github.com/duynhne/order-service/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// maxExternalRefLength matches the orders.external_ref column
+const maxExternalRefLength = 100
+
+// validateExternalRef accepts an empty reference (none) or up to maxExternalRefLength printable
+// characters without surrounding whitespace, so the stored value matches what lookups send.
+func validateExternalRef(ref string) error {
+	if ref == "" {
+		return nil
+	}
+	if utf8.RuneCountInString(ref) > maxExternalRefLength || strings.TrimSpace(ref) != ref || !printable(ref) {
+		return fmt.Errorf("invalid external ref %q: %w", ref, ErrInvalidOrder)
+	}
+	return nil
+}
+
+// GetOrderByExternalRef retrieves userID's order by the external reference given at creation.
+// Only the caller's own orders are searched, so another user's reference is simply not found.
+func (s *OrderService) GetOrderByExternalRef(ctx context.Context, userID, ref string) (*domain.Order, error) {
+	ctx, span := middleware.StartSpan(ctx, "order.get_by_external_ref", trace.WithAttributes(
+		attribute.String("layer", "logic"),
+		attribute.String("user.id", userID),
+	))
+	defer span.End()
+
+	if ref == "" || validateExternalRef(ref) != nil {
+		return nil, fmt.Errorf("get order: invalid external ref %q: %w", ref, ErrInvalidInput)
+	}
+
+	order, err := s.orderRepo.FindByExternalRef(ctx, userID, ref)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			span.SetAttributes(attribute.Bool("order.found", false))
+			return nil, fmt.Errorf("get order by external ref %q: %w", ref, ErrOrderNotFound)
+		}
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Bool("order.found", true), attribute.String("order.id", order.ID))
+	return order, nil
+}
+
+// MaxExternalRefsPerLookup caps the references accepted by one GetOrdersByExternalRefs call
+const MaxExternalRefsPerLookup = 100
+
+// GetOrdersByExternalRefs retrieves userID's orders created with any of refs, with their items,
+// newest first. Duplicate references are looked up once and references without an order are
+// simply absent from the result. Returns ErrInvalidInput for an empty list, more than
+// MaxExternalRefsPerLookup distinct references, or an invalid reference.
+func (s *OrderService) GetOrdersByExternalRefs(ctx context.Context, userID string, refs []string) ([]domain.Order, error) {
+	ctx, span := middleware.StartSpan(ctx, "order.get_by_external_refs", trace.WithAttributes(
+		attribute.String("layer", "logic"),
+		attribute.String("user.id", userID),
+		attribute.Int("refs.count", len(refs)),
+	))
+	defer span.End()
+
+	unique := make([]string, 0, len(refs))
+	seen := make(map[string]bool, len(refs))
+	for _, ref := range refs {
+		if ref == "" || validateExternalRef(ref) != nil {
+			return nil, fmt.Errorf("get orders: invalid external ref %q: %w", ref, ErrInvalidInput)
+		}
+		if !seen[ref] {
+			seen[ref] = true
+			unique = append(unique, ref)
+		}
+	}
+	if len(unique) == 0 || len(unique) > MaxExternalRefsPerLookup {
+		return nil, fmt.Errorf("get orders: %d external refs, want 1 to %d: %w",
+			len(unique), MaxExternalRefsPerLookup, ErrInvalidInput)
+	}
+
+	orders, err := s.orderRepo.FindByExternalRefs(ctx, userID, unique)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	if len(orders) > 0 {
+		if err := s.attachItems(ctx, orders); err != nil {
+			span.RecordError(err)
+			return nil, err
+		}
+	}
+
+	span.SetAttributes(attribute.Int("orders.count", len(orders)))
+	return orders, nil
+}
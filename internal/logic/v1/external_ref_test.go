@@ -0,0 +1,134 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+)
+
+func TestCreateOrderExternalRef(t *testing.T) {
+	ctx := context.Background()
+	items := []domain.OrderItem{{ProductID: "101", Quantity: 1, Price: 10.0}}
+
+	tests := []struct {
+		name      string
+		ref       string
+		createErr error
+		wantErr   error
+	}{
+		{name: "No reference", ref: ""},
+		{name: "New reference", ref: "shop-1001"},
+		{name: "Duplicate reference", ref: "shop-1001", createErr: fmt.Errorf("insert: %w", domain.ErrConflict), wantErr: ErrDuplicateExternalRef},
+		{name: "Too long", ref: strings.Repeat("r", maxExternalRefLength+1), wantErr: ErrInvalidOrder},
+		{name: "Surrounding whitespace", ref: " shop-1001", wantErr: ErrInvalidOrder},
+		{name: "Control character", ref: "shop\n1001", wantErr: ErrInvalidOrder},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stored string
+			repo := &MockOrderRepository{
+				createWithTxFunc: func(ctx context.Context, tx domain.Transaction, order *domain.Order) error {
+					stored = order.ExternalRef
+					return tt.createErr
+				},
+			}
+			service := NewOrderService(repo, &MockTransactionManager{})
+
+			order, err := service.CreateOrder(ctx, domain.CreateOrderRequest{UserID: "user1", Items: items, ExternalRef: tt.ref})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("CreateOrder() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateOrder() error = %v", err)
+			}
+			if order.ExternalRef != tt.ref || stored != tt.ref {
+				t.Errorf("external ref = %q (stored %q), want %q", order.ExternalRef, stored, tt.ref)
+			}
+		})
+	}
+}
+
+func TestGetOrderByExternalRef(t *testing.T) {
+	ctx := context.Background()
+	repo := &MockOrderRepository{
+		externalRefs: map[string]*domain.Order{"alice/shop-1001": {ID: "7", UserID: "alice", ExternalRef: "shop-1001"}},
+	}
+	service := NewOrderService(repo, &MockTransactionManager{})
+
+	order, err := service.GetOrderByExternalRef(ctx, "alice", "shop-1001")
+	if err != nil || order.ID != "7" {
+		t.Fatalf("GetOrderByExternalRef() = %+v, %v", order, err)
+	}
+	if _, err := service.GetOrderByExternalRef(ctx, "bob", "shop-1001"); !errors.Is(err, ErrOrderNotFound) {
+		t.Errorf("GetOrderByExternalRef(other user) error = %v, want ErrOrderNotFound", err)
+	}
+	if _, err := service.GetOrderByExternalRef(ctx, "alice", ""); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("GetOrderByExternalRef(empty) error = %v, want ErrInvalidInput", err)
+	}
+}
+
+func TestGetOrdersByExternalRefs(t *testing.T) {
+	ctx := context.Background()
+	tooMany := make([]string, MaxExternalRefsPerLookup+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("shop-%d", i)
+	}
+
+	tests := []struct {
+		name    string
+		userID  string
+		refs    []string
+		wantIDs []string
+		wantErr error
+	}{
+		{name: "Matches and unknown", userID: "alice", refs: []string{"shop-1001", "shop-404", "shop-1002"}, wantIDs: []string{"7", "8"}},
+		{name: "Duplicates looked up once", userID: "alice", refs: []string{"shop-1001", "shop-1001"}, wantIDs: []string{"7"}},
+		{name: "Other user's refs", userID: "bob", refs: []string{"shop-1001"}},
+		{name: "Empty list", userID: "alice", refs: nil, wantErr: ErrInvalidInput},
+		{name: "Empty ref", userID: "alice", refs: []string{"shop-1001", ""}, wantErr: ErrInvalidInput},
+		{name: "Too many", userID: "alice", refs: tooMany, wantErr: ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockOrderRepository{
+				externalRefs: map[string]*domain.Order{
+					"alice/shop-1001": {ID: "7", UserID: "alice", ExternalRef: "shop-1001"},
+					"alice/shop-1002": {ID: "8", UserID: "alice", ExternalRef: "shop-1002"},
+				},
+				itemsByOrder: map[string][]domain.OrderItem{"7": {{ProductID: "101", Quantity: 1}}},
+			}
+			service := NewOrderService(repo, &MockTransactionManager{})
+
+			orders, err := service.GetOrdersByExternalRefs(ctx, tt.userID, tt.refs)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("GetOrdersByExternalRefs() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetOrdersByExternalRefs() error = %v", err)
+			}
+			if len(orders) != len(tt.wantIDs) {
+				t.Fatalf("GetOrdersByExternalRefs() returned %d orders, want %d", len(orders), len(tt.wantIDs))
+			}
+			for i, order := range orders {
+				if order.ID != tt.wantIDs[i] {
+					t.Errorf("orders[%d].ID = %q, want %q", i, order.ID, tt.wantIDs[i])
+				}
+			}
+			if len(orders) > 0 && len(orders[0].Items) != 1 {
+				t.Errorf("orders[0].Items = %v, want the batch-loaded items", orders[0].Items)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"github.com/duynhne/order-service/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// CreateOrderFromCart creates an order from the items of the user's cart snapshot, taken by the
+// caller from the cart service; req carries everything else (user, address, priority, ...) and
+// its Items are replaced by cart. Returns ErrEmptyCart when the cart holds no items; otherwise
+// the order is validated and priced exactly like CreateOrder.
+func (s *OrderService) CreateOrderFromCart(
+	ctx context.Context, req domain.CreateOrderRequest, cart []domain.OrderItem,
+) (*domain.Order, error) {
+	ctx, span := middleware.StartSpan(ctx, "order.create_from_cart", trace.WithAttributes(
+		attribute.String("layer", "logic"),
+		attribute.String("user.id", req.UserID),
+		attribute.Int("cart.items", len(cart)),
+	))
+	defer span.End()
+
+	if len(cart) == 0 {
+		span.SetAttributes(attribute.Bool("order.created", false))
+		return nil, ErrEmptyCart
+	}
+
+	req.Items = cart
+	return s.CreateOrder(ctx, req)
+}
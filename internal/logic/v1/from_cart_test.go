@@ -0,0 +1,49 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+)
+
+func TestCreateOrderFromCart(t *testing.T) {
+	ctx := context.Background()
+	req := domain.CreateOrderRequest{UserID: "user1", Priority: "express", ExternalRef: "shop-1001"}
+
+	t.Run("Empty cart", func(t *testing.T) {
+		created := false
+		repo := &MockOrderRepository{
+			createWithTxFunc: func(ctx context.Context, tx domain.Transaction, order *domain.Order) error {
+				created = true
+				return nil
+			},
+		}
+		service := NewOrderService(repo, &MockTransactionManager{})
+
+		_, err := service.CreateOrderFromCart(ctx, req, nil)
+		if !errors.Is(err, ErrEmptyCart) || !errors.Is(err, ErrInvalidOrder) {
+			t.Errorf("CreateOrderFromCart(empty) error = %v, want ErrEmptyCart wrapping ErrInvalidOrder", err)
+		}
+		if created {
+			t.Error("CreateOrderFromCart(empty) created an order")
+		}
+	})
+
+	t.Run("Cart items", func(t *testing.T) {
+		service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{})
+		cart := []domain.OrderItem{{ProductID: "101", Quantity: 2, Price: 10}, {ProductID: "102", Quantity: 1, Price: 5}}
+
+		order, err := service.CreateOrderFromCart(ctx, req, cart)
+		if err != nil {
+			t.Fatalf("CreateOrderFromCart() error = %v", err)
+		}
+		if len(order.Items) != 2 || order.Subtotal != 25 {
+			t.Errorf("order items = %+v, subtotal %v, want both cart items totalling 25", order.Items, order.Subtotal)
+		}
+		if order.UserID != "user1" || order.Priority != domain.OrderPriorityExpress || order.ExternalRef != "shop-1001" {
+			t.Errorf("order = %+v, want user, priority and external ref from the request", order)
+		}
+	})
+}
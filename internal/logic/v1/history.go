@@ -0,0 +1,36 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"github.com/duynhne/order-service/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// GetStatusHistory returns userID's order together with its status transitions, oldest first.
+// Returns ErrUnauthorized if the order belongs to another user.
+func (s *OrderService) GetStatusHistory(
+	ctx context.Context, id, userID string,
+) (*domain.Order, []domain.StatusChange, error) {
+	ctx, span := middleware.StartSpan(ctx, "order.status_history", trace.WithAttributes(
+		attribute.String("layer", "logic"),
+		attribute.String("order.id", id),
+	))
+	defer span.End()
+
+	order, err := s.GetUserOrder(ctx, id, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	history, err := s.orderRepo.FindStatusHistory(ctx, order.ID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, nil, err
+	}
+
+	span.SetAttributes(attribute.Int("history.count", len(history)))
+	return order, history, nil
+}
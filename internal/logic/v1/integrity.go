@@ -0,0 +1,34 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"github.com/duynhne/order-service/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// FindOrphanedItems returns one page of order items whose order no longer exists, plus their total
+// count (admin only; role is enforced by the caller). It only reports them: cleaning up is left to
+// ops, who may want to inspect or restore the orders first.
+func (s *OrderService) FindOrphanedItems(ctx context.Context, page domain.Page) ([]domain.OrphanedItem, int, error) {
+	ctx, span := middleware.StartSpan(ctx, "order.integrity.orphaned_items", trace.WithAttributes(
+		attribute.String("layer", "logic"),
+		attribute.Int("page.limit", page.Limit),
+		attribute.Int("page.offset", page.Offset),
+	))
+	defer span.End()
+
+	items, total, err := s.orderRepo.FindOrphanedItems(ctx, page)
+	if err != nil {
+		span.RecordError(err)
+		return nil, 0, err
+	}
+
+	span.SetAttributes(attribute.Int("items.count", len(items)), attribute.Int("items.total", total))
+	if items == nil {
+		items = []domain.OrphanedItem{}
+	}
+	return items, total, nil
+}
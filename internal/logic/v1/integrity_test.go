@@ -0,0 +1,36 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+)
+
+func TestFindOrphanedItems(t *testing.T) {
+	repo := &MockOrderRepository{orphanedItems: []domain.OrphanedItem{
+		{ID: "7", OrderID: "3", OrderItem: domain.OrderItem{ProductID: "101", Quantity: 1}},
+		{ID: "8", OrderID: "3", OrderItem: domain.OrderItem{ProductID: "102", Quantity: 2}},
+		{ID: "9", OrderID: "5", OrderItem: domain.OrderItem{ProductID: "101", Quantity: 1}},
+	}}
+	service := NewOrderService(repo, &MockTransactionManager{})
+	ctx := context.Background()
+
+	items, total, err := service.FindOrphanedItems(ctx, domain.Page{Limit: 2, Offset: 1})
+	if err != nil {
+		t.Fatalf("FindOrphanedItems() error = %v", err)
+	}
+	if total != 3 || len(items) != 2 || items[0].ID != "8" || items[1].ID != "9" {
+		t.Errorf("FindOrphanedItems() = %+v (total %d), want items 8 and 9 of 3", items, total)
+	}
+
+	// Without orphans the page is an empty JSON array, not null
+	items, _, err = NewOrderService(&MockOrderRepository{}, &MockTransactionManager{}).FindOrphanedItems(ctx, domain.Page{Limit: 2})
+	if err != nil {
+		t.Fatalf("FindOrphanedItems() error = %v", err)
+	}
+	if body, _ := json.Marshal(items); string(body) != "[]" {
+		t.Errorf("FindOrphanedItems() without orphans = %s, want []", body)
+	}
+}
@@ -0,0 +1,72 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"github.com/duynhne/order-service/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// MaxInternalNoteLength is the maximum internal note length in characters
+const MaxInternalNoteLength = 2000
+
+// GetInternalNote returns the staff-only note of an order (admin only; ErrUnauthorized for a
+// non-admin AuthContext)
+func (s *OrderService) GetInternalNote(ctx context.Context, id string) (*domain.InternalNote, error) {
+	ctx, span := middleware.StartSpan(ctx, "order.get_internal_note", trace.WithAttributes(
+		attribute.String("layer", "logic"),
+		attribute.String("order.id", id),
+	))
+	defer span.End()
+
+	if err := requireAdmin(ctx, "get internal note"); err != nil {
+		return nil, err
+	}
+	note, err := s.orderRepo.FindInternalNote(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("get internal note for order %q: %w", id, ErrOrderNotFound)
+		}
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return &domain.InternalNote{OrderID: id, Note: note}, nil
+}
+
+// SetInternalNote replaces the staff-only note of an order (admin only; ErrUnauthorized for a
+// non-admin AuthContext).
+// The note is trimmed; an empty note clears it. Returns ErrInvalidInput if it exceeds MaxInternalNoteLength.
+func (s *OrderService) SetInternalNote(ctx context.Context, id, note string) (*domain.InternalNote, error) {
+	ctx, span := middleware.StartSpan(ctx, "order.set_internal_note", trace.WithAttributes(
+		attribute.String("layer", "logic"),
+		attribute.String("order.id", id),
+	))
+	defer span.End()
+
+	if err := requireAdmin(ctx, "set internal note"); err != nil {
+		return nil, err
+	}
+	note = strings.TrimSpace(note)
+	if !utf8.ValidString(note) || utf8.RuneCountInString(note) > MaxInternalNoteLength {
+		return nil, fmt.Errorf("internal note for order %q exceeds %d characters: %w",
+			id, MaxInternalNoteLength, ErrInvalidInput)
+	}
+
+	if err := s.orderRepo.UpdateInternalNote(ctx, id, note); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("set internal note for order %q: %w", id, ErrOrderNotFound)
+		}
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("note.length", utf8.RuneCountInString(note)))
+	return &domain.InternalNote{OrderID: id, Note: note}, nil
+}
@@ -0,0 +1,152 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"github.com/duynhne/order-service/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// CancelOrderItem cancels the items of productID in userID's order and recomputes the order
+// subtotal, shipping, promotions and total from the remaining active items: a promotion the
+// remaining items no longer qualify for is dropped. Cancelling the last active item
+// cancels the whole order (recorded in status history with source StatusSourceItemCancel).
+//
+// The order's revision is incremented in the same transaction.
+//
+// Items can only be cancelled while the order itself could still be cancelled, i.e. before it
+// ships; otherwise ErrInvalidOrderState. Returns ErrItemNotFound if the order has no active item
+// for productID.
+func (s *OrderService) CancelOrderItem(ctx context.Context, id, productID, userID string) (*domain.Order, error) {
+	ctx, span := middleware.StartSpan(ctx, "order.cancel_item", trace.WithAttributes(
+		attribute.String("layer", "logic"),
+		attribute.String("order.id", id),
+		attribute.String("item.product_id", productID),
+	))
+	defer span.End()
+
+	order, err := s.GetUserOrder(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var result itemCancelResult
+	err = s.inTx(ctx, func(tx domain.Transaction) error {
+		var err error
+		result, err = s.cancelItemWithTx(ctx, tx, id, productID, order)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.invalidateOrder(ctx, id)
+	if result.orderCancelled {
+		s.notifyStatusChange(ctx, id, result.status, domain.OrderStatusCancelled)
+	}
+
+	span.SetAttributes(
+		attribute.Int("items.remaining", result.remaining),
+		attribute.Bool("order.cancelled", result.orderCancelled),
+		attribute.Int("order.revision", result.revision),
+	)
+	return s.GetOrder(ctx, id)
+}
+
+// itemCancelResult is what cancelItemWithTx changed
+type itemCancelResult struct {
+	status         domain.OrderStatus // before the cancellation
+	remaining      int                // active items left
+	orderCancelled bool
+	revision       int
+}
+
+// cancelItemWithTx cancels the items of productID in order id (as read before tx) and rewrites its
+// totals and promotions within tx
+func (s *OrderService) cancelItemWithTx(
+	ctx context.Context, tx domain.Transaction, id, productID string, order *domain.Order,
+) (itemCancelResult, error) {
+	// Lock the order so concurrent item cancellations and status changes serialize
+	if err := s.orderRepo.LockOrderWithTx(ctx, tx, id); err != nil {
+		return itemCancelResult{}, err
+	}
+	status, err := s.orderRepo.FindStatusForUpdateWithTx(ctx, tx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return itemCancelResult{}, fmt.Errorf("cancel item of order %q: %w", id, ErrOrderNotFound)
+		}
+		return itemCancelResult{}, err
+	}
+	if !s.transitions.Allows(status, domain.OrderStatusCancelled) {
+		return itemCancelResult{}, fmt.Errorf("cancel item of %s order %q: %w", status, id, ErrInvalidOrderState)
+	}
+
+	items, err := s.orderRepo.FindItemsWithTx(ctx, tx, id)
+	if err != nil {
+		return itemCancelResult{}, err
+	}
+	var (
+		remaining   []domain.OrderItem
+		found       bool
+		subtotal    float64
+		tax         float64
+		totalWeight float64
+	)
+	for _, item := range items {
+		switch {
+		case item.Cancelled:
+		case item.ProductID == productID:
+			found = true
+		default:
+			remaining = append(remaining, item)
+			subtotal = s.roundMoney(subtotal + item.Subtotal)
+			tax = s.roundMoney(tax + item.Tax)
+			totalWeight += item.Weight * float64(item.Quantity)
+		}
+	}
+	if !found {
+		return itemCancelResult{}, fmt.Errorf("cancel item %q of order %q: %w", productID, id, ErrItemNotFound)
+	}
+
+	if err := s.orderRepo.CancelItemWithTx(ctx, tx, id, productID); err != nil {
+		return itemCancelResult{}, err
+	}
+	revision, err := s.orderRepo.IncrementRevisionWithTx(ctx, tx, id)
+	if err != nil {
+		return itemCancelResult{}, err
+	}
+
+	var shipping float64
+	if len(remaining) > 0 {
+		shipping = s.roundMoney(s.shipping.Calculate(subtotal, remaining, order.Priority))
+	}
+	// Promotions are re-evaluated like at creation, so cancelling below a threshold loses the discount
+	promotions, discount := s.applyPromotions(remaining, subtotal)
+	total := s.roundMoney(subtotal + shipping + tax - discount)
+	if err := s.orderRepo.UpdateTotalsWithTx(ctx, tx, id, subtotal, shipping, tax, discount, total, totalWeight); err != nil {
+		return itemCancelResult{}, err
+	}
+	if len(promotions) > 0 || len(order.Promotions) > 0 {
+		if err := s.orderRepo.ReplacePromotionsWithTx(ctx, tx, id, promotions); err != nil {
+			return itemCancelResult{}, err
+		}
+	}
+
+	orderCancelled := len(remaining) == 0
+	if orderCancelled {
+		err := s.applyTransitionWithTx(ctx, tx, id, status, domain.OrderStatusCancelled, StatusSourceItemCancel)
+		if err != nil {
+			return itemCancelResult{}, err
+		}
+	}
+
+	return itemCancelResult{
+		status:         status,
+		remaining:      len(remaining),
+		orderCancelled: orderCancelled,
+		revision:       revision,
+	}, nil
+}
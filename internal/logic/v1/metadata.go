@@ -0,0 +1,39 @@
+package v1
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Order metadata limits; keep the JSONB column small enough to return on every read
+const (
+	maxMetadataEntries     = 20
+	maxMetadataKeyLength   = 40
+	maxMetadataValueLength = 500
+)
+
+// validateMetadata enforces metadata limits: at most maxMetadataEntries pairs,
+// non-empty keys up to maxMetadataKeyLength characters, values up to maxMetadataValueLength,
+// valid UTF-8 and no control characters.
+func validateMetadata(metadata map[string]string) error {
+	if len(metadata) > maxMetadataEntries {
+		return fmt.Errorf("metadata has %d entries, max %d: %w", len(metadata), maxMetadataEntries, ErrInvalidOrder)
+	}
+	for key, value := range metadata {
+		keyLen := utf8.RuneCountInString(key)
+		if strings.TrimSpace(key) == "" || keyLen > maxMetadataKeyLength || !printable(key) {
+			return fmt.Errorf("invalid metadata key %q: %w", key, ErrInvalidOrder)
+		}
+		if utf8.RuneCountInString(value) > maxMetadataValueLength || !printable(value) {
+			return fmt.Errorf("invalid metadata value for key %q: %w", key, ErrInvalidOrder)
+		}
+	}
+	return nil
+}
+
+// printable reports whether s is valid UTF-8 without control characters
+func printable(s string) bool {
+	return utf8.ValidString(s) && strings.IndexFunc(s, unicode.IsControl) < 0
+}
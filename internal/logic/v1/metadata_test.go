@@ -0,0 +1,42 @@
+package v1
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestValidateMetadata(t *testing.T) {
+	tooMany := make(map[string]string, maxMetadataEntries+1)
+	for i := range maxMetadataEntries + 1 {
+		tooMany["k"+strconv.Itoa(i)] = "v"
+	}
+
+	tests := []struct {
+		name     string
+		metadata map[string]string
+		wantErr  bool
+	}{
+		{name: "nil", metadata: nil},
+		{name: "valid", metadata: map[string]string{"storefront": "eu", "gift_wrap": "true"}},
+		{name: "max value length", metadata: map[string]string{"note": strings.Repeat("é", maxMetadataValueLength)}},
+		{name: "too many entries", metadata: tooMany, wantErr: true},
+		{name: "empty key", metadata: map[string]string{" ": "v"}, wantErr: true},
+		{name: "key too long", metadata: map[string]string{strings.Repeat("k", maxMetadataKeyLength+1): "v"}, wantErr: true},
+		{name: "value too long", metadata: map[string]string{"k": strings.Repeat("v", maxMetadataValueLength+1)}, wantErr: true},
+		{name: "control character", metadata: map[string]string{"k": "a\x00b"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMetadata(tt.metadata)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("validateMetadata() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidOrder) {
+				t.Errorf("validateMetadata() error = %v, want ErrInvalidOrder", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package v1
+
+import "math"
+
+// RoundingMode selects how amounts with fractional cents are rounded to whole cents
+type RoundingMode string
+
+// Rounding modes selectable via ORDER_ROUNDING_MODE
+const (
+	RoundingHalfUp   RoundingMode = "half_up"   // 0.125 -> 0.13 (ties away from zero)
+	RoundingHalfEven RoundingMode = "half_even" // 0.125 -> 0.12 (bankers: ties to the even cent)
+)
+
+// centEpsilonScale snaps binary float noise off the cent value before rounding, so an amount that
+// is exactly half a cent in decimal (1.005 stored as 1.00499999...) is treated as a tie
+const centEpsilonScale = 1e6
+
+// roundCents rounds amount to whole cents using mode; any mode other than RoundingHalfEven
+// rounds half up
+func roundCents(amount float64, mode RoundingMode) float64 {
+	cents := math.Round(amount*100*centEpsilonScale) / centEpsilonScale
+	if mode == RoundingHalfEven {
+		return math.RoundToEven(cents) / 100
+	}
+	return math.Round(cents) / 100
+}
+
+// wholeCents reports whether amount has at most two decimal places, ignoring binary float noise.
+// Prices are stored as DECIMAL(10,2), so a finer price would be rounded by Postgres and no longer
+// match the item subtotal computed from it.
+func wholeCents(amount float64) bool {
+	cents := math.Round(amount*100*centEpsilonScale) / centEpsilonScale
+	return cents == math.Trunc(cents)
+}
+
+// roundMoney rounds amount to cents with the service's rounding mode. Every computed amount
+// (item subtotal, tax, shipping, and the order subtotal, tax and total) goes through it so quotes and created orders agree to the cent.
+func (s *OrderService) roundMoney(amount float64) float64 {
+	return roundCents(amount, s.rounding)
+}
@@ -0,0 +1,115 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+)
+
+func TestRoundCents(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   float64
+		halfUp   float64
+		halfEven float64
+	}{
+		{name: "Tie, even cent below", amount: 0.125, halfUp: 0.13, halfEven: 0.12},
+		{name: "Tie, odd cent below", amount: 0.135, halfUp: 0.14, halfEven: 0.14},
+		{name: "Tie not exact in binary", amount: 1.005, halfUp: 1.01, halfEven: 1.00},
+		{name: "Below half", amount: 2.344, halfUp: 2.34, halfEven: 2.34},
+		{name: "Above half", amount: 2.346, halfUp: 2.35, halfEven: 2.35},
+		{name: "Whole cents", amount: 19.99, halfUp: 19.99, halfEven: 19.99},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := roundCents(tt.amount, RoundingHalfUp); got != tt.halfUp {
+				t.Errorf("roundCents(%v, half_up) = %v, want %v", tt.amount, got, tt.halfUp)
+			}
+			if got := roundCents(tt.amount, RoundingHalfEven); got != tt.halfEven {
+				t.Errorf("roundCents(%v, half_even) = %v, want %v", tt.amount, got, tt.halfEven)
+			}
+		})
+	}
+}
+
+func TestQuoteOrderRoundingMode(t *testing.T) {
+	ctx := context.Background()
+	rate := 0.05
+	// 2.50 at 5% = 0.125 tax: exactly half a cent
+	req := domain.CreateOrderRequest{
+		UserID: "user1",
+		Items:  []domain.OrderItem{{ProductID: "101", Quantity: 1, Price: 2.50, TaxRate: &rate}},
+	}
+
+	for mode, wantTax := range map[RoundingMode]float64{RoundingHalfUp: 0.13, RoundingHalfEven: 0.12} {
+		service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{}, WithRoundingMode(mode))
+
+		quote, err := service.QuoteOrder(ctx, req)
+		if err != nil {
+			t.Fatalf("QuoteOrder(%s) error = %v", mode, err)
+		}
+		order, err := service.CreateOrder(ctx, req)
+		if err != nil {
+			t.Fatalf("CreateOrder(%s) error = %v", mode, err)
+		}
+		if quote.Tax != wantTax || order.Tax != wantTax {
+			t.Errorf("%s: quote tax = %v, order tax = %v, want %v", mode, quote.Tax, order.Tax, wantTax)
+		}
+	}
+}
+
+func TestWholeCents(t *testing.T) {
+	for amount, want := range map[float64]bool{
+		19.99: true, 0.1: true, 1.005 - 0.005: true, 10: true, 0: true,
+		0.125: false, 1.001: false, 19.999: false,
+	} {
+		if got := wholeCents(amount); got != want {
+			t.Errorf("wholeCents(%v) = %v, want %v", amount, got, want)
+		}
+	}
+}
+
+func TestCreateOrderRoundsOrderAmounts(t *testing.T) {
+	ctx := context.Background()
+	noTax := 0.0
+	// 0.10 + 0.20 is 0.30000000000000004 in float64
+	req := domain.CreateOrderRequest{
+		UserID: "user1",
+		Items: []domain.OrderItem{
+			{ProductID: "101", Quantity: 1, Price: 0.10, TaxRate: &noTax},
+			{ProductID: "102", Quantity: 1, Price: 0.20, TaxRate: &noTax},
+			{ProductID: "103", Quantity: 1, Price: 0.40, TaxRate: &noTax},
+		},
+	}
+	service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{})
+
+	quote, err := service.QuoteOrder(ctx, req)
+	if err != nil {
+		t.Fatalf("QuoteOrder() error = %v", err)
+	}
+	order, err := service.CreateOrder(ctx, req)
+	if err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+	if quote.Subtotal != 0.70 || order.Subtotal != 0.70 {
+		t.Errorf("quote subtotal = %v, order subtotal = %v, want 0.7", quote.Subtotal, order.Subtotal)
+	}
+	wantTotal := roundCents(0.70+quote.Shipping, RoundingHalfUp)
+	if quote.Total != wantTotal || order.Total != wantTotal {
+		t.Errorf("quote total = %v, order total = %v, want %v", quote.Total, order.Total, wantTotal)
+	}
+}
+
+func TestCreateOrderRejectsFractionalCentPrice(t *testing.T) {
+	service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{})
+	_, err := service.CreateOrder(context.Background(), domain.CreateOrderRequest{
+		UserID: "user1",
+		Items:  []domain.OrderItem{{ProductID: "101", Quantity: 3, Price: 0.125}},
+	})
+	if !errors.Is(err, ErrInvalidOrder) {
+		t.Errorf("CreateOrder() error = %v, want ErrInvalidOrder", err)
+	}
+}
@@ -0,0 +1,101 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+// Notification retry policy: notifyAttempts includes the first call; the wait before each retry
+// starts at notifyInitialBackoff and doubles. notifyTimeout bounds one dispatch including retries.
+const (
+	notifyAttempts       = 3
+	notifyInitialBackoff = 500 * time.Millisecond
+	notifyTimeout        = 10 * time.Second
+)
+
+// notifiableStatuses are the statuses customers are notified about when an order moves into them
+var notifiableStatuses = map[domain.OrderStatus]bool{
+	domain.OrderStatusPaid:      true,
+	domain.OrderStatusShipped:   true,
+	domain.OrderStatusCompleted: true, // delivered
+	domain.OrderStatusCancelled: true,
+}
+
+// WithNotifier sends customer notifications after committed status changes into paid, shipped,
+// completed (delivered) or cancelled. Delivery is asynchronous and never fails the status change;
+// failures are retried in the background and logged to logger. A nil notifier (the default)
+// disables notifications.
+func WithNotifier(notifier domain.Notifier, logger *zap.Logger) Option {
+	return func(s *OrderService) {
+		s.notifier = notifier
+		s.notifyLogger = logger
+		if s.notifyLogger == nil {
+			s.notifyLogger = zap.NewNop()
+		}
+	}
+}
+
+// notifyStatusChange dispatches a notification for a committed transition of order id to `to`
+// in the background. It must only be called after the transaction has committed.
+func (s *OrderService) notifyStatusChange(ctx context.Context, id string, from, to domain.OrderStatus) {
+	// A draft was never placed, so its expiry or cancellation is not news to the customer
+	if s.notifier == nil || !notifiableStatuses[to] || from == domain.OrderStatusDraft {
+		return
+	}
+	n := domain.StatusNotification{
+		OrderID:    id,
+		FromStatus: from,
+		ToStatus:   to,
+		ChangedAt:  time.Now().UTC(),
+	}
+	// Detach from the request so the response is not delayed and a finished request does not
+	// cancel delivery; trace values carry over for correlation.
+	ctx = context.WithoutCancel(ctx)
+	s.notifyWG.Go(func() {
+		ctx, cancel := context.WithTimeout(ctx, notifyTimeout)
+		defer cancel()
+		s.deliverNotification(ctx, n)
+	})
+}
+
+// deliverNotification resolves the order's owner and calls the notifier, retrying failures
+func (s *OrderService) deliverNotification(ctx context.Context, n domain.StatusNotification) {
+	logger := s.notifyLogger.With(zap.String("order_id", n.OrderID), zap.String("to_status", n.ToStatus.String()))
+
+	info, err := s.orderRepo.FindStatus(ctx, n.OrderID)
+	if err != nil {
+		logger.Error("Status notification dropped: order lookup failed", zap.Error(err))
+		return
+	}
+	n.UserID = info.UserID
+
+	backoff := notifyInitialBackoff
+	for attempt := 1; ; attempt++ {
+		err := s.notifier.Notify(ctx, n)
+		if err == nil {
+			return
+		}
+		if attempt == notifyAttempts {
+			logger.Error("Status notification failed", zap.Error(err), zap.Int("attempts", attempt))
+			return
+		}
+		logger.Warn("Status notification failed, retrying", zap.Error(err), zap.Int("attempt", attempt))
+
+		select {
+		case <-ctx.Done():
+			logger.Error("Status notification abandoned", zap.Error(err), zap.NamedError("cause", ctx.Err()))
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// WaitForNotifications blocks until in-flight notification dispatches finish.
+// Each dispatch is bounded by notifyTimeout, so this returns within that bound at shutdown.
+func (s *OrderService) WaitForNotifications() {
+	s.notifyWG.Wait()
+}
@@ -0,0 +1,94 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+)
+
+// mockNotifier records notifications; the first failures calls return an error
+type mockNotifier struct {
+	mu       sync.Mutex
+	failures int
+	calls    int
+	sent     []domain.StatusNotification
+}
+
+func (m *mockNotifier) Notify(ctx context.Context, n domain.StatusNotification) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls++
+	if m.calls <= m.failures {
+		return errors.New("notification service unavailable")
+	}
+	m.sent = append(m.sent, n)
+	return nil
+}
+
+func TestUpdateOrderStatusNotifies(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		current   domain.OrderStatus
+		to        string
+		failures  int
+		wantCalls int
+		wantSent  int
+	}{
+		{name: "shipped", current: domain.OrderStatusPaid, to: "shipped", wantCalls: 1, wantSent: 1},
+		{name: "retried after failure", current: domain.OrderStatusPending, to: "cancelled", failures: 1, wantCalls: 2, wantSent: 1},
+		{name: "gives up after all attempts", current: domain.OrderStatusPaid, to: "shipped", failures: notifyAttempts, wantCalls: notifyAttempts},
+		{name: "status not notified", current: domain.OrderStatusPaid, to: "processing"},
+		{name: "unchanged status", current: domain.OrderStatusShipped, to: "shipped"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockOrderRepository{
+				ownerID: "user1",
+				findStatusFunc: func(ctx context.Context, id string) (domain.OrderStatus, error) {
+					return tt.current, nil
+				},
+			}
+			notifier := &mockNotifier{failures: tt.failures}
+			service := NewOrderService(repo, &MockTransactionManager{}, WithNotifier(notifier, nil))
+
+			if err := service.UpdateOrderStatus(ctx, "7", tt.to, false); err != nil {
+				t.Fatalf("UpdateOrderStatus() error = %v", err)
+			}
+			service.WaitForNotifications()
+
+			if notifier.calls != tt.wantCalls {
+				t.Errorf("Notify calls = %d, want %d", notifier.calls, tt.wantCalls)
+			}
+			if len(notifier.sent) != tt.wantSent {
+				t.Fatalf("notifications delivered = %d, want %d", len(notifier.sent), tt.wantSent)
+			}
+			if tt.wantSent == 0 {
+				return
+			}
+			got := notifier.sent[0]
+			if got.OrderID != "7" || got.UserID != "user1" || got.FromStatus != tt.current || got.ToStatus.String() != tt.to {
+				t.Errorf("notification = %+v, want order 7 of user1 from %s to %s", got, tt.current, tt.to)
+			}
+		})
+	}
+}
+
+func TestUpdateOrderStatusWithoutNotifier(t *testing.T) {
+	repo := &MockOrderRepository{
+		findStatusFunc: func(ctx context.Context, id string) (domain.OrderStatus, error) {
+			return domain.OrderStatusPaid, nil
+		},
+	}
+	service := NewOrderService(repo, &MockTransactionManager{})
+
+	if err := service.UpdateOrderStatus(context.Background(), "7", "shipped", false); err != nil {
+		t.Fatalf("UpdateOrderStatus() error = %v", err)
+	}
+	service.WaitForNotifications()
+}
@@ -0,0 +1,50 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"github.com/duynhne/order-service/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// orderNumberPattern matches the numbers assigned by the database: ORD-<year>-<sequence, 6+ digits>
+var orderNumberPattern = regexp.MustCompile(`^ORD-\d{4}-\d{6,18}$`)
+
+// GetOrderByNumber retrieves userID's order by its human-friendly order number. The number is
+// matched case-insensitively ("ord-2026-000123" works). Returns ErrInvalidInput for a malformed
+// number and ErrUnauthorized for another user's order, like GetUserOrder.
+func (s *OrderService) GetOrderByNumber(ctx context.Context, number, userID string) (*domain.Order, error) {
+	ctx, span := middleware.StartSpan(ctx, "order.get_by_number", trace.WithAttributes(
+		attribute.String("layer", "logic"),
+		attribute.String("order.number", number),
+	))
+	defer span.End()
+
+	number = strings.ToUpper(strings.TrimSpace(number))
+	if !orderNumberPattern.MatchString(number) {
+		return nil, fmt.Errorf("get order: invalid order number %q: %w", number, ErrInvalidInput)
+	}
+
+	order, err := s.orderRepo.FindByOrderNumber(ctx, number)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			span.SetAttributes(attribute.Bool("order.found", false))
+			return nil, fmt.Errorf("get order by number %q: %w", number, ErrOrderNotFound)
+		}
+		span.RecordError(err)
+		return nil, err
+	}
+	if order.UserID != userID {
+		span.SetAttributes(attribute.Bool("order.owner", false))
+		return nil, fmt.Errorf("order %q requested by user %q: %w", number, userID, ErrUnauthorized)
+	}
+
+	span.SetAttributes(attribute.Bool("order.found", true), attribute.String("order.id", order.ID))
+	return order, nil
+}
@@ -0,0 +1,75 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+)
+
+// PricePolicy decides which price wins when an item carries a client price and the catalog has one too
+type PricePolicy string
+
+// Price policies selectable via ORDER_PRICE_POLICY
+const (
+	PriceTrustClient      PricePolicy = "trust_client"       // use the client price as sent (no catalog lookup)
+	PriceTrustCatalog     PricePolicy = "trust_catalog"      // replace client prices with catalog prices
+	PriceRejectOnMismatch PricePolicy = "reject_on_mismatch" // reject items whose client price differs from the catalog
+)
+
+// priceMismatchTolerance absorbs float noise when comparing client and catalog prices
+const priceMismatchTolerance = 0.005
+
+// WithPricePolicy sets how client item prices are checked against catalog (default: PriceTrustClient,
+// no catalog). Other policies need a catalog; without one they behave like PriceTrustClient.
+func WithPricePolicy(policy PricePolicy, catalog domain.PriceCatalog) Option {
+	return func(s *OrderService) {
+		s.pricePolicy = policy
+		s.catalog = catalog
+	}
+}
+
+// resolvePrices applies the price policy to items before pricing. With PriceTrustCatalog the returned
+// items carry catalog prices; with PriceRejectOnMismatch a differing price fails with ErrInvalidOrder.
+// Under both, an item missing from the catalog fails with ErrInvalidOrder. items is not modified.
+// The distinct-product limit is checked first, so an oversized order never reaches the catalog.
+func (s *OrderService) resolvePrices(ctx context.Context, items []domain.OrderItem) ([]domain.OrderItem, error) {
+	if s.catalog == nil || s.pricePolicy == PriceTrustClient || s.pricePolicy == "" {
+		return items, nil
+	}
+	if err := checkDistinctProducts(items, s.maxProducts); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		if !slices.Contains(ids, item.ProductID) {
+			ids = append(ids, item.ProductID)
+		}
+	}
+	prices, err := s.catalog.Prices(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("look up catalog prices: %w", err)
+	}
+
+	resolved := make([]domain.OrderItem, len(items))
+	for i, item := range items {
+		price, ok := prices[item.ProductID]
+		if !ok {
+			return nil, fmt.Errorf("item %d (%s): not in catalog: %w", i, item.ProductID, ErrInvalidOrder)
+		}
+		switch s.pricePolicy {
+		case PriceTrustCatalog:
+			item.Price = price
+		case PriceRejectOnMismatch:
+			if math.Abs(item.Price-price) > priceMismatchTolerance {
+				return nil, fmt.Errorf("item %d (%s): price %.2f does not match catalog price %.2f: %w",
+					i, item.ProductID, item.Price, price, ErrInvalidOrder)
+			}
+		}
+		resolved[i] = item
+	}
+	return resolved, nil
+}
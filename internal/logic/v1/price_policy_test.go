@@ -0,0 +1,128 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+)
+
+// mockPriceCatalog serves fixed catalog prices
+type mockPriceCatalog struct {
+	prices map[string]float64
+	err    error
+	calls  int
+}
+
+func (m *mockPriceCatalog) Prices(ctx context.Context, productIDs []string) (map[string]float64, error) {
+	m.calls++
+	if m.err != nil {
+		return nil, m.err
+	}
+	prices := make(map[string]float64)
+	for _, id := range productIDs {
+		if price, ok := m.prices[id]; ok {
+			prices[id] = price
+		}
+	}
+	return prices, nil
+}
+
+func TestCreateOrderPricePolicy(t *testing.T) {
+	ctx := context.Background()
+	catalogErr := errors.New("product service unavailable")
+
+	tests := []struct {
+		name         string
+		policy       PricePolicy
+		items        []domain.OrderItem
+		catalogErr   error
+		maxProducts  int
+		wantErr      error
+		wantSubtotal float64
+		wantLookups  int
+	}{
+		{
+			name:         "trust_client keeps client prices",
+			policy:       PriceTrustClient,
+			items:        []domain.OrderItem{{ProductID: "1", Quantity: 2, Price: 9.00}},
+			wantSubtotal: 18.00,
+		},
+		{
+			name:         "trust_catalog overrides client prices",
+			policy:       PriceTrustCatalog,
+			items:        []domain.OrderItem{{ProductID: "1", Quantity: 2, Price: 9.00}, {ProductID: "2", Quantity: 1, Price: 0.01}},
+			wantSubtotal: 2*10.00 + 4.50,
+			wantLookups:  1,
+		},
+		{
+			name:        "trust_catalog rejects unknown products",
+			policy:      PriceTrustCatalog,
+			items:       []domain.OrderItem{{ProductID: "3", Quantity: 1, Price: 9.00}},
+			wantErr:     ErrInvalidOrder,
+			wantLookups: 1,
+		},
+		{
+			name:         "reject_on_mismatch accepts matching prices",
+			policy:       PriceRejectOnMismatch,
+			items:        []domain.OrderItem{{ProductID: "1", Quantity: 1, Price: 10.00}},
+			wantSubtotal: 10.00,
+			wantLookups:  1,
+		},
+		{
+			name:        "reject_on_mismatch rejects differing prices",
+			policy:      PriceRejectOnMismatch,
+			items:       []domain.OrderItem{{ProductID: "1", Quantity: 1, Price: 10.00}, {ProductID: "2", Quantity: 1, Price: 4.00}},
+			wantErr:     ErrInvalidOrder,
+			wantLookups: 1,
+		},
+		{
+			name:        "catalog failure",
+			policy:      PriceTrustCatalog,
+			items:       []domain.OrderItem{{ProductID: "1", Quantity: 1, Price: 10.00}},
+			catalogErr:  catalogErr,
+			wantErr:     catalogErr,
+			wantLookups: 1,
+		},
+		{
+			name:        "too many products fail before any lookup",
+			policy:      PriceTrustCatalog,
+			items:       []domain.OrderItem{{ProductID: "1", Quantity: 1, Price: 10.00}, {ProductID: "2", Quantity: 1, Price: 4.50}},
+			maxProducts: 1,
+			wantErr:     ErrInvalidOrder,
+			wantLookups: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			catalog := &mockPriceCatalog{prices: map[string]float64{"1": 10.00, "2": 4.50}, err: tt.catalogErr}
+			opts := []Option{WithPricePolicy(tt.policy, catalog)}
+			if tt.maxProducts > 0 {
+				opts = append(opts, WithMaxDistinctProducts(tt.maxProducts))
+			}
+			service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{}, opts...)
+
+			order, err := service.CreateOrder(ctx, domain.CreateOrderRequest{UserID: "user1", Items: tt.items})
+			if catalog.calls != tt.wantLookups {
+				t.Errorf("catalog lookups = %d, want %d", catalog.calls, tt.wantLookups)
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("CreateOrder() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateOrder() error = %v", err)
+			}
+			if order.Subtotal != tt.wantSubtotal {
+				t.Errorf("Subtotal = %v, want %v", order.Subtotal, tt.wantSubtotal)
+			}
+			if tt.policy == PriceTrustCatalog && tt.items[0].Price != 9.00 {
+				t.Errorf("request item price modified to %v", tt.items[0].Price)
+			}
+		})
+	}
+}
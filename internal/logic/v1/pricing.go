@@ -0,0 +1,297 @@
+package v1
+
+import (
+	"fmt"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+// DefaultFlatShippingRate is the shipping charge applied when no calculator is configured
+const DefaultFlatShippingRate = 5.00
+
+// DefaultExpressSurcharge is added to shipping for express orders when no calculator is configured
+const DefaultExpressSurcharge = 10.00
+
+// ShippingCalculator computes the shipping charge for a priced set of order items.
+// The same calculator is used by CreateOrder, QuoteOrder and EstimateShipping so quotes match actual charges.
+type ShippingCalculator interface {
+	Calculate(subtotal float64, items []domain.OrderItem, priority domain.OrderPriority) float64
+}
+
+// ExpressShipping adds Surcharge to the Base charge for express orders.
+// The surcharge applies even when Base ships for free.
+type ExpressShipping struct {
+	Base      ShippingCalculator
+	Surcharge float64
+}
+
+// Calculate returns Base's charge, plus Surcharge for express priority
+func (e ExpressShipping) Calculate(subtotal float64, items []domain.OrderItem, priority domain.OrderPriority) float64 {
+	shipping := e.Base.Calculate(subtotal, items, priority)
+	if priority == domain.OrderPriorityExpress {
+		shipping += e.Surcharge
+	}
+	return shipping
+}
+
+// FlatRateShipping charges a fixed Rate per order.
+// When FreeShippingThreshold is positive and the subtotal exceeds it, shipping is free.
+// A threshold of 0 disables free shipping.
+type FlatRateShipping struct {
+	Rate                  float64
+	FreeShippingThreshold float64
+}
+
+// Calculate returns the flat rate, or 0 when the free-shipping threshold is exceeded
+func (f FlatRateShipping) Calculate(subtotal float64, _ []domain.OrderItem, _ domain.OrderPriority) float64 {
+	if f.FreeShippingThreshold > 0 && subtotal > f.FreeShippingThreshold {
+		return 0
+	}
+	return f.Rate
+}
+
+// PerItemShipping charges BaseFee per order plus PerUnitRate for every unit ordered
+// (quantity-weighted), e.g. base 5.00 + 0.50 x 3 units = 6.50.
+type PerItemShipping struct {
+	BaseFee     float64
+	PerUnitRate float64
+}
+
+// Calculate returns BaseFee plus PerUnitRate times the total quantity
+func (p PerItemShipping) Calculate(_ float64, items []domain.OrderItem, _ domain.OrderPriority) float64 {
+	units := 0
+	for _, item := range items {
+		units += item.Quantity
+	}
+	return p.BaseFee + p.PerUnitRate*float64(units)
+}
+
+// Shipping strategies selectable via SHIPPING_STRATEGY
+const (
+	ShippingStrategyFlat     = "flat"      // FlatRateShipping without a free-shipping threshold
+	ShippingStrategyPerItem  = "per_item"  // PerItemShipping
+	ShippingStrategyFreeOver = "free_over" // FlatRateShipping, free above a subtotal threshold
+)
+
+// ShippingRates are the configured amounts a shipping strategy draws from
+type ShippingRates struct {
+	Rate                  float64 // flat rate, or base fee for per_item
+	PerUnitRate           float64 // per_item only
+	FreeShippingThreshold float64 // free_over only
+	ExpressSurcharge      float64 // added for express orders, any strategy
+}
+
+// NewShippingCalculator builds the calculator for strategy from rates.
+// Every strategy charges rates.ExpressSurcharge on top for express orders.
+func NewShippingCalculator(strategy string, rates ShippingRates) (ShippingCalculator, error) {
+	var base ShippingCalculator
+	switch strategy {
+	case ShippingStrategyFlat:
+		base = FlatRateShipping{Rate: rates.Rate}
+	case ShippingStrategyPerItem:
+		base = PerItemShipping{BaseFee: rates.Rate, PerUnitRate: rates.PerUnitRate}
+	case ShippingStrategyFreeOver:
+		base = FlatRateShipping{Rate: rates.Rate, FreeShippingThreshold: rates.FreeShippingThreshold}
+	default:
+		return nil, fmt.Errorf("unknown shipping strategy %q", strategy)
+	}
+	return ExpressShipping{Base: base, Surcharge: rates.ExpressSurcharge}, nil
+}
+
+// BelowMinimumTotalError reports an order whose subtotal is below the configured minimum
+// order total. It wraps ErrInvalidOrder.
+type BelowMinimumTotalError struct {
+	Subtotal float64
+	Minimum  float64
+}
+
+func (e *BelowMinimumTotalError) Error() string {
+	return fmt.Sprintf("subtotal %.2f is below the minimum order total %.2f: %v", e.Subtotal, e.Minimum, ErrInvalidOrder)
+}
+
+func (e *BelowMinimumTotalError) Unwrap() error {
+	return ErrInvalidOrder
+}
+
+// DefaultMaxDistinctProducts is the maximum number of distinct products per order when not configured
+const DefaultMaxDistinctProducts = 100
+
+// TooManyProductsError reports an order with more distinct products than the configured maximum.
+// The limit counts distinct ProductIDs, not quantities.
+type TooManyProductsError struct {
+	Count   int
+	Maximum int
+}
+
+func (e *TooManyProductsError) Error() string {
+	return fmt.Sprintf("%d distinct products exceed the maximum of %d: %v", e.Count, e.Maximum, ErrInvalidOrder)
+}
+
+func (e *TooManyProductsError) Unwrap() error {
+	return ErrInvalidOrder
+}
+
+// checkDistinctProducts returns a *TooManyProductsError when items name more than maxProducts
+// distinct products; maxProducts 0 disables the check
+func checkDistinctProducts(items []domain.OrderItem, maxProducts int) error {
+	if maxProducts <= 0 || len(items) <= maxProducts {
+		return nil
+	}
+	distinct := make(map[string]struct{}, len(items))
+	for _, item := range items {
+		distinct[item.ProductID] = struct{}{}
+	}
+	if len(distinct) > maxProducts {
+		return &TooManyProductsError{Count: len(distinct), Maximum: maxProducts}
+	}
+	return nil
+}
+
+// itemKey identifies an order line for merging: lines of one product with different SKUs
+// are different stock and stay separate
+type itemKey struct {
+	productID string
+	sku       string
+}
+
+// mergeDuplicateItems collapses items sharing a ProductID and SKU into the first occurrence, summing
+// quantities; order of first appearance is kept. Duplicates must agree on price, tax rate and
+// weight, otherwise there is no single correct value for the merged line and ErrInvalidOrder is
+// returned.
+func mergeDuplicateItems(items []domain.OrderItem) ([]domain.OrderItem, error) {
+	merged := make([]domain.OrderItem, 0, len(items))
+	index := make(map[itemKey]int, len(items))
+	for _, item := range items {
+		key := itemKey{productID: item.ProductID, sku: item.SKU}
+		i, seen := index[key]
+		if !seen {
+			index[key] = len(merged)
+			merged = append(merged, item)
+			continue
+		}
+		if merged[i].Price != item.Price {
+			return nil, fmt.Errorf("product %s listed with different prices: %w", item.ProductID, ErrInvalidOrder)
+		}
+		if !sameTaxRate(merged[i].TaxRate, item.TaxRate) {
+			return nil, fmt.Errorf("product %s listed with different tax rates: %w", item.ProductID, ErrInvalidOrder)
+		}
+		if merged[i].Weight != item.Weight {
+			return nil, fmt.Errorf("product %s listed with different weights: %w", item.ProductID, ErrInvalidOrder)
+		}
+		merged[i].Quantity += item.Quantity
+	}
+	return merged, nil
+}
+
+// sameTaxRate reports whether two optional tax rates are both unset or set to the same value
+func sameTaxRate(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// priceOrder validates and enriches items (subtotal, tax, sanitized or fallback product name)
+// and computes order totals and weight. Items without a TaxRate are taxed at the service's default
+// rate. Product names over the configured length are truncated or, without truncation, rejected.
+// Returns ErrInvalidOrder for an invalid product ID or SKU, a price with fractional cents, a negative weight, a tax rate outside
+// [0, 1], or for a zero price when zero-priced items are not allowed or an unknown priority, and
+// *BelowMinimumTotalError when the subtotal is below the minimum order total.
+func (s *OrderService) priceOrder(items []domain.OrderItem, rawPriority string) (*domain.OrderQuote, error) {
+	priority, err := domain.ParseOrderPriority(rawPriority)
+	if err != nil {
+		return nil, fmt.Errorf("price order: %v: %w", err, ErrInvalidOrder)
+	}
+	if err := checkDistinctProducts(items, s.maxProducts); err != nil {
+		return nil, err
+	}
+	if s.mergeItems {
+		if items, err = mergeDuplicateItems(items); err != nil {
+			return nil, err
+		}
+	}
+
+	enrichedItems := make([]domain.OrderItem, len(items))
+	var subtotal, tax, totalWeight float64
+	for i, item := range items {
+		if !validProductID(item.ProductID) {
+			return nil, fmt.Errorf("item %d: invalid product id: %w", i, ErrInvalidOrder)
+		}
+		if !validSKU(item.SKU) {
+			return nil, fmt.Errorf("item %d (%s): invalid sku: %w", i, item.ProductID, ErrInvalidOrder)
+		}
+		if item.Price == 0 && !s.allowZeroPrice {
+			return nil, fmt.Errorf("item %d (%s): zero price not allowed: %w", i, item.ProductID, ErrInvalidOrder)
+		}
+		if !wholeCents(item.Price) {
+			return nil, fmt.Errorf("item %d (%s): price %v has fractional cents: %w", i, item.ProductID, item.Price, ErrInvalidOrder)
+		}
+		if item.Weight < 0 {
+			return nil, fmt.Errorf("item %d (%s): negative weight: %w", i, item.ProductID, ErrInvalidOrder)
+		}
+
+		taxRate := s.taxRate
+		if item.TaxRate != nil {
+			taxRate = *item.TaxRate
+		}
+		if taxRate < 0 || taxRate > 1 {
+			return nil, fmt.Errorf("item %d (%s): tax rate %v outside [0, 1]: %w", i, item.ProductID, taxRate, ErrInvalidOrder)
+		}
+
+		// Rounded per item so the item amounts add up exactly to the order amounts; the sums are
+		// rounded again to drop the float noise of adding cents
+		itemSubtotal := s.roundMoney(item.Price * float64(item.Quantity))
+		lineTax := s.roundMoney(itemSubtotal * taxRate)
+		subtotal = s.roundMoney(subtotal + itemSubtotal)
+		tax = s.roundMoney(tax + lineTax)
+		totalWeight += item.Weight * float64(item.Quantity)
+
+		productName, truncated := sanitizeProductName(item.ProductName, s.maxNameLength)
+		if truncated {
+			if !s.truncateNames {
+				return nil, fmt.Errorf("item %d (%s): product name longer than %d bytes: %w", i, item.ProductID, s.maxNameLength, ErrInvalidOrder)
+			}
+			// The name is customer-entered text: log its size, not its content
+			s.nameLogger.Warn("Product name truncated",
+				zap.String("product_id", item.ProductID),
+				zap.Int("length", len(item.ProductName)),
+				zap.Int("max_length", s.maxNameLength),
+			)
+		}
+		if productName == "" {
+			productName = "Product " + item.ProductID
+		}
+
+		enrichedItems[i] = domain.OrderItem{
+			ProductID:   item.ProductID,
+			ProductName: productName,
+			SKU:         item.SKU,
+			Quantity:    item.Quantity,
+			Price:       item.Price,
+			Subtotal:    itemSubtotal,
+			Weight:      item.Weight,
+			TaxRate:     &taxRate,
+			Tax:         lineTax,
+		}
+	}
+
+	if subtotal < s.minTotal {
+		return nil, &BelowMinimumTotalError{Subtotal: subtotal, Minimum: s.minTotal}
+	}
+
+	shipping := s.roundMoney(s.shipping.Calculate(subtotal, enrichedItems, priority))
+	// Promotions discount the order, not the items: tax and shipping use the undiscounted subtotal
+	promotions, discount := s.applyPromotions(enrichedItems, subtotal)
+	return &domain.OrderQuote{
+		Priority:    priority,
+		Items:       enrichedItems,
+		Subtotal:    subtotal,
+		Shipping:    shipping,
+		Tax:         tax,
+		Discount:    discount,
+		Total:       s.roundMoney(subtotal + shipping + tax - discount),
+		TotalWeight: totalWeight,
+		Promotions:  promotions,
+	}, nil
+}
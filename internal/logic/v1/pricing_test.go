@@ -0,0 +1,524 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+)
+
+func TestFlatRateShippingFreeThreshold(t *testing.T) {
+	tests := []struct {
+		name     string
+		calc     FlatRateShipping
+		subtotal float64
+		want     float64
+	}{
+		{name: "Just below threshold", calc: FlatRateShipping{Rate: 5, FreeShippingThreshold: 50}, subtotal: 49.99, want: 5},
+		{name: "At threshold", calc: FlatRateShipping{Rate: 5, FreeShippingThreshold: 50}, subtotal: 50, want: 5},
+		{name: "Just above threshold", calc: FlatRateShipping{Rate: 5, FreeShippingThreshold: 50}, subtotal: 50.01, want: 0},
+		{name: "Threshold disabled", calc: FlatRateShipping{Rate: 5}, subtotal: 10000, want: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.calc.Calculate(tt.subtotal, nil, domain.OrderPriorityStandard); got != tt.want {
+				t.Errorf("Calculate(%v) = %v, want %v", tt.subtotal, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuoteOrderMatchesCreateOrder(t *testing.T) {
+	ctx := context.Background()
+	service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{},
+		WithShippingCalculator(FlatRateShipping{Rate: 5, FreeShippingThreshold: 50}),
+	)
+	req := domain.CreateOrderRequest{
+		UserID: "user1",
+		Items:  []domain.OrderItem{{ProductID: "101", Quantity: 3, Price: 20.0}},
+	}
+
+	quote, err := service.QuoteOrder(ctx, req)
+	if err != nil {
+		t.Fatalf("QuoteOrder() error = %v", err)
+	}
+	order, err := service.CreateOrder(ctx, req)
+	if err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+
+	if quote.Shipping != 0 || order.Shipping != 0 {
+		t.Errorf("shipping quote = %v, order = %v, want 0 above threshold", quote.Shipping, order.Shipping)
+	}
+	if quote.Total != order.Total {
+		t.Errorf("quote total = %v, order total = %v, want equal", quote.Total, order.Total)
+	}
+}
+
+func TestCreateOrderZeroPrice(t *testing.T) {
+	ctx := context.Background()
+	req := domain.CreateOrderRequest{
+		UserID: "user1",
+		Items: []domain.OrderItem{
+			{ProductID: "101", Quantity: 1, Price: 10.0},
+			{ProductID: "900", Quantity: 1, Price: 0},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr error
+	}{
+		{name: "Allowed by default", opts: nil},
+		{name: "Explicitly allowed", opts: []Option{WithAllowZeroPrice(true)}},
+		{name: "Disallowed", opts: []Option{WithAllowZeroPrice(false)}, wantErr: ErrInvalidOrder},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{}, tt.opts...)
+
+			_, err := service.CreateOrder(ctx, req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CreateOrder() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMinOrderTotal(t *testing.T) {
+	ctx := context.Background()
+	newReq := func(price float64) domain.CreateOrderRequest {
+		return domain.CreateOrderRequest{
+			UserID: "user1",
+			Items:  []domain.OrderItem{{ProductID: "101", Quantity: 2, Price: price}},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		price   float64
+		wantErr bool
+	}{
+		{name: "Disabled by default", price: 0.01},
+		{name: "At threshold", opts: []Option{WithMinOrderTotal(25)}, price: 12.50},
+		{name: "Just below threshold", opts: []Option{WithMinOrderTotal(25)}, price: 12.49, wantErr: true},
+		{name: "Shipping does not count", opts: []Option{WithMinOrderTotal(25)}, price: 10, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{}, tt.opts...)
+
+			for name, call := range map[string]func() error{
+				"CreateOrder": func() error { _, err := service.CreateOrder(ctx, newReq(tt.price)); return err },
+				"QuoteOrder":  func() error { _, err := service.QuoteOrder(ctx, newReq(tt.price)); return err },
+			} {
+				err := call()
+				if !tt.wantErr {
+					if err != nil {
+						t.Errorf("%s() error = %v", name, err)
+					}
+					continue
+				}
+
+				var minErr *BelowMinimumTotalError
+				if !errors.As(err, &minErr) || !errors.Is(err, ErrInvalidOrder) {
+					t.Fatalf("%s() error = %v, want *BelowMinimumTotalError wrapping ErrInvalidOrder", name, err)
+				}
+				if minErr.Minimum != 25 {
+					t.Errorf("%s() Minimum = %v, want 25", name, minErr.Minimum)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateOrderMaxDistinctProducts(t *testing.T) {
+	ctx := context.Background()
+	newReq := func(products, quantity int) domain.CreateOrderRequest {
+		items := make([]domain.OrderItem, products)
+		for i := range items {
+			items[i] = domain.OrderItem{ProductID: strconv.Itoa(i + 1), Quantity: quantity, Price: 1}
+		}
+		return domain.CreateOrderRequest{UserID: "user1", Items: items}
+	}
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		req     domain.CreateOrderRequest
+		wantMax int // 0: accepted
+	}{
+		{name: "At default limit", req: newReq(DefaultMaxDistinctProducts, 1)},
+		{name: "Above default limit", req: newReq(DefaultMaxDistinctProducts+1, 1), wantMax: DefaultMaxDistinctProducts},
+		{name: "Quantity does not count", opts: []Option{WithMaxDistinctProducts(2)}, req: newReq(2, 50)},
+		{name: "Above configured limit", opts: []Option{WithMaxDistinctProducts(2)}, req: newReq(3, 1), wantMax: 2},
+		{name: "Disabled", opts: []Option{WithMaxDistinctProducts(0)}, req: newReq(DefaultMaxDistinctProducts+1, 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{}, tt.opts...)
+
+			_, err := service.CreateOrder(ctx, tt.req)
+			if tt.wantMax == 0 {
+				if err != nil {
+					t.Errorf("CreateOrder() error = %v", err)
+				}
+				return
+			}
+
+			var productsErr *TooManyProductsError
+			if !errors.As(err, &productsErr) || !errors.Is(err, ErrInvalidOrder) {
+				t.Fatalf("CreateOrder() error = %v, want *TooManyProductsError wrapping ErrInvalidOrder", err)
+			}
+			if productsErr.Maximum != tt.wantMax || productsErr.Count != len(tt.req.Items) {
+				t.Errorf("CreateOrder() error = %+v, want Maximum %d, Count %d", productsErr, tt.wantMax, len(tt.req.Items))
+			}
+		})
+	}
+}
+
+func TestShippingStrategies(t *testing.T) {
+	ctx := context.Background()
+	// Sample cart: 3 units, subtotal 60.00
+	req := domain.CreateOrderRequest{
+		UserID: "user1",
+		Items: []domain.OrderItem{
+			{ProductID: "101", Quantity: 2, Price: 20.0},
+			{ProductID: "102", Quantity: 1, Price: 20.0},
+		},
+	}
+	rates := ShippingRates{Rate: 5, PerUnitRate: 0.5, FreeShippingThreshold: 50}
+
+	tests := []struct {
+		strategy string
+		want     float64
+	}{
+		{strategy: ShippingStrategyFlat, want: 5},
+		{strategy: ShippingStrategyPerItem, want: 6.5},
+		{strategy: ShippingStrategyFreeOver, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			calc, err := NewShippingCalculator(tt.strategy, rates)
+			if err != nil {
+				t.Fatalf("NewShippingCalculator() error = %v", err)
+			}
+			service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{}, WithShippingCalculator(calc))
+
+			quote, err := service.QuoteOrder(ctx, req)
+			if err != nil {
+				t.Fatalf("QuoteOrder() error = %v", err)
+			}
+			order, err := service.CreateOrder(ctx, req)
+			if err != nil {
+				t.Fatalf("CreateOrder() error = %v", err)
+			}
+			if quote.Shipping != tt.want || order.Shipping != tt.want {
+				t.Errorf("shipping quote = %v, order = %v, want %v", quote.Shipping, order.Shipping, tt.want)
+			}
+		})
+	}
+
+	if _, err := NewShippingCalculator("by_weight", rates); err == nil {
+		t.Error("NewShippingCalculator(unknown) error = nil, want error")
+	}
+}
+
+func TestExpressPriorityShipping(t *testing.T) {
+	ctx := context.Background()
+	newReq := func(priority string) domain.CreateOrderRequest {
+		return domain.CreateOrderRequest{
+			UserID:   "user1",
+			Items:    []domain.OrderItem{{ProductID: "101", Quantity: 3, Price: 20.0}},
+			Priority: priority,
+		}
+	}
+
+	for _, strategy := range []string{ShippingStrategyFlat, ShippingStrategyPerItem, ShippingStrategyFreeOver} {
+		t.Run(strategy, func(t *testing.T) {
+			calc, err := NewShippingCalculator(strategy, ShippingRates{
+				Rate: 5, PerUnitRate: 0.5, FreeShippingThreshold: 50, ExpressSurcharge: 10,
+			})
+			if err != nil {
+				t.Fatalf("NewShippingCalculator() error = %v", err)
+			}
+			service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{}, WithShippingCalculator(calc))
+
+			standard, err := service.CreateOrder(ctx, newReq(""))
+			if err != nil {
+				t.Fatalf("CreateOrder(standard) error = %v", err)
+			}
+			express, err := service.CreateOrder(ctx, newReq("Express"))
+			if err != nil {
+				t.Fatalf("CreateOrder(express) error = %v", err)
+			}
+
+			if standard.Priority != domain.OrderPriorityStandard || express.Priority != domain.OrderPriorityExpress {
+				t.Errorf("priorities = %q, %q", standard.Priority, express.Priority)
+			}
+			if express.Shipping != standard.Shipping+10 {
+				t.Errorf("express shipping = %v, want standard %v + 10", express.Shipping, standard.Shipping)
+			}
+		})
+	}
+
+	service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{})
+	if _, err := service.QuoteOrder(ctx, newReq("overnight")); !errors.Is(err, ErrInvalidOrder) {
+		t.Errorf("QuoteOrder(unknown priority) error = %v, want ErrInvalidOrder", err)
+	}
+}
+
+func TestCreateOrderMergeDuplicateItems(t *testing.T) {
+	ctx := context.Background()
+	req := domain.CreateOrderRequest{
+		UserID: "user1",
+		Items: []domain.OrderItem{
+			{ProductID: "101", Quantity: 1, Price: 10.0},
+			{ProductID: "102", Quantity: 1, Price: 5.0},
+			{ProductID: "101", Quantity: 2, Price: 10.0},
+		},
+	}
+
+	t.Run("Disabled by default", func(t *testing.T) {
+		service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{})
+		order, err := service.CreateOrder(ctx, req)
+		if err != nil {
+			t.Fatalf("CreateOrder() error = %v", err)
+		}
+		if len(order.Items) != 3 {
+			t.Errorf("items = %d, want 3 (unmerged)", len(order.Items))
+		}
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{}, WithMergeDuplicateItems(true))
+		order, err := service.CreateOrder(ctx, req)
+		if err != nil {
+			t.Fatalf("CreateOrder() error = %v", err)
+		}
+		if len(order.Items) != 2 {
+			t.Fatalf("items = %+v, want 2 merged items", order.Items)
+		}
+		if got := order.Items[0]; got.ProductID != "101" || got.Quantity != 3 || got.Subtotal != 30 {
+			t.Errorf("merged p1 = %+v, want quantity 3, subtotal 30", got)
+		}
+		if order.Subtotal != 35 {
+			t.Errorf("order subtotal = %v, want 35", order.Subtotal)
+		}
+	})
+
+	t.Run("Conflicting prices", func(t *testing.T) {
+		service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{}, WithMergeDuplicateItems(true))
+		conflicting := domain.CreateOrderRequest{
+			UserID: "user1",
+			Items: []domain.OrderItem{
+				{ProductID: "101", Quantity: 1, Price: 10.0},
+				{ProductID: "101", Quantity: 1, Price: 12.0},
+			},
+		}
+		if _, err := service.CreateOrder(ctx, conflicting); !errors.Is(err, ErrInvalidOrder) {
+			t.Errorf("CreateOrder() error = %v, want ErrInvalidOrder", err)
+		}
+	})
+
+	t.Run("Conflicting tax rates", func(t *testing.T) {
+		service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{}, WithMergeDuplicateItems(true))
+		rate := 0.08
+		conflicting := domain.CreateOrderRequest{
+			UserID: "user1",
+			Items: []domain.OrderItem{
+				{ProductID: "101", Quantity: 1, Price: 10.0, TaxRate: &rate},
+				{ProductID: "101", Quantity: 1, Price: 10.0},
+			},
+		}
+		if _, err := service.CreateOrder(ctx, conflicting); !errors.Is(err, ErrInvalidOrder) {
+			t.Errorf("CreateOrder() error = %v, want ErrInvalidOrder", err)
+		}
+	})
+
+	t.Run("Equal tax rates merge", func(t *testing.T) {
+		service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{}, WithMergeDuplicateItems(true))
+		rate, sameRate := 0.08, 0.08
+		order, err := service.CreateOrder(ctx, domain.CreateOrderRequest{
+			UserID: "user1",
+			Items: []domain.OrderItem{
+				{ProductID: "101", Quantity: 1, Price: 10.0, TaxRate: &rate},
+				{ProductID: "101", Quantity: 1, Price: 10.0, TaxRate: &sameRate},
+			},
+		})
+		if err != nil {
+			t.Fatalf("CreateOrder() error = %v", err)
+		}
+		if len(order.Items) != 1 || order.Items[0].Quantity != 2 {
+			t.Errorf("items = %+v, want one merged item with quantity 2", order.Items)
+		}
+	})
+
+	t.Run("Conflicting weights", func(t *testing.T) {
+		service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{}, WithMergeDuplicateItems(true))
+		conflicting := domain.CreateOrderRequest{
+			UserID: "user1",
+			Items: []domain.OrderItem{
+				{ProductID: "101", Quantity: 1, Price: 10.0, Weight: 1.5},
+				{ProductID: "101", Quantity: 1, Price: 10.0, Weight: 2},
+			},
+		}
+		if _, err := service.CreateOrder(ctx, conflicting); !errors.Is(err, ErrInvalidOrder) {
+			t.Errorf("CreateOrder() error = %v, want ErrInvalidOrder", err)
+		}
+	})
+
+	t.Run("Different SKUs stay separate", func(t *testing.T) {
+		service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{}, WithMergeDuplicateItems(true))
+		order, err := service.CreateOrder(ctx, domain.CreateOrderRequest{
+			UserID: "user1",
+			Items: []domain.OrderItem{
+				{ProductID: "101", SKU: "TSHIRT-S", Quantity: 1, Price: 10.0},
+				{ProductID: "101", SKU: "TSHIRT-M", Quantity: 1, Price: 10.0},
+				{ProductID: "101", SKU: "TSHIRT-S", Quantity: 2, Price: 10.0},
+			},
+		})
+		if err != nil {
+			t.Fatalf("CreateOrder() error = %v", err)
+		}
+		if len(order.Items) != 2 || order.Items[0].SKU != "TSHIRT-S" || order.Items[0].Quantity != 3 || order.Items[1].SKU != "TSHIRT-M" {
+			t.Errorf("items = %+v, want TSHIRT-S x3 and TSHIRT-M x1", order.Items)
+		}
+	})
+}
+
+func TestCreateOrderItemSKU(t *testing.T) {
+	ctx := context.Background()
+	service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{})
+
+	order, err := service.CreateOrder(ctx, domain.CreateOrderRequest{
+		UserID: "user1",
+		Items: []domain.OrderItem{
+			{ProductID: "101", SKU: "WID-001.blue_L", Quantity: 1, Price: 10.0},
+			{ProductID: "102", Quantity: 1, Price: 5.0},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+	if order.Items[0].SKU != "WID-001.blue_L" || order.Items[1].SKU != "" {
+		t.Errorf("item SKUs = %q, %q, want the SKUs as sent", order.Items[0].SKU, order.Items[1].SKU)
+	}
+
+	for _, sku := range []string{"-WID", "WID 001", "WID/001", "<b>", strings.Repeat("A", 65)} {
+		_, err := service.CreateOrder(ctx, domain.CreateOrderRequest{
+			UserID: "user1",
+			Items:  []domain.OrderItem{{ProductID: "101", SKU: sku, Quantity: 1, Price: 10}},
+		})
+		if !errors.Is(err, ErrInvalidOrder) {
+			t.Errorf("CreateOrder(sku=%q) error = %v, want ErrInvalidOrder", sku, err)
+		}
+	}
+}
+
+func TestCreateOrderTotalWeight(t *testing.T) {
+	ctx := context.Background()
+	service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{})
+
+	order, err := service.CreateOrder(ctx, domain.CreateOrderRequest{
+		UserID: "user1",
+		Items: []domain.OrderItem{
+			{ProductID: "101", Quantity: 2, Price: 10.0, Weight: 1.25},
+			{ProductID: "102", Quantity: 1, Price: 5.0, Weight: 0.5},
+			{ProductID: "103", Quantity: 3, Price: 1.0},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+	if order.TotalWeight != 3 {
+		t.Errorf("TotalWeight = %v, want 3", order.TotalWeight)
+	}
+	if order.Items[0].Weight != 1.25 {
+		t.Errorf("item weight = %v, want 1.25", order.Items[0].Weight)
+	}
+
+	_, err = service.CreateOrder(ctx, domain.CreateOrderRequest{
+		UserID: "user1",
+		Items:  []domain.OrderItem{{ProductID: "101", Quantity: 1, Price: 10.0, Weight: -1}},
+	})
+	if !errors.Is(err, ErrInvalidOrder) {
+		t.Errorf("CreateOrder() with negative weight error = %v, want ErrInvalidOrder", err)
+	}
+}
+
+func TestCreateOrderItemTax(t *testing.T) {
+	ctx := context.Background()
+	rate := func(r float64) *float64 { return &r }
+
+	tests := []struct {
+		name     string
+		items    []domain.OrderItem
+		wantTax  []float64 // per item
+		wantErr  error
+		orderTax float64
+	}{
+		{
+			name: "Mixed taxed, exempt and default-rate items",
+			items: []domain.OrderItem{
+				{ProductID: "101", Quantity: 2, Price: 10.00, TaxRate: rate(0.2)}, // 20.00 at 20%
+				{ProductID: "102", Quantity: 1, Price: 15.00, TaxRate: rate(0)},   // exempt
+				{ProductID: "103", Quantity: 3, Price: 3.33},                      // 9.99 at default 8%
+			},
+			wantTax:  []float64{4.00, 0, 0.80},
+			orderTax: 4.80,
+		},
+		{
+			name:    "Rate above 100%",
+			items:   []domain.OrderItem{{ProductID: "101", Quantity: 1, Price: 10, TaxRate: rate(1.5)}},
+			wantErr: ErrInvalidOrder,
+		},
+		{
+			name:    "Negative rate",
+			items:   []domain.OrderItem{{ProductID: "101", Quantity: 1, Price: 10, TaxRate: rate(-0.1)}},
+			wantErr: ErrInvalidOrder,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{},
+				WithShippingCalculator(FlatRateShipping{Rate: 5}),
+				WithTaxRate(0.08),
+			)
+
+			order, err := service.CreateOrder(ctx, domain.CreateOrderRequest{UserID: "user1", Items: tt.items})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("CreateOrder() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateOrder() error = %v", err)
+			}
+			for i, item := range order.Items {
+				if item.Tax != tt.wantTax[i] || item.TaxRate == nil {
+					t.Errorf("item %d tax = %v (rate %v), want %v", i, item.Tax, item.TaxRate, tt.wantTax[i])
+				}
+			}
+			if math.Abs(order.Tax-tt.orderTax) > 1e-9 {
+				t.Errorf("order tax = %v, want %v", order.Tax, tt.orderTax)
+			}
+			if want := order.Subtotal + order.Shipping + order.Tax; order.Total != want {
+				t.Errorf("order total = %v, want subtotal + shipping + tax = %v", order.Total, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package v1
+
+import (
+	"github.com/duynhne/order-service/internal/core/domain"
+)
+
+// PromotionEngine finds the automatic promotions a priced cart qualifies for.
+// Amounts are taken off the order total; the same engine is used by CreateOrder and QuoteOrder
+// so quotes show the discount the order will get.
+type PromotionEngine interface {
+	Apply(items []domain.OrderItem, subtotal float64) []domain.AppliedPromotion
+}
+
+// PromotionRule is one automatic promotion; ok is false when the cart does not qualify
+type PromotionRule interface {
+	Evaluate(items []domain.OrderItem, subtotal float64) (promo domain.AppliedPromotion, ok bool)
+}
+
+// RulePromotionEngine applies every rule the cart qualifies for, in order (promotions stack)
+type RulePromotionEngine struct {
+	Rules []PromotionRule
+}
+
+// Apply returns the promotions of the qualifying rules
+func (e RulePromotionEngine) Apply(items []domain.OrderItem, subtotal float64) []domain.AppliedPromotion {
+	var applied []domain.AppliedPromotion
+	for _, rule := range e.Rules {
+		if promo, ok := rule.Evaluate(items, subtotal); ok && promo.Amount > 0 {
+			applied = append(applied, promo)
+		}
+	}
+	return applied
+}
+
+// QuantityPercentOff takes PercentOff (a fraction, 0.10 = 10%) off the subtotal when the cart holds
+// at least MinUnits units in total, e.g. "buy 3 get 10% off".
+type QuantityPercentOff struct {
+	ID          string
+	Description string
+	MinUnits    int
+	PercentOff  float64
+}
+
+// Evaluate applies the rule to a cart
+func (q QuantityPercentOff) Evaluate(items []domain.OrderItem, subtotal float64) (domain.AppliedPromotion, bool) {
+	units := 0
+	for _, item := range items {
+		units += item.Quantity
+	}
+	if units < q.MinUnits {
+		return domain.AppliedPromotion{}, false
+	}
+	return domain.AppliedPromotion{
+		ID:          q.ID,
+		Description: q.Description,
+		Amount:      subtotal * q.PercentOff,
+	}, true
+}
+
+// WithPromotionEngine applies automatic promotions when pricing orders (default: none)
+func WithPromotionEngine(engine PromotionEngine) Option {
+	return func(s *OrderService) {
+		s.promotions = engine
+	}
+}
+
+// applyPromotions runs the promotion engine on a priced cart and returns the rounded promotions and
+// their summed discount. The discount never exceeds the subtotal: the last promotions are trimmed
+// so the items are never priced below zero.
+func (s *OrderService) applyPromotions(items []domain.OrderItem, subtotal float64) ([]domain.AppliedPromotion, float64) {
+	if s.promotions == nil {
+		return nil, 0
+	}
+	var (
+		applied  []domain.AppliedPromotion
+		discount float64
+	)
+	for _, promo := range s.promotions.Apply(items, subtotal) {
+		promo.Amount = min(s.roundMoney(promo.Amount), s.roundMoney(subtotal-discount))
+		if promo.Amount <= 0 {
+			continue
+		}
+		discount += promo.Amount
+		applied = append(applied, promo)
+	}
+	return applied, discount
+}
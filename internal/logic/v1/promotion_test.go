@@ -0,0 +1,82 @@
+package v1
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+)
+
+func TestCreateOrderPromotions(t *testing.T) {
+	ctx := context.Background()
+	bulk := QuantityPercentOff{ID: "bulk3", Description: "Buy 3, get 10% off", MinUnits: 3, PercentOff: 0.10}
+
+	tests := []struct {
+		name         string
+		rules        []PromotionRule
+		items        []domain.OrderItem
+		wantDiscount float64
+		wantPromos   []string
+	}{
+		{
+			name:         "Qualifying cart",
+			rules:        []PromotionRule{bulk},
+			items:        []domain.OrderItem{{ProductID: "1", Quantity: 2, Price: 10}, {ProductID: "2", Quantity: 1, Price: 5.55}},
+			wantDiscount: 2.56, // 10% of 25.55, rounded half up
+			wantPromos:   []string{"bulk3"},
+		},
+		{
+			name:  "Non-qualifying cart",
+			rules: []PromotionRule{bulk},
+			items: []domain.OrderItem{{ProductID: "1", Quantity: 2, Price: 10}},
+		},
+		{
+			name:         "Stacked promotions capped at subtotal",
+			rules:        []PromotionRule{bulk, QuantityPercentOff{ID: "all", MinUnits: 1, PercentOff: 1}},
+			items:        []domain.OrderItem{{ProductID: "1", Quantity: 3, Price: 10}},
+			wantDiscount: 30,
+			wantPromos:   []string{"bulk3", "all"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created *domain.Order
+			repo := &MockOrderRepository{
+				createWithTxFunc: func(ctx context.Context, tx domain.Transaction, order *domain.Order) error {
+					created = order
+					return nil
+				},
+			}
+			service := NewOrderService(repo, &MockTransactionManager{},
+				WithShippingCalculator(FlatRateShipping{Rate: 5}),
+				WithPromotionEngine(RulePromotionEngine{Rules: tt.rules}),
+			)
+
+			order, err := service.CreateOrder(ctx, domain.CreateOrderRequest{UserID: "user1", Items: tt.items})
+			if err != nil {
+				t.Fatalf("CreateOrder() error = %v", err)
+			}
+
+			if order.Discount != tt.wantDiscount {
+				t.Errorf("Discount = %v, want %v", order.Discount, tt.wantDiscount)
+			}
+			if want := roundCents(order.Subtotal+order.Shipping+order.Tax-tt.wantDiscount, RoundingHalfUp); order.Total != want {
+				t.Errorf("Total = %v, want %v", order.Total, want)
+			}
+			var ids []string
+			var sum float64
+			for _, promo := range created.Promotions {
+				ids = append(ids, promo.ID)
+				sum += promo.Amount
+			}
+			if !slices.Equal(ids, tt.wantPromos) {
+				t.Errorf("persisted promotions = %v, want %v", ids, tt.wantPromos)
+			}
+			if sum != order.Discount {
+				t.Errorf("promotion amounts sum to %v, want Discount %v", sum, order.Discount)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"github.com/duynhne/order-service/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// MinPurgeAge is the shortest retention PurgeOrders accepts, so a typo cannot wipe recent orders
+const MinPurgeAge = 30 * 24 * time.Hour
+
+// MaxPurgeBatch caps the orders deleted by one PurgeOrders call to keep the transaction short;
+// callers repeat the purge while it returns a full batch
+const MaxPurgeBatch = 10000
+
+// purgeableStatuses are the statuses whose orders may be hard-deleted for data retention
+var purgeableStatuses = map[domain.OrderStatus]bool{
+	domain.OrderStatusCancelled: true,
+}
+
+// PurgeOrders hard-deletes (with their items and status history) up to MaxPurgeBatch orders in
+// status whose last update is older than olderThan, in one transaction (admin only; ErrUnauthorized
+// for a non-admin AuthContext). Returns ErrInvalidInput if status is not purgeable or olderThan is
+// below MinPurgeAge.
+func (s *OrderService) PurgeOrders(ctx context.Context, status domain.OrderStatus, olderThan time.Duration) (*domain.PurgeResult, error) {
+	ctx, span := middleware.StartSpan(ctx, "order.purge", trace.WithAttributes(
+		attribute.String("layer", "logic"),
+		attribute.String("order.status", string(status)),
+		attribute.String("purge.older_than", olderThan.String()),
+	))
+	defer span.End()
+
+	if err := requireAdmin(ctx, "purge orders"); err != nil {
+		return nil, err
+	}
+	if !purgeableStatuses[status] {
+		return nil, fmt.Errorf("purge %q orders: status is not purgeable: %w", status, ErrInvalidInput)
+	}
+	if olderThan < MinPurgeAge {
+		return nil, fmt.Errorf("purge orders older than %s (min %s): %w", olderThan, MinPurgeAge, ErrInvalidInput)
+	}
+	before := time.Now().Add(-olderThan)
+
+	var ids []string
+	err := s.inTx(ctx, func(tx domain.Transaction) error {
+		var err error
+		ids, err = s.orderRepo.PurgeWithTx(ctx, tx, status, before, MaxPurgeBatch)
+		return err
+	})
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	for _, id := range ids {
+		s.invalidateOrder(ctx, id)
+	}
+
+	span.SetAttributes(attribute.Int("purge.count", len(ids)))
+	return &domain.PurgeResult{Status: status, Before: before, Purged: len(ids)}, nil
+}
@@ -0,0 +1,59 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+)
+
+func TestPurgeOrders(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		status    domain.OrderStatus
+		olderThan time.Duration
+		wantErr   error
+	}{
+		{name: "Cancelled older than 90 days", status: domain.OrderStatusCancelled, olderThan: 90 * 24 * time.Hour},
+		{name: "Minimum age", status: domain.OrderStatusCancelled, olderThan: MinPurgeAge},
+		{name: "Too recent", status: domain.OrderStatusCancelled, olderThan: 7 * 24 * time.Hour, wantErr: ErrInvalidInput},
+		{name: "Status not purgeable", status: domain.OrderStatusCompleted, olderThan: 90 * 24 * time.Hour, wantErr: ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockOrderRepository{purgedIDs: []string{"3", "9"}}
+			cache := &mockOrderCache{orders: map[string]*domain.Order{}}
+			service := NewOrderService(repo, &MockTransactionManager{}, WithOrderCache(cache))
+
+			start := time.Now()
+			result, err := service.PurgeOrders(ctx, tt.status, tt.olderThan)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("PurgeOrders() error = %v, want %v", err, tt.wantErr)
+				}
+				if len(repo.purgeBefore) != 0 {
+					t.Errorf("PurgeOrders() deleted orders despite invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("PurgeOrders() error = %v", err)
+			}
+			if result.Purged != 2 || result.Status != tt.status {
+				t.Errorf("PurgeOrders() = %+v, want 2 %s orders purged", result, tt.status)
+			}
+			if len(repo.purgeBefore) != 1 || repo.purgeBefore[0].Before(start.Add(-tt.olderThan)) {
+				t.Errorf("PurgeWithTx before = %v, want %s before now", repo.purgeBefore, tt.olderThan)
+			}
+			if !slices.Equal(cache.deleted, []string{"3", "9"}) {
+				t.Errorf("invalidated %v, want the purged orders", cache.deleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package v1
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"github.com/duynhne/order-service/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrQueueFull indicates the async creation queue is at capacity (or shutting down).
+	// HTTP Status: 503 Service Unavailable
+	ErrQueueFull = errors.New("order queue full")
+
+	// ErrJobNotFound indicates the creation job is unknown, expired, or owned by another user.
+	// HTTP Status: 404 Not Found
+	ErrJobNotFound = errors.New("order creation job not found")
+)
+
+// CreateJobStatus is the lifecycle state of an async order creation job
+type CreateJobStatus string
+
+// Create job statuses
+const (
+	CreateJobQueued     CreateJobStatus = "queued"
+	CreateJobProcessing CreateJobStatus = "processing"
+	CreateJobCompleted  CreateJobStatus = "completed"
+	CreateJobFailed     CreateJobStatus = "failed"
+)
+
+// createJobRetention is how long finished jobs stay pollable
+const createJobRetention = 15 * time.Minute
+
+// CreateJob is the pollable state of one queued CreateOrder request
+type CreateJob struct {
+	ID        string          `json:"job_id"`
+	Status    CreateJobStatus `json:"status"`
+	OrderID   string          `json:"order_id,omitempty"`
+	Error     string          `json:"error,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+
+	userID string
+}
+
+// OnCreated runs after a queued order is committed (e.g. best-effort cart clearing)
+type OnCreated func(ctx context.Context, order *domain.Order)
+
+type queuedCreate struct {
+	job       *CreateJob
+	req       domain.CreateOrderRequest
+	onCreated OnCreated
+}
+
+// OrderQueue smooths order-creation spikes: requests are buffered in a bounded channel
+// and committed by a fixed pool of workers, so DB load is capped at `workers` concurrent
+// creations. Enqueue never blocks; a full queue is reported as ErrQueueFull (backpressure).
+//
+// Job state is kept in memory on the replica that accepted the request, so clients must
+// poll the same replica (e.g. session affinity) and jobs do not survive a restart.
+// Queued requests are still drained on graceful shutdown.
+type OrderQueue struct {
+	service *OrderService
+	workers int
+	ch      chan queuedCreate
+
+	mu         sync.Mutex
+	jobs       map[string]*CreateJob
+	closed     bool
+	lastPruned time.Time
+}
+
+// NewOrderQueue creates a queue holding up to size pending requests, processed by workers goroutines.
+// Call Run to start processing.
+func NewOrderQueue(service *OrderService, size, workers int) *OrderQueue {
+	return &OrderQueue{
+		service: service,
+		workers: max(workers, 1),
+		ch:      make(chan queuedCreate, max(size, 1)),
+		jobs:    make(map[string]*CreateJob),
+	}
+}
+
+// Enqueue accepts req for async creation and returns the queued job.
+// Requests that would fail CreateOrder validation, the price policy included, are rejected up
+// front (ErrInvalidOrder), so clients get a 400 instead of a failed job. Returns ErrQueueFull
+// when the buffer is full or the queue is shutting down.
+func (q *OrderQueue) Enqueue(ctx context.Context, req domain.CreateOrderRequest, onCreated OnCreated) (*CreateJob, error) {
+	if err := validateCreateRequest(req); err != nil {
+		return nil, err
+	}
+	items, err := q.service.resolvePrices(ctx, req.Items)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := q.service.priceOrder(items, req.Priority); err != nil {
+		return nil, err
+	}
+
+	id, err := newJobID()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	job := &CreateJob{ID: id, Status: CreateJobQueued, CreatedAt: now, UpdatedAt: now, userID: req.UserID}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return nil, fmt.Errorf("enqueue order for user %q: queue closed: %w", req.UserID, ErrQueueFull)
+	}
+	select {
+	case q.ch <- queuedCreate{job: job, req: req, onCreated: onCreated}:
+	default:
+		return nil, fmt.Errorf("enqueue order for user %q: %w", req.UserID, ErrQueueFull)
+	}
+
+	q.pruneLocked(now)
+	q.jobs[id] = job
+	snapshot := *job
+	return &snapshot, nil
+}
+
+// Job returns a snapshot of job id if it belongs to userID
+func (q *OrderQueue) Job(id, userID string) (*CreateJob, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	job, ok := q.jobs[id]
+	if !ok || job.userID != userID {
+		return nil, fmt.Errorf("get order job %q: %w", id, ErrJobNotFound)
+	}
+	snapshot := *job
+	return &snapshot, nil
+}
+
+// Run processes queued requests until ctx is cancelled, then stops accepting new
+// requests, drains what is already queued and returns once all workers are done.
+func (q *OrderQueue) Run(ctx context.Context, logger *zap.Logger) {
+	var wg sync.WaitGroup
+	// Queued orders were already acknowledged with 202, so finish them even during shutdown.
+	workCtx := context.WithoutCancel(ctx)
+	for range q.workers {
+		wg.Go(func() {
+			for item := range q.ch {
+				q.process(workCtx, item, logger)
+			}
+		})
+	}
+
+	<-ctx.Done()
+	q.mu.Lock()
+	q.closed = true
+	close(q.ch)
+	q.mu.Unlock()
+
+	wg.Wait()
+	logger.Info("Order creation queue drained")
+}
+
+func (q *OrderQueue) process(ctx context.Context, item queuedCreate, logger *zap.Logger) {
+	ctx, span := middleware.StartSpan(ctx, "order.queue.process", trace.WithAttributes(
+		attribute.String("layer", "logic"),
+		attribute.String("job.id", item.job.ID),
+		attribute.String("user.id", item.req.UserID),
+	))
+	defer span.End()
+
+	q.update(item.job.ID, func(j *CreateJob) { j.Status = CreateJobProcessing })
+
+	order, err := q.service.CreateOrder(ctx, item.req)
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("Queued order creation failed", zap.String("job_id", item.job.ID), zap.Error(err))
+		// Client-facing reason only; the cause is in logs and the trace
+		reason := "order creation failed"
+		switch {
+		case errors.Is(err, ErrInvalidOrder):
+			reason = "invalid order"
+		case errors.Is(err, ErrInsufficientStock):
+			reason = "insufficient stock"
+		}
+		q.update(item.job.ID, func(j *CreateJob) {
+			j.Status = CreateJobFailed
+			j.Error = reason
+		})
+		return
+	}
+
+	span.SetAttributes(attribute.String("order.id", order.ID))
+	q.update(item.job.ID, func(j *CreateJob) {
+		j.Status = CreateJobCompleted
+		j.OrderID = order.ID
+	})
+	if item.onCreated != nil {
+		item.onCreated(ctx, order)
+	}
+}
+
+func (q *OrderQueue) update(id string, fn func(*CreateJob)) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if job, ok := q.jobs[id]; ok {
+		fn(job)
+		job.UpdatedAt = time.Now()
+	}
+}
+
+// pruneLocked drops finished jobs older than createJobRetention, at most once a minute
+// so enqueueing stays cheap during spikes; q.mu must be held
+func (q *OrderQueue) pruneLocked(now time.Time) {
+	if now.Sub(q.lastPruned) < time.Minute {
+		return
+	}
+	q.lastPruned = now
+	for id, job := range q.jobs {
+		finished := job.Status == CreateJobCompleted || job.Status == CreateJobFailed
+		if finished && now.Sub(job.UpdatedAt) > createJobRetention {
+			delete(q.jobs, id)
+		}
+	}
+}
+
+func newJobID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate job id: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
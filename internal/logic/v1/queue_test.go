@@ -0,0 +1,90 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+func validCreateRequest(userID string) domain.CreateOrderRequest {
+	return domain.CreateOrderRequest{
+		UserID: userID,
+		Items:  []domain.OrderItem{{ProductID: "101", ProductName: "Widget", Quantity: 1, Price: 10}},
+	}
+}
+
+func TestOrderQueueProcessesAndDrains(t *testing.T) {
+	repo := &MockOrderRepository{
+		createWithTxFunc: func(ctx context.Context, tx domain.Transaction, order *domain.Order) error {
+			order.ID = "42"
+			return nil
+		},
+	}
+	queue := NewOrderQueue(NewOrderService(repo, &MockTransactionManager{}), 10, 2)
+
+	var created []string
+	job, err := queue.Enqueue(context.Background(), validCreateRequest("u1"), func(ctx context.Context, order *domain.Order) {
+		created = append(created, order.ID)
+	})
+	if err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+	if job.Status != CreateJobQueued {
+		t.Errorf("Enqueue() status = %q, want %q", job.Status, CreateJobQueued)
+	}
+
+	// Cancelling before Run still drains the already-queued job
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	queue.Run(ctx, zap.NewNop())
+
+	got, err := queue.Job(job.ID, "u1")
+	if err != nil {
+		t.Fatalf("Job() error = %v", err)
+	}
+	if got.Status != CreateJobCompleted || got.OrderID != "42" {
+		t.Errorf("Job() = %+v, want completed with order 42", got)
+	}
+	if len(created) != 1 {
+		t.Errorf("onCreated calls = %d, want 1", len(created))
+	}
+
+	if _, err := queue.Job(job.ID, "someone-else"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Job() for other user error = %v, want ErrJobNotFound", err)
+	}
+	if _, err := queue.Enqueue(context.Background(), validCreateRequest("u1"), nil); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("Enqueue() after shutdown error = %v, want ErrQueueFull", err)
+	}
+}
+
+func TestOrderQueueBackpressure(t *testing.T) {
+	queue := NewOrderQueue(NewOrderService(&MockOrderRepository{}, &MockTransactionManager{}), 1, 1)
+
+	if _, err := queue.Enqueue(context.Background(), validCreateRequest("u1"), nil); err != nil {
+		t.Fatalf("first Enqueue() error = %v", err)
+	}
+	if _, err := queue.Enqueue(context.Background(), validCreateRequest("u1"), nil); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("Enqueue() on full queue error = %v, want ErrQueueFull", err)
+	}
+	if _, err := queue.Enqueue(context.Background(), domain.CreateOrderRequest{UserID: "u1"}, nil); !errors.Is(err, ErrInvalidOrder) {
+		t.Errorf("Enqueue() with no items error = %v, want ErrInvalidOrder", err)
+	}
+}
+
+func TestOrderQueueEnqueueAppliesPricePolicy(t *testing.T) {
+	catalog := &mockPriceCatalog{prices: map[string]float64{"101": 12}}
+	service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{},
+		WithPricePolicy(PriceRejectOnMismatch, catalog),
+	)
+	queue := NewOrderQueue(service, 1, 1)
+
+	if _, err := queue.Enqueue(context.Background(), validCreateRequest("u1"), nil); !errors.Is(err, ErrInvalidOrder) {
+		t.Errorf("Enqueue() with a price off the catalog error = %v, want ErrInvalidOrder", err)
+	}
+	if catalog.calls != 1 {
+		t.Errorf("catalog lookups = %d, want 1", catalog.calls)
+	}
+}
@@ -0,0 +1,151 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"github.com/duynhne/order-service/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// reconcileBatchSize caps the orders checked per reconciliation run
+const reconcileBatchSize = 500
+
+// reconcilableStatuses are the local statuses that shipping progress can advance
+var reconcilableStatuses = []domain.OrderStatus{
+	domain.OrderStatusPaid,
+	domain.OrderStatusProcessing,
+	domain.OrderStatusShipped,
+}
+
+// orderStatusForShipment maps a shipping-service shipment status to the matching order status
+func orderStatusForShipment(shipmentStatus string) (domain.OrderStatus, bool) {
+	switch shipmentStatus {
+	case "shipped", "in_transit", "out_for_delivery":
+		return domain.OrderStatusShipped, true
+	case "delivered":
+		return domain.OrderStatusCompleted, true
+	}
+	return "", false
+}
+
+// ReconcileResult summarizes one reconciliation run
+type ReconcileResult struct {
+	Checked       int
+	Updated       int
+	Discrepancies int
+	Errors        int
+	// Findings lists every order that differed from its shipment or could not be checked, in scan order
+	Findings []ReconcileFinding
+}
+
+// ReconcileFinding is one order a reconciliation run flagged. Err is set when the shipment lookup
+// or the status update failed; otherwise Updated tells whether the order was moved to Target.
+type ReconcileFinding struct {
+	OrderID        string
+	OrderStatus    domain.OrderStatus
+	ShipmentStatus string
+	Target         domain.OrderStatus
+	Updated        bool
+	Err            error
+}
+
+// ReconcileWithShipping compares recently-active orders against the shipping service and
+// advances local statuses that lag behind shipment progress (e.g. paid -> shipped).
+// Orders are scanned in reconcileBatchSize pages by (updated_at, id), so every order updated
+// since is checked however many there are. Discrepancies that cannot be fixed forward
+// (shipping behind local state) are only reported in the result's Findings.
+func (s *OrderService) ReconcileWithShipping(
+	ctx context.Context, provider domain.ShipmentStatusProvider, since time.Time,
+) (ReconcileResult, error) {
+	ctx, span := middleware.StartSpan(ctx, "order.reconcile", trace.WithAttributes(
+		attribute.String("layer", "logic"),
+	))
+	defer span.End()
+
+	var (
+		result ReconcileResult
+		cursor domain.OrderUpdateCursor
+	)
+	for {
+		orders, next, err := s.orderRepo.FindUpdatedSince(ctx, since, reconcilableStatuses, cursor, reconcileBatchSize)
+		if err != nil {
+			span.RecordError(err)
+			return result, err
+		}
+		for _, order := range orders {
+			s.reconcileOrder(ctx, provider, order, &result)
+		}
+		if len(orders) < reconcileBatchSize {
+			break
+		}
+		cursor = next
+	}
+
+	span.SetAttributes(
+		attribute.Int("reconcile.checked", result.Checked),
+		attribute.Int("reconcile.updated", result.Updated),
+		attribute.Int("reconcile.discrepancies", result.Discrepancies),
+	)
+	return result, nil
+}
+
+// reconcileOrder checks one order against its shipment and records the outcome in result
+func (s *OrderService) reconcileOrder(
+	ctx context.Context, provider domain.ShipmentStatusProvider, order domain.Order, result *ReconcileResult,
+) {
+	result.Checked++
+	shipmentStatus, found, err := provider.ShipmentStatus(ctx, order.ID)
+	if err != nil {
+		result.Errors++
+		result.Findings = append(result.Findings, ReconcileFinding{OrderID: order.ID, OrderStatus: order.Status, Err: err})
+		return
+	}
+	if !found {
+		return
+	}
+	target, ok := orderStatusForShipment(shipmentStatus)
+	if !ok || target == order.Status {
+		return
+	}
+
+	result.Discrepancies++
+	finding := ReconcileFinding{
+		OrderID:        order.ID,
+		OrderStatus:    order.Status,
+		ShipmentStatus: shipmentStatus,
+		Target:         target,
+	}
+	// The transition table only allows forward moves, so shipping never drags an order backwards
+	_, finding.Updated, finding.Err = s.transitionStatus(ctx, order.ID, target, StatusSourceReconciliation, false)
+	switch {
+	case finding.Err != nil:
+		result.Errors++
+	case finding.Updated:
+		result.Updated++
+	}
+	result.Findings = append(result.Findings, finding)
+}
+
+// RunReconciliation runs ReconcileWithShipping every interval over orders active within lookback,
+// until ctx is cancelled, and hands each run's outcome to report.
+func (s *OrderService) RunReconciliation(
+	ctx context.Context,
+	provider domain.ShipmentStatusProvider,
+	interval, lookback time.Duration,
+	report func(ReconcileResult, error),
+) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report(s.ReconcileWithShipping(ctx, provider, time.Now().Add(-lookback)))
+		}
+	}
+}
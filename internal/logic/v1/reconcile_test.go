@@ -0,0 +1,76 @@
+package v1
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+)
+
+type mockShipmentProvider map[string]string
+
+func (m mockShipmentProvider) ShipmentStatus(ctx context.Context, orderID string) (string, bool, error) {
+	status, ok := m[orderID]
+	return status, ok, nil
+}
+
+func TestReconcileWithShipping(t *testing.T) {
+	mockRepo := &MockOrderRepository{
+		updatedSince: []domain.Order{
+			{ID: "1", Status: domain.OrderStatusPaid},    // shipped in shipping -> advance
+			{ID: "2", Status: domain.OrderStatusShipped}, // in sync
+			{ID: "3", Status: domain.OrderStatusPaid},    // no shipment yet
+		},
+		findStatusFunc: func(ctx context.Context, id string) (domain.OrderStatus, error) {
+			return domain.OrderStatusPaid, nil
+		},
+	}
+	provider := mockShipmentProvider{"1": "in_transit", "2": "shipped"}
+	service := NewOrderService(mockRepo, &MockTransactionManager{})
+
+	result, err := service.ReconcileWithShipping(context.Background(), provider, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("ReconcileWithShipping() error = %v", err)
+	}
+
+	if result.Checked != 3 || result.Updated != 1 || result.Discrepancies != 1 {
+		t.Errorf("ReconcileWithShipping() = %+v, want checked=3 updated=1 discrepancies=1", result)
+	}
+	if len(mockRepo.history) != 1 || mockRepo.history[0].Source != StatusSourceReconciliation {
+		t.Errorf("history = %+v, want one reconciliation entry", mockRepo.history)
+	}
+	if len(result.Findings) != 1 || result.Findings[0].OrderID != "1" || !result.Findings[0].Updated {
+		t.Errorf("Findings = %+v, want order 1 updated", result.Findings)
+	}
+}
+
+func TestReconcileWithShippingScansEveryPage(t *testing.T) {
+	total := 2*reconcileBatchSize + 1
+	orders := make([]domain.Order, total)
+	for i := range orders {
+		orders[i] = domain.Order{ID: strconv.Itoa(i + 1), Status: domain.OrderStatusPaid}
+	}
+	newest := orders[total-1].ID
+	mockRepo := &MockOrderRepository{
+		updatedSince: orders,
+		findStatusFunc: func(ctx context.Context, id string) (domain.OrderStatus, error) {
+			return domain.OrderStatusPaid, nil
+		},
+	}
+	provider := mockShipmentProvider{newest: "shipped"}
+	service := NewOrderService(mockRepo, &MockTransactionManager{})
+
+	result, err := service.ReconcileWithShipping(context.Background(), provider, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("ReconcileWithShipping() error = %v", err)
+	}
+
+	if result.Checked != total || result.Updated != 1 {
+		t.Errorf("ReconcileWithShipping() = checked %d updated %d, want checked %d updated 1", result.Checked, result.Updated, total)
+	}
+	if len(result.Findings) != 1 || result.Findings[0].OrderID != newest {
+		t.Errorf("Findings = %+v, want the newest order %s", result.Findings, newest)
+	}
+}
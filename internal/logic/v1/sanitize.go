@@ -0,0 +1,68 @@
+package v1
+
+import (
+	"html"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// maxProductNameLength matches order_items.product_name VARCHAR(255)
+const maxProductNameLength = 255
+
+// skuPattern is the accepted SKU format: letters, digits, '-', '_' and '.', starting with a letter
+// or digit, at most 64 characters (order_items.sku VARCHAR(64))
+var skuPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+
+// validSKU reports whether sku is a well-formed stock-keeping unit; the empty SKU (none given) is valid
+func validSKU(sku string) bool {
+	return sku == "" || skuPattern.MatchString(sku)
+}
+
+// validProductID reports whether id can be an order_items.product_id (INTEGER, a product service
+// products.id): a positive decimal int32. Anything else would pass validation only to fail the insert.
+func validProductID(id string) bool {
+	return positiveInt32(id)
+}
+
+// validOrderID reports whether id can be an orders.id (SERIAL: a positive decimal int32).
+// Rejecting malformed IDs early avoids a DB round trip that would only end in a 404 or a cast error.
+func validOrderID(id string) bool {
+	return positiveInt32(id)
+}
+
+// positiveInt32 reports whether s is the canonical decimal form of a positive int32
+func positiveInt32(s string) bool {
+	if s == "" || s[0] < '1' || s[0] > '9' {
+		return false // also rejects signs, leading zeros and whitespace
+	}
+	n, err := strconv.ParseInt(s, 10, 32)
+	return err == nil && n > 0 && n <= math.MaxInt32
+}
+
+// sanitizeProductName neutralizes client-supplied product names before they are stored
+// and later rendered by the frontend (stored XSS):
+//   - control characters are removed and surrounding whitespace trimmed
+//   - HTML special characters are escaped (<script> becomes &lt;script&gt;)
+//   - the escaped result is capped at maxLen bytes without splitting an entity; truncated reports
+//     whether it was longer and had to be cut
+func sanitizeProductName(name string, maxLen int) (sanitized string, truncated bool) {
+	name = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name))
+
+	var b strings.Builder
+	for _, r := range name {
+		escaped := html.EscapeString(string(r))
+		if b.Len()+len(escaped) > maxLen {
+			return b.String(), true
+		}
+		b.WriteString(escaped)
+	}
+	return b.String(), false
+}
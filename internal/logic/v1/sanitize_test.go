@@ -0,0 +1,121 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+)
+
+func TestSanitizeProductName(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "Plain name unchanged", in: "Wireless Mouse", want: "Wireless Mouse"},
+		{name: "Script tag escaped", in: "<script>alert(1)</script>", want: "&lt;script&gt;alert(1)&lt;/script&gt;"},
+		{name: "Attribute injection escaped", in: `"><img src=x onerror=alert(1)>`, want: "&#34;&gt;&lt;img src=x onerror=alert(1)&gt;"},
+		{name: "Control chars stripped", in: "Mouse\x00\x1b[31m\n", want: "Mouse[31m"},
+		{name: "Only whitespace", in: "  \t ", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := sanitizeProductName(tt.in, maxProductNameLength); got != tt.want {
+				t.Errorf("sanitizeProductName(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeProductNameLengthCap(t *testing.T) {
+	got, truncated := sanitizeProductName(strings.Repeat("<", 200), maxProductNameLength)
+	if !truncated {
+		t.Error("truncated = false, want true")
+	}
+	if len(got) > maxProductNameLength {
+		t.Fatalf("len = %d, want <= %d", len(got), maxProductNameLength)
+	}
+	if strings.TrimSuffix(got, "&lt;") != strings.Repeat("&lt;", len(got)/4-1) {
+		t.Errorf("truncation split an HTML entity: %q", got[len(got)-8:])
+	}
+}
+
+func TestCreateOrderNeutralizesMaliciousProductName(t *testing.T) {
+	service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{})
+
+	order, err := service.CreateOrder(context.Background(), domain.CreateOrderRequest{
+		UserID: "user1",
+		Items: []domain.OrderItem{
+			{ProductID: "101", ProductName: "<script>alert('xss')</script>", Quantity: 1, Price: 10},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+	if name := order.Items[0].ProductName; strings.Contains(name, "<") || strings.Contains(name, ">") {
+		t.Errorf("CreateOrder() stored product name %q, want HTML escaped", name)
+	}
+}
+
+func TestCreateOrderRejectsInvalidProductID(t *testing.T) {
+	service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{})
+
+	for _, id := range []string{"", "<script>", "1 OR 1=1", "p1", "0", "007", "2147483648", strings.Repeat("1", 65)} {
+		_, err := service.CreateOrder(context.Background(), domain.CreateOrderRequest{
+			UserID: "user1",
+			Items:  []domain.OrderItem{{ProductID: id, Quantity: 1, Price: 10}},
+		})
+		if !errors.Is(err, ErrInvalidOrder) {
+			t.Errorf("CreateOrder(product_id=%q) error = %v, want ErrInvalidOrder", id, err)
+		}
+	}
+}
+
+func TestCreateOrderLongProductName(t *testing.T) {
+	longName := strings.Repeat("a", 1000)
+	req := domain.CreateOrderRequest{
+		UserID: "user1",
+		Items:  []domain.OrderItem{{ProductID: "101", ProductName: longName, Quantity: 1, Price: 10}},
+	}
+
+	t.Run("Truncated by default", func(t *testing.T) {
+		service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{})
+		order, err := service.CreateOrder(context.Background(), req)
+		if err != nil {
+			t.Fatalf("CreateOrder() error = %v", err)
+		}
+		if got := order.Items[0].ProductName; got != longName[:maxProductNameLength] {
+			t.Errorf("product name has %d bytes, want the first %d", len(got), maxProductNameLength)
+		}
+	})
+
+	t.Run("Truncated to the configured length", func(t *testing.T) {
+		service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{}, WithProductNameLimit(100, true, nil))
+		order, err := service.CreateOrder(context.Background(), req)
+		if err != nil {
+			t.Fatalf("CreateOrder() error = %v", err)
+		}
+		if got := order.Items[0].ProductName; got != longName[:100] {
+			t.Errorf("product name has %d bytes, want 100", len(got))
+		}
+	})
+
+	t.Run("Rejected without truncation", func(t *testing.T) {
+		repo := &MockOrderRepository{}
+		service := NewOrderService(repo, &MockTransactionManager{}, WithProductNameLimit(maxProductNameLength, false, nil))
+		if _, err := service.CreateOrder(context.Background(), req); !errors.Is(err, ErrInvalidOrder) {
+			t.Fatalf("CreateOrder() error = %v, want ErrInvalidOrder", err)
+		}
+
+		// A name within the limit is still accepted
+		fits := req
+		fits.Items = []domain.OrderItem{{ProductID: "101", ProductName: longName[:maxProductNameLength], Quantity: 1, Price: 10}}
+		if _, err := service.CreateOrder(context.Background(), fits); err != nil {
+			t.Errorf("CreateOrder() with a %d-byte name error = %v", maxProductNameLength, err)
+		}
+	})
+}
@@ -3,44 +3,233 @@ package v1
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
+	"time"
 
 	"github.com/duynhne/order-service/internal/core/domain"
 	"github.com/duynhne/order-service/middleware"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
 )
 
 // OrderService handles order business logic
 type OrderService struct {
 	orderRepo domain.OrderRepository
 	txManager domain.TransactionManager
+	shipping  ShippingCalculator
+	cache     domain.OrderCache // optional; nil disables caching
+
+	notifier     domain.Notifier // optional; nil disables status notifications
+	notifyLogger *zap.Logger
+	notifyWG     sync.WaitGroup // in-flight notification dispatches
+
+	allowZeroPrice bool    // accept items with Price == 0 (free items)
+	minTotal       float64 // minimum subtotal (before shipping); 0 disables
+	maxProducts    int     // maximum distinct ProductIDs per order; 0 disables
+	mergeItems     bool    // merge line items sharing a ProductID
+	maxNameLength  int     // product name cap in bytes after escaping, at most maxProductNameLength
+	truncateNames  bool    // cut longer product names (logged) instead of rejecting the order
+	nameLogger     *zap.Logger
+	taxRate        float64 // rate for items without their own TaxRate; 0 disables
+	rounding       RoundingMode
+	promotions     PromotionEngine // optional; nil applies no automatic promotions
+	pricePolicy    PricePolicy
+	catalog        domain.PriceCatalog     // optional; required by policies other than PriceTrustClient
+	inventory      domain.InventoryChecker // optional; nil skips the pre-create stock check
+
+	drafts      bool        // CreateOrder creates drafts that the owner confirms (ConfirmOrder)
+	transitions Transitions // allowed status changes
+
+	deliveryBaseDays  int // days from order date to estimated delivery
+	expressAdjustDays int // added to deliveryBaseDays for express orders
+}
+
+// Option configures optional OrderService behavior
+type Option func(*OrderService)
+
+// WithShippingCalculator overrides the default flat-rate shipping calculator
+func WithShippingCalculator(calc ShippingCalculator) Option {
+	return func(s *OrderService) {
+		if calc != nil {
+			s.shipping = calc
+		}
+	}
+}
+
+// WithAllowZeroPrice controls whether items priced at 0 are accepted (default: true).
+// Catalogs without free items disable it to catch clients that didn't populate prices.
+func WithAllowZeroPrice(allow bool) Option {
+	return func(s *OrderService) {
+		s.allowZeroPrice = allow
+	}
+}
+
+// WithMinOrderTotal rejects orders whose subtotal (before shipping) is below minTotal
+// with a *BelowMinimumTotalError. 0 (the default) disables the check.
+func WithMinOrderTotal(minTotal float64) Option {
+	return func(s *OrderService) {
+		s.minTotal = minTotal
+	}
+}
+
+// WithMaxDistinctProducts rejects orders naming more than maxProducts distinct products with a
+// *TooManyProductsError (default: DefaultMaxDistinctProducts). 0 disables the check.
+func WithMaxDistinctProducts(maxProducts int) Option {
+	return func(s *OrderService) {
+		s.maxProducts = maxProducts
+	}
+}
+
+// WithTaxRate sets the default tax rate (a fraction, 0.08 = 8%) applied to items that do not
+// carry their own TaxRate (default: 0, untaxed)
+func WithTaxRate(rate float64) Option {
+	return func(s *OrderService) {
+		s.taxRate = rate
+	}
+}
+
+// WithRoundingMode sets how computed amounts are rounded to cents (default: RoundingHalfUp)
+func WithRoundingMode(mode RoundingMode) Option {
+	return func(s *OrderService) {
+		s.rounding = mode
+	}
+}
+
+// WithMergeDuplicateItems merges line items that share a ProductID into one item with the summed
+// quantity before pricing (default: false, each line item is stored as sent).
+func WithMergeDuplicateItems(merge bool) Option {
+	return func(s *OrderService) {
+		s.mergeItems = merge
+	}
+}
+
+// WithProductNameLimit caps product names at maxLength bytes (after HTML escaping; at most and by
+// default maxProductNameLength, the column size). Longer names are cut with a warning to logger
+// when truncate is set (the default), otherwise the order is rejected with ErrInvalidOrder.
+func WithProductNameLimit(maxLength int, truncate bool, logger *zap.Logger) Option {
+	return func(s *OrderService) {
+		if maxLength > 0 && maxLength <= maxProductNameLength {
+			s.maxNameLength = maxLength
+		}
+		s.truncateNames = truncate
+		if logger != nil {
+			s.nameLogger = logger
+		}
+	}
+}
+
+// WithTransitions replaces the built-in state machine (DefaultTransitions) with t, e.g. one read
+// with ParseTransitions. A nil t keeps the default.
+func WithTransitions(t Transitions) Option {
+	return func(s *OrderService) {
+		if t != nil {
+			s.transitions = t
+		}
+	}
 }
 
 // NewOrderService creates a new OrderService with repository injection
-func NewOrderService(orderRepo domain.OrderRepository, txManager domain.TransactionManager) *OrderService {
-	return &OrderService{
+func NewOrderService(orderRepo domain.OrderRepository, txManager domain.TransactionManager, opts ...Option) *OrderService {
+	s := &OrderService{
 		orderRepo: orderRepo,
 		txManager: txManager,
+		shipping: ExpressShipping{
+			Base:      FlatRateShipping{Rate: DefaultFlatShippingRate},
+			Surcharge: DefaultExpressSurcharge,
+		},
+
+		allowZeroPrice: true,
+		maxProducts:    DefaultMaxDistinctProducts,
+		maxNameLength:  maxProductNameLength,
+		truncateNames:  true,
+		nameLogger:     zap.NewNop(),
+		rounding:       RoundingHalfUp,
+		pricePolicy:    PriceTrustClient,
+		transitions:    defaultTransitions,
+
+		deliveryBaseDays:  DefaultDeliveryBaseDays,
+		expressAdjustDays: DefaultExpressDeliveryAdjustDays,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// ListOptions controls optional data loaded by ListOrders
+type ListOptions struct {
+	// IncludeItems batch-loads line items for the page of orders (one extra query)
+	IncludeItems bool
+	// IncludeDrafts lists (and counts) unconfirmed draft orders too
+	IncludeDrafts bool
 }
 
-// ListOrders retrieves all orders for a user
-func (s *OrderService) ListOrders(ctx context.Context, userID string) ([]domain.Order, error) {
+// ListOrders retrieves one page of orders for a user and the user's total order count.
+// Items are only loaded when opts.IncludeItems is set, keeping the default list lightweight.
+func (s *OrderService) ListOrders(
+	ctx context.Context, userID string, page domain.Page, opts ListOptions,
+) ([]domain.Order, int, error) {
 	ctx, span := middleware.StartSpan(ctx, "order.list", trace.WithAttributes(
 		attribute.String("layer", "logic"),
 		attribute.String("user.id", userID),
+		attribute.Int("page.limit", page.Limit),
+		attribute.Int("page.offset", page.Offset),
+		attribute.Bool("include.items", opts.IncludeItems),
+		attribute.Bool("include.drafts", opts.IncludeDrafts),
 	))
 	defer span.End()
 
 	// Call repository
-	orders, err := s.orderRepo.FindByUserID(ctx, userID)
+	orders, err := s.orderRepo.FindByUserID(ctx, userID, page, opts.IncludeDrafts)
 	if err != nil {
 		span.RecordError(err)
-		return nil, err
+		return nil, 0, err
+	}
+
+	total, err := s.orderRepo.CountByUserID(ctx, userID, opts.IncludeDrafts)
+	if err != nil {
+		span.RecordError(err)
+		return nil, 0, err
+	}
+
+	if opts.IncludeItems && len(orders) > 0 {
+		if err := s.attachItems(ctx, orders); err != nil {
+			span.RecordError(err)
+			return nil, 0, err
+		}
 	}
 
-	span.SetAttributes(attribute.Int("orders.count", len(orders)))
-	return orders, nil
+	span.SetAttributes(attribute.Int("orders.count", len(orders)), attribute.Int("orders.total", total))
+	return nonNilOrders(orders), total, nil
+}
+
+// nonNilOrders returns an empty slice in place of nil so empty lists
+// serialize as [] rather than null.
+func nonNilOrders(orders []domain.Order) []domain.Order {
+	if orders == nil {
+		return []domain.Order{}
+	}
+	return orders
+}
+
+// attachItems batch-loads and sets Items on each order
+func (s *OrderService) attachItems(ctx context.Context, orders []domain.Order) error {
+	ids := make([]string, len(orders))
+	for i := range orders {
+		ids[i] = orders[i].ID
+	}
+
+	itemsByOrder, err := s.orderRepo.FindItemsByOrderIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range orders {
+		orders[i].Items = itemsByOrder[orders[i].ID]
+	}
+	return nil
 }
 
 // GetOrder retrieves a single order by ID
@@ -51,6 +240,20 @@ func (s *OrderService) GetOrder(ctx context.Context, id string) (*domain.Order,
 	))
 	defer span.End()
 
+	if !validOrderID(id) {
+		span.SetAttributes(attribute.Bool("order.id_valid", false))
+		return nil, fmt.Errorf("get order: invalid order id %q: %w", id, ErrInvalidInput)
+	}
+
+	if s.cache != nil {
+		if order, ok := s.cache.Get(ctx, id); ok {
+			span.SetAttributes(attribute.Bool("cache.hit", true), attribute.Bool("order.found", true))
+			// Callers may change the order they get (e.g. add a shipment estimate): never hand out the cached one
+			return order.Clone(), nil
+		}
+		span.SetAttributes(attribute.Bool("cache.hit", false))
+	}
+
 	// Call repository
 	order, err := s.orderRepo.FindByID(ctx, id)
 	if err != nil {
@@ -58,91 +261,258 @@ func (s *OrderService) GetOrder(ctx context.Context, id string) (*domain.Order,
 			span.SetAttributes(attribute.Bool("order.found", false))
 			return nil, ErrOrderNotFound
 		}
+		if errors.Is(err, domain.ErrGone) {
+			span.SetAttributes(attribute.Bool("order.found", false), attribute.Bool("order.purged", true))
+			return nil, ErrOrderGone
+		}
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return nil, fmt.Errorf("get order: %w: %w", err, ErrInvalidInput)
+		}
 		span.RecordError(err)
 		return nil, err
 	}
 
+	if s.cache != nil {
+		s.cache.Set(ctx, order.Clone())
+	}
+
 	span.SetAttributes(attribute.Bool("order.found", true))
 	return order, nil
 }
 
-// CreateOrder creates a new order with transaction support
-func (s *OrderService) CreateOrder(ctx context.Context, req domain.CreateOrderRequest) (*domain.Order, error) {
-	ctx, span := middleware.StartSpan(ctx, "order.create", trace.WithAttributes(
+// GetUserOrder retrieves an order on behalf of userID.
+// Returns ErrUnauthorized if the order exists but belongs to another user; the web layer
+// decides whether to reveal that (403) or hide it as not found (404).
+func (s *OrderService) GetUserOrder(ctx context.Context, id, userID string) (*domain.Order, error) {
+	order, err := s.GetOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if order.UserID != userID {
+		trace.SpanFromContext(ctx).SetAttributes(attribute.Bool("order.owner", false))
+		return nil, fmt.Errorf("order %q requested by user %q: %w", id, userID, ErrUnauthorized)
+	}
+	return order, nil
+}
+
+// GetOrderStatus returns just the status and last update of userID's order, without loading
+// items, for cheap status polling. Ownership is enforced like GetUserOrder (ErrUnauthorized).
+func (s *OrderService) GetOrderStatus(ctx context.Context, id, userID string) (*domain.OrderStatusInfo, error) {
+	ctx, span := middleware.StartSpan(ctx, "order.get_status", trace.WithAttributes(
+		attribute.String("layer", "logic"),
+		attribute.String("order.id", id),
+	))
+	defer span.End()
+
+	if !validOrderID(id) {
+		span.SetAttributes(attribute.Bool("order.id_valid", false))
+		return nil, fmt.Errorf("get order status: invalid order id %q: %w", id, ErrInvalidInput)
+	}
+
+	info, err := s.orderRepo.FindStatus(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			span.SetAttributes(attribute.Bool("order.found", false))
+			return nil, fmt.Errorf("get order status %q: %w", id, ErrOrderNotFound)
+		}
+		span.RecordError(err)
+		return nil, err
+	}
+	if info.UserID != userID {
+		span.SetAttributes(attribute.Bool("order.owner", false))
+		return nil, fmt.Errorf("order %q status requested by user %q: %w", id, userID, ErrUnauthorized)
+	}
+
+	span.SetAttributes(attribute.String("order.status", string(info.Status)))
+	return info, nil
+}
+
+// GetOrderItems returns just the line items of userID's order (cancelled ones flagged), never nil.
+// Ownership is enforced like GetUserOrder (ErrUnauthorized).
+func (s *OrderService) GetOrderItems(ctx context.Context, id, userID string) ([]domain.OrderItem, error) {
+	ctx, span := middleware.StartSpan(ctx, "order.get_items", trace.WithAttributes(
+		attribute.String("layer", "logic"),
+		attribute.String("order.id", id),
+	))
+	defer span.End()
+
+	// Ownership and existence come from the status row, so items are only read for the owner
+	if _, err := s.GetOrderStatus(ctx, id, userID); err != nil {
+		return nil, err
+	}
+
+	items, err := s.orderRepo.FindItemsByOrderID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	if items == nil {
+		items = []domain.OrderItem{}
+	}
+
+	span.SetAttributes(attribute.Int("items.count", len(items)))
+	return items, nil
+}
+
+// SearchOrders searches orders across all users (admin only; role is enforced by the caller).
+// At least one filter field is required. Returns the page of orders and the total match count.
+func (s *OrderService) SearchOrders(
+	ctx context.Context, filter domain.OrderSearchFilter, page domain.Page,
+) ([]domain.Order, int, error) {
+	ctx, span := middleware.StartSpan(ctx, "order.search", trace.WithAttributes(
+		attribute.String("layer", "logic"),
+		attribute.String("filter.user_id", filter.UserID),
+		attribute.Int("page.limit", page.Limit),
+		attribute.Int("page.offset", page.Offset),
+	))
+	defer span.End()
+
+	if filter.UserID == "" {
+		return nil, 0, fmt.Errorf("search orders without filter: %w", ErrInvalidInput)
+	}
+
+	orders, total, err := s.orderRepo.Search(ctx, filter, page)
+	if err != nil {
+		span.RecordError(err)
+		return nil, 0, err
+	}
+
+	span.SetAttributes(attribute.Int("orders.count", len(orders)), attribute.Int("orders.total", total))
+	return nonNilOrders(orders), total, nil
+}
+
+// QuoteOrder prices a cart (subtotal, shipping, total) without persisting an order.
+// It applies the same validation and pricing rules as CreateOrder.
+func (s *OrderService) QuoteOrder(ctx context.Context, req domain.CreateOrderRequest) (*domain.OrderQuote, error) {
+	ctx, span := middleware.StartSpan(ctx, "order.quote", trace.WithAttributes(
 		attribute.String("layer", "logic"),
 		attribute.String("user.id", req.UserID),
 	))
 	defer span.End()
 
-	// Business validation
 	if len(req.Items) == 0 {
-		span.SetAttributes(attribute.Bool("order.created", false))
 		return nil, ErrInvalidOrder
 	}
 
-	// Enrich order items: Subtotal, ProductName (fallback if empty)
-	enrichedItems := make([]domain.OrderItem, len(req.Items))
-	var subtotal float64
-	for i, item := range req.Items {
-		itemSubtotal := item.Price * float64(item.Quantity)
-		subtotal += itemSubtotal
-
-		productName := item.ProductName
-		if productName == "" {
-			productName = "Product " + item.ProductID
-		}
+	items, err := s.resolvePrices(ctx, req.Items)
+	if err != nil {
+		return nil, err
+	}
+	quote, err := s.priceOrder(items, req.Priority)
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.Float64("order.shipping", quote.Shipping))
+	return quote, nil
+}
 
-		enrichedItems[i] = domain.OrderItem{
-			ProductID:   item.ProductID,
-			ProductName: productName,
-			Quantity:    item.Quantity,
-			Price:      item.Price,
-			Subtotal:   itemSubtotal,
+// validateCreateRequest checks request-level rules; item rules are enforced by priceOrder
+func validateCreateRequest(req domain.CreateOrderRequest) error {
+	if len(req.Items) == 0 {
+		return ErrInvalidOrder
+	}
+	if req.ShippingAddress != nil {
+		if _, err := normalizeShippingAddress(*req.ShippingAddress); err != nil {
+			return fmt.Errorf("%v: %w", err, ErrInvalidOrder)
 		}
 	}
+	if err := validateExternalRef(req.ExternalRef); err != nil {
+		return err
+	}
+	return validateMetadata(req.Metadata)
+}
 
-	// Create order domain model
-	order := &domain.Order{
-		UserID:   req.UserID,
-		Items:    enrichedItems,
-		Subtotal: subtotal,
-		Shipping: 5.00, // Fixed shipping for demo
-		Total:    subtotal + 5.00,
-		Status:   "pending",
+// CreateOrder creates a new order with transaction support. The order starts pending, or as a
+// draft awaiting ConfirmOrder when WithDraftOrders is set.
+func (s *OrderService) CreateOrder(ctx context.Context, req domain.CreateOrderRequest) (*domain.Order, error) {
+	ctx, span := middleware.StartSpan(ctx, "order.create", trace.WithAttributes(
+		attribute.String("layer", "logic"),
+		attribute.String("user.id", req.UserID),
+	))
+	defer span.End()
+
+	// Business validation
+	if err := validateCreateRequest(req); err != nil {
+		span.SetAttributes(attribute.Bool("order.created", false))
+		return nil, err
 	}
 
-	// Begin transaction
-	tx, err := s.txManager.Begin(ctx)
+	items, err := s.resolvePrices(ctx, req.Items)
 	if err != nil {
-		span.RecordError(err)
+		span.SetAttributes(attribute.Bool("order.created", false))
 		return nil, err
 	}
-	defer func() { _ = tx.Rollback(ctx) }() // Rollback if not committed
-
-	// Create order with transaction
-	err = s.orderRepo.CreateWithTx(ctx, tx, order)
+	quote, err := s.priceOrder(items, req.Priority)
 	if err != nil {
+		span.SetAttributes(attribute.Bool("order.created", false))
+		return nil, err
+	}
+	// Fail fast, listing every short item, before anything is written
+	if err := s.checkStock(ctx, quote.Items); err != nil {
 		span.RecordError(err)
+		span.SetAttributes(attribute.Bool("order.created", false))
 		return nil, err
 	}
+	// Business size of the order, set before persisting so slow or failed creates carry it too.
+	// Amounts and counts only: no product names or other customer-entered text.
+	span.SetAttributes(
+		attribute.Int("order.item_count", len(quote.Items)),
+		attribute.Float64("order.subtotal", quote.Subtotal),
+		attribute.Float64("order.tax", quote.Tax),
+		attribute.Float64("order.total", quote.Total),
+	)
+
+	var address *domain.ShippingAddress
+	if req.ShippingAddress != nil {
+		normalized, _ := normalizeShippingAddress(*req.ShippingAddress) // validated above
+		address = &normalized
+	}
+
+	estimatedDelivery := s.estimateDelivery(time.Now(), quote.Priority)
+
+	// Create order domain model
+	order := &domain.Order{
+		UserID:          req.UserID,
+		Items:           quote.Items,
+		Subtotal:        quote.Subtotal,
+		Shipping:        quote.Shipping,
+		Tax:             quote.Tax,
+		Discount:        quote.Discount,
+		Total:           quote.Total,
+		TotalWeight:     quote.TotalWeight,
+		Status:          s.initialStatus(),
+		Priority:        quote.Priority,
+		Metadata:        req.Metadata,
+		ShippingAddress: address,
+		ExternalRef:     req.ExternalRef,
+		Promotions:      quote.Promotions,
+
+		EstimatedDelivery: &estimatedDelivery,
+	}
 
-	// TODO: Update inventory (when inventory service is available)
-	// for _, item := range order.Items {
-	//     err = s.inventoryRepo.DecrementStockWithTx(ctx, tx, item.ProductID, item.Quantity)
-	//     if err != nil {
-	//         return nil, ErrInsufficientStock
-	//     }
-	// }
+	// Create order with transaction; re-run from scratch if the connection is lost before COMMIT
+	err = s.inTx(ctx, func(tx domain.Transaction) error {
+		if err := s.orderRepo.CreateWithTx(ctx, tx, order); err != nil {
+			return err
+		}
 
-	// TODO: Clear cart (when cart clearing with transaction is needed)
-	// err = s.cartRepo.ClearWithTx(ctx, tx, req.UserID)
-	// if err != nil {
-	//     return nil, err
-	// }
+		// TODO: Update inventory (when inventory service is available)
+		// for _, item := range order.Items {
+		//     err = s.inventoryRepo.DecrementStockWithTx(ctx, tx, item.ProductID, item.Quantity)
+		//     if err != nil {
+		//         return ErrInsufficientStock
+		//     }
+		// }
 
-	// Commit transaction
-	if err := tx.Commit(ctx); err != nil {
+		// TODO: Clear cart (when cart clearing with transaction is needed)
+		// return s.cartRepo.ClearWithTx(ctx, tx, req.UserID)
+		return nil
+	})
+	if err != nil {
 		span.RecordError(err)
+		if errors.Is(err, domain.ErrConflict) {
+			return nil, fmt.Errorf("create order: %v: %w", err, ErrDuplicateExternalRef)
+		}
 		return nil, err
 	}
 
@@ -155,25 +525,77 @@ func (s *OrderService) CreateOrder(ctx context.Context, req domain.CreateOrderRe
 	return order, nil
 }
 
-// UpdateOrderStatus updates the status of an order
-func (s *OrderService) UpdateOrderStatus(ctx context.Context, id, status string) error {
+// Status change sources recorded in order status history
+const (
+	StatusSourcePaymentWebhook = "payment_webhook"
+	StatusSourceReconciliation = "reconciliation"
+	StatusSourceAPI            = "api"
+	StatusSourceItemCancel     = "item_cancel" // last active item cancelled
+	StatusSourceConfirm        = "confirm"     // owner confirmed a draft
+	StatusSourceDraftExpiry    = "draft_expiry"
+)
+
+// MarkOrderPaid transitions a pending order to paid after the payment provider confirms payment.
+// It is idempotent: an order that is already paid is left untouched and alreadyPaid is true.
+// Returns ErrInvalidInput for an ID that cannot be an order's, ErrOrderNotFound if the order does
+// not exist, or ErrInvalidOrderState if the order is in any status other than pending or paid.
+func (s *OrderService) MarkOrderPaid(ctx context.Context, id string) (alreadyPaid bool, err error) {
+	ctx, span := middleware.StartSpan(ctx, "order.mark_paid", trace.WithAttributes(
+		attribute.String("layer", "logic"),
+		attribute.String("order.id", id),
+	))
+	defer span.End()
+
+	if !validOrderID(id) {
+		span.SetAttributes(attribute.Bool("order.id_valid", false))
+		return false, fmt.Errorf("mark order paid: invalid order id %q: %w", id, ErrInvalidInput)
+	}
+
+	current, changed, err := s.transitionStatus(ctx, id, domain.OrderStatusPaid, StatusSourcePaymentWebhook, false)
+	if err != nil {
+		if !errors.Is(err, ErrOrderNotFound) && !errors.Is(err, ErrInvalidOrderState) {
+			span.RecordError(err)
+		}
+		return false, err
+	}
+	span.SetAttributes(attribute.String("order.status", current.String()))
+
+	if !changed {
+		span.SetAttributes(attribute.Bool("order.already_paid", true))
+		return true, nil
+	}
+
+	span.AddEvent("order.paid")
+	return false, nil
+}
+
+// UpdateOrderStatus moves an order to status, recording history.
+// Returns ErrInvalidOrderState if status is not a known OrderStatus or the transition table
+// does not allow the move. Setting the current status again is a no-op (no write, no history
+// entry) unless force is set, which rewrites it to touch updated_at and records the re-set.
+func (s *OrderService) UpdateOrderStatus(ctx context.Context, id, status string, force bool) error {
 	ctx, span := middleware.StartSpan(ctx, "order.update_status", trace.WithAttributes(
 		attribute.String("layer", "logic"),
 		attribute.String("order.id", id),
 		attribute.String("status", status),
+		attribute.Bool("status.force", force),
 	))
 	defer span.End()
 
-	// Call repository
-	err := s.orderRepo.UpdateStatus(ctx, id, status)
+	newStatus, err := domain.ParseOrderStatus(status)
 	if err != nil {
-		if errors.Is(err, domain.ErrNotFound) {
-			return ErrOrderNotFound
+		span.SetAttributes(attribute.Bool("status.updated", false))
+		return fmt.Errorf("update order %q status: %w", id, ErrInvalidOrderState)
+	}
+
+	_, changed, err := s.transitionStatus(ctx, id, newStatus, StatusSourceAPI, force)
+	if err != nil {
+		if !errors.Is(err, ErrOrderNotFound) && !errors.Is(err, ErrInvalidOrderState) {
+			span.RecordError(err)
 		}
-		span.RecordError(err)
 		return err
 	}
 
-	span.SetAttributes(attribute.Bool("status.updated", true))
+	span.SetAttributes(attribute.Bool("status.updated", changed))
 	return nil
 }
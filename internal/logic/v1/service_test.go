@@ -2,9 +2,18 @@ package v1
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/duynhne/order-service/internal/core/domain"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 // MockTransaction
@@ -33,18 +42,191 @@ func (m *MockTransactionManager) Begin(ctx context.Context) (domain.Transaction,
 // MockOrderRepository
 type MockOrderRepository struct {
 	createWithTxFunc func(ctx context.Context, tx domain.Transaction, order *domain.Order) error
+	findStatusFunc   func(ctx context.Context, id string) (domain.OrderStatus, error)
+	updatedStatuses  []domain.OrderStatus
+	history          []domain.StatusChange
+	updatedSince     []domain.Order
+	userOrders       []domain.Order
+	includeDrafts    []bool   // includeDrafts of every FindByUserID/CountByUserID call
+	draftIDs         []string // returned by FindDraftsCreatedBefore
+	itemsByOrder     map[string][]domain.OrderItem
+	revisionBumps    int
+	lockedIDs        []string // order IDs passed to LockOrderWithTx
+	lockErr          error
+	itemBatchCalls   int
+	internalNote     string
+	findByIDCalls    int
+	cancelledItems   []string
+	totals           []float64 // subtotal, shipping, tax, discount, total, total weight of the last UpdateTotalsWithTx
+	createdBetween   []domain.Order
+	exportCursors    []domain.OrderCursor
+	shippingAddress  *domain.ShippingAddress
+	ownerID          string                    // UserID of every order returned by FindByID
+	externalRefs     map[string]*domain.Order  // keyed by userID + "/" + ref
+	failedCartClears []string                  // "userID/orderID: reason" per AddFailedCartClear
+	statsSince       []time.Time               // since of every CountCreatedSince/SumRevenueSince call
+	purgedIDs        []string                  // returned by PurgeWithTx
+	purgeBefore      []time.Time               // before of every PurgeWithTx call
+	orphanedItems    []domain.OrphanedItem     // every orphan; FindOrphanedItems returns a page of it
+	promotions       []domain.AppliedPromotion // returned by FindByID, replaced by ReplacePromotionsWithTx
 }
 
 func (m *MockOrderRepository) FindByID(ctx context.Context, id string) (*domain.Order, error) {
-	return nil, nil
+	m.findByIDCalls++
+	if slices.Contains(m.purgedIDs, id) {
+		return nil, domain.ErrGone
+	}
+	return &domain.Order{ID: id, UserID: m.ownerID, Promotions: m.promotions}, nil
+}
+func (m *MockOrderRepository) FindItemsByOrderID(ctx context.Context, orderID string) ([]domain.OrderItem, error) {
+	return m.itemsByOrder[orderID], nil
+}
+func (m *MockOrderRepository) FindByOrderNumber(ctx context.Context, number string) (*domain.Order, error) {
+	if number != "ORD-2026-000123" {
+		return nil, domain.ErrNotFound
+	}
+	return &domain.Order{ID: "123", OrderNumber: number, UserID: m.ownerID}, nil
+}
+func (m *MockOrderRepository) FindByExternalRef(ctx context.Context, userID, ref string) (*domain.Order, error) {
+	if order, ok := m.externalRefs[userID+"/"+ref]; ok {
+		return order, nil
+	}
+	return nil, domain.ErrNotFound
+}
+func (m *MockOrderRepository) FindByExternalRefs(ctx context.Context, userID string, refs []string) ([]domain.Order, error) {
+	var orders []domain.Order
+	for _, ref := range refs {
+		if order, ok := m.externalRefs[userID+"/"+ref]; ok {
+			orders = append(orders, *order)
+		}
+	}
+	return orders, nil
+}
+func (m *MockOrderRepository) PurgeWithTx(ctx context.Context, tx domain.Transaction, status domain.OrderStatus, before time.Time, limit int) ([]string, error) {
+	m.purgeBefore = append(m.purgeBefore, before)
+	return m.purgedIDs, nil
+}
+func (m *MockOrderRepository) AddFailedCartClear(ctx context.Context, userID, orderID, reason string) error {
+	m.failedCartClears = append(m.failedCartClears, userID+"/"+orderID+": "+reason)
+	return nil
 }
-func (m *MockOrderRepository) FindByUserID(ctx context.Context, userID string) ([]domain.Order, error) {
-	return nil, nil
+func (m *MockOrderRepository) CountCreatedSince(ctx context.Context, since time.Time) (int, error) {
+	m.statsSince = append(m.statsSince, since)
+	return 3, nil
+}
+func (m *MockOrderRepository) SumRevenueSince(ctx context.Context, since time.Time) (float64, error) {
+	m.statsSince = append(m.statsSince, since)
+	return 125.5, nil
+}
+func (m *MockOrderRepository) FindByUserID(ctx context.Context, userID string, page domain.Page, includeDrafts bool) ([]domain.Order, error) {
+	m.includeDrafts = append(m.includeDrafts, includeDrafts)
+	return m.userOrders, nil
+}
+func (m *MockOrderRepository) FindItemsByOrderIDs(ctx context.Context, orderIDs []string) (map[string][]domain.OrderItem, error) {
+	m.itemBatchCalls++
+	return m.itemsByOrder, nil
+}
+func (m *MockOrderRepository) CountByUserID(ctx context.Context, userID string, includeDrafts bool) (int, error) {
+	m.includeDrafts = append(m.includeDrafts, includeDrafts)
+	return 0, nil
+}
+func (m *MockOrderRepository) FindDraftsCreatedBefore(ctx context.Context, before time.Time, limit int) ([]string, error) {
+	return m.draftIDs, nil
 }
 func (m *MockOrderRepository) Create(ctx context.Context, order *domain.Order) error {
 	return nil
 }
-func (m *MockOrderRepository) UpdateStatus(ctx context.Context, id, status string) error {
+func (m *MockOrderRepository) FindUpdatedSince(ctx context.Context, since time.Time, statuses []domain.OrderStatus, after domain.OrderUpdateCursor, limit int) ([]domain.Order, domain.OrderUpdateCursor, error) {
+	start := 0
+	if after.ID != "" {
+		start = slices.IndexFunc(m.updatedSince, func(o domain.Order) bool { return o.ID == after.ID }) + 1
+	}
+	end := min(start+limit, len(m.updatedSince))
+	page := m.updatedSince[start:end]
+	if len(page) == 0 {
+		return nil, after, nil
+	}
+	return page, domain.OrderUpdateCursor{ID: page[len(page)-1].ID}, nil
+}
+func (m *MockOrderRepository) FindCreatedBetween(ctx context.Context, from, to time.Time, after domain.OrderCursor, limit int) ([]domain.Order, error) {
+	m.exportCursors = append(m.exportCursors, after)
+	start := 0
+	if after.ID != "" {
+		start = slices.IndexFunc(m.createdBetween, func(o domain.Order) bool { return o.ID == after.ID }) + 1
+	}
+	end := min(start+limit, len(m.createdBetween))
+	return slices.Clone(m.createdBetween[start:end]), nil
+}
+func (m *MockOrderRepository) FindStatus(ctx context.Context, id string) (*domain.OrderStatusInfo, error) {
+	status := domain.OrderStatusPending
+	if m.findStatusFunc != nil {
+		var err error
+		if status, err = m.findStatusFunc(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	return &domain.OrderStatusInfo{UserID: m.ownerID, Status: status, UpdatedAt: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)}, nil
+}
+func (m *MockOrderRepository) FindInternalNote(ctx context.Context, id string) (string, error) {
+	return m.internalNote, nil
+}
+func (m *MockOrderRepository) UpdateInternalNote(ctx context.Context, id string, note string) error {
+	m.internalNote = note
+	return nil
+}
+func (m *MockOrderRepository) Search(ctx context.Context, filter domain.OrderSearchFilter, page domain.Page) ([]domain.Order, int, error) {
+	return nil, 0, nil
+}
+func (m *MockOrderRepository) FindOrphanedItems(ctx context.Context, page domain.Page) ([]domain.OrphanedItem, int, error) {
+	start := min(page.Offset, len(m.orphanedItems))
+	end := min(start+page.Limit, len(m.orphanedItems))
+	return m.orphanedItems[start:end:end], len(m.orphanedItems), nil
+}
+func (m *MockOrderRepository) UpdateStatus(ctx context.Context, id string, status domain.OrderStatus) error {
+	return nil
+}
+func (m *MockOrderRepository) LockOrderWithTx(ctx context.Context, tx domain.Transaction, id string) error {
+	m.lockedIDs = append(m.lockedIDs, id)
+	return m.lockErr
+}
+func (m *MockOrderRepository) FindStatusForUpdateWithTx(ctx context.Context, tx domain.Transaction, id string) (domain.OrderStatus, error) {
+	if m.findStatusFunc != nil {
+		return m.findStatusFunc(ctx, id)
+	}
+	return domain.OrderStatusPending, nil
+}
+func (m *MockOrderRepository) UpdateStatusWithTx(ctx context.Context, tx domain.Transaction, id string, status domain.OrderStatus) error {
+	m.updatedStatuses = append(m.updatedStatuses, status)
+	return nil
+}
+func (m *MockOrderRepository) AddStatusHistoryWithTx(ctx context.Context, tx domain.Transaction, change *domain.StatusChange) error {
+	m.history = append(m.history, *change)
+	return nil
+}
+func (m *MockOrderRepository) FindStatusHistory(ctx context.Context, orderID string) ([]domain.StatusChange, error) {
+	return m.history, nil
+}
+func (m *MockOrderRepository) FindItemsWithTx(ctx context.Context, tx domain.Transaction, orderID string) ([]domain.OrderItem, error) {
+	return m.itemsByOrder[orderID], nil
+}
+func (m *MockOrderRepository) IncrementRevisionWithTx(ctx context.Context, tx domain.Transaction, id string) (int, error) {
+	m.revisionBumps++
+	return 1 + m.revisionBumps, nil
+}
+func (m *MockOrderRepository) CancelItemWithTx(ctx context.Context, tx domain.Transaction, orderID, productID string) error {
+	m.cancelledItems = append(m.cancelledItems, productID)
+	return nil
+}
+func (m *MockOrderRepository) UpdateShippingAddressWithTx(ctx context.Context, tx domain.Transaction, id string, address domain.ShippingAddress) error {
+	m.shippingAddress = &address
+	return nil
+}
+func (m *MockOrderRepository) UpdateTotalsWithTx(ctx context.Context, tx domain.Transaction, id string, subtotal, shipping, tax, discount, total, totalWeight float64) error {
+	m.totals = []float64{subtotal, shipping, tax, discount, total, totalWeight}
+	return nil
+}
+func (m *MockOrderRepository) ReplacePromotionsWithTx(ctx context.Context, tx domain.Transaction, id string, promotions []domain.AppliedPromotion) error {
+	m.promotions = promotions
 	return nil
 }
 func (m *MockOrderRepository) CreateWithTx(ctx context.Context, tx domain.Transaction, order *domain.Order) error {
@@ -68,8 +250,8 @@ func TestCreateOrder(t *testing.T) {
 			req: domain.CreateOrderRequest{
 				UserID: "user1",
 				Items: []domain.OrderItem{
-					{ProductID: "p1", Quantity: 2, Price: 10.0}, // 20.0
-					{ProductID: "p2", Quantity: 1, Price: 5.0},  // 5.0
+					{ProductID: "101", Quantity: 2, Price: 10.0}, // 20.0
+					{ProductID: "102", Quantity: 1, Price: 5.0},  // 5.0
 				},
 			},
 			wantSubtotal: 25.0,
@@ -111,3 +293,760 @@ func TestCreateOrder(t *testing.T) {
 		})
 	}
 }
+
+func TestMarkOrderPaid(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name            string
+		current         domain.OrderStatus
+		findErr         error
+		wantAlreadyPaid bool
+		wantErr         error
+		wantUpdated     bool
+	}{
+		{name: "Pending becomes paid", current: domain.OrderStatusPending, wantUpdated: true},
+		{name: "Already paid is a no-op", current: domain.OrderStatusPaid, wantAlreadyPaid: true},
+		{name: "Shipped cannot be paid", current: domain.OrderStatusShipped, wantErr: ErrInvalidOrderState},
+		{name: "Missing order", findErr: domain.ErrNotFound, wantErr: ErrOrderNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &MockOrderRepository{
+				findStatusFunc: func(ctx context.Context, id string) (domain.OrderStatus, error) {
+					return tt.current, tt.findErr
+				},
+			}
+			service := NewOrderService(mockRepo, &MockTransactionManager{})
+
+			alreadyPaid, err := service.MarkOrderPaid(ctx, "1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("MarkOrderPaid() error = %v, want %v", err, tt.wantErr)
+			}
+			if alreadyPaid != tt.wantAlreadyPaid {
+				t.Errorf("MarkOrderPaid() alreadyPaid = %v, want %v", alreadyPaid, tt.wantAlreadyPaid)
+			}
+			if got := len(mockRepo.updatedStatuses) == 1; got != tt.wantUpdated {
+				t.Errorf("MarkOrderPaid() updated = %v, want %v", got, tt.wantUpdated)
+			}
+			if tt.wantUpdated && (len(mockRepo.history) != 1 || mockRepo.history[0].ToStatus != domain.OrderStatusPaid) {
+				t.Errorf("MarkOrderPaid() history = %+v, want one pending->paid entry", mockRepo.history)
+			}
+		})
+	}
+}
+
+func TestListOrdersIncludeItems(t *testing.T) {
+	ctx := context.Background()
+
+	for _, includeItems := range []bool{false, true} {
+		mockRepo := &MockOrderRepository{
+			userOrders: []domain.Order{{ID: "1"}, {ID: "2"}},
+			itemsByOrder: map[string][]domain.OrderItem{
+				"1": {{ProductID: "101", Quantity: 1}},
+				"2": {{ProductID: "102", Quantity: 2}, {ProductID: "103", Quantity: 1}},
+			},
+		}
+		service := NewOrderService(mockRepo, &MockTransactionManager{})
+
+		orders, _, err := service.ListOrders(ctx, "user1", domain.Page{Limit: 20}, ListOptions{IncludeItems: includeItems})
+		if err != nil {
+			t.Fatalf("ListOrders(includeItems=%v) error = %v", includeItems, err)
+		}
+
+		wantCalls, wantItems := 0, 0
+		if includeItems {
+			wantCalls, wantItems = 1, 2
+		}
+		if mockRepo.itemBatchCalls != wantCalls {
+			t.Errorf("ListOrders(includeItems=%v) item queries = %d, want %d", includeItems, mockRepo.itemBatchCalls, wantCalls)
+		}
+		if got := len(orders[1].Items); got != wantItems {
+			t.Errorf("ListOrders(includeItems=%v) order 2 items = %d, want %d", includeItems, got, wantItems)
+		}
+	}
+}
+
+func TestListOrdersEmptyIsNonNil(t *testing.T) {
+	ctx := context.Background()
+	service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{})
+
+	orders, _, err := service.ListOrders(ctx, "user1", domain.Page{Limit: 20}, ListOptions{})
+	if err != nil {
+		t.Fatalf("ListOrders() error = %v", err)
+	}
+	searched, _, err := service.SearchOrders(ctx, domain.OrderSearchFilter{UserID: "user1"}, domain.Page{Limit: 20})
+	if err != nil {
+		t.Fatalf("SearchOrders() error = %v", err)
+	}
+
+	for name, got := range map[string][]domain.Order{"ListOrders": orders, "SearchOrders": searched} {
+		body, err := json.Marshal(got)
+		if err != nil {
+			t.Fatalf("%s: marshal error = %v", name, err)
+		}
+		if string(body) != "[]" {
+			t.Errorf("%s: JSON = %s, want []", name, body)
+		}
+	}
+}
+
+func TestSetInternalNote(t *testing.T) {
+	tests := []struct {
+		name     string
+		note     string
+		wantErr  error
+		wantNote string
+	}{
+		{name: "trimmed", note: "  call customer  ", wantNote: "call customer"},
+		{name: "empty clears", note: "", wantNote: ""},
+		{name: "max length", note: strings.Repeat("é", MaxInternalNoteLength), wantNote: strings.Repeat("é", MaxInternalNoteLength)},
+		{name: "too long", note: strings.Repeat("a", MaxInternalNoteLength+1), wantErr: ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockOrderRepository{internalNote: "previous"}
+			service := NewOrderService(repo, &MockTransactionManager{})
+
+			got, err := service.SetInternalNote(context.Background(), "1", tt.note)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("SetInternalNote() error = %v, want %v", err, tt.wantErr)
+				}
+				if repo.internalNote != "previous" {
+					t.Errorf("note persisted despite error: %q", repo.internalNote)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SetInternalNote() error = %v", err)
+			}
+			if got.Note != tt.wantNote || repo.internalNote != tt.wantNote {
+				t.Errorf("note = %q (stored %q), want %q", got.Note, repo.internalNote, tt.wantNote)
+			}
+		})
+	}
+}
+
+func TestAdminOperationsRequireAdminRole(t *testing.T) {
+	customer := domain.WithAuthContext(context.Background(), domain.AuthContext{UserID: "7"})
+	admin := domain.WithAuthContext(context.Background(), domain.AuthContext{UserID: "1", Roles: []string{domain.RoleAdmin}})
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		wantErr error
+	}{
+		{name: "Customer", ctx: customer, wantErr: ErrUnauthorized},
+		{name: "Admin", ctx: admin},
+		{name: "No principal (internal caller)", ctx: context.Background()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockOrderRepository{internalNote: "previous", purgedIDs: []string{"3"}}
+			service := NewOrderService(repo, &MockTransactionManager{})
+
+			if _, err := service.SetInternalNote(tt.ctx, "1", "call customer"); !errors.Is(err, tt.wantErr) {
+				t.Errorf("SetInternalNote() error = %v, want %v", err, tt.wantErr)
+			}
+			if _, err := service.GetInternalNote(tt.ctx, "1"); !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetInternalNote() error = %v, want %v", err, tt.wantErr)
+			}
+			if _, err := service.PurgeOrders(tt.ctx, domain.OrderStatusCancelled, MinPurgeAge); !errors.Is(err, tt.wantErr) {
+				t.Errorf("PurgeOrders() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil && (repo.internalNote != "previous" || len(repo.purgeBefore) != 0) {
+				t.Errorf("admin operation ran for a non-admin principal")
+			}
+		})
+	}
+}
+
+func TestStatusHistoryRecordsActor(t *testing.T) {
+	repo := &MockOrderRepository{
+		findStatusFunc: func(ctx context.Context, id string) (domain.OrderStatus, error) {
+			return domain.OrderStatusPaid, nil
+		},
+	}
+	service := NewOrderService(repo, &MockTransactionManager{})
+
+	ctx := domain.WithAuthContext(context.Background(), domain.AuthContext{UserID: "42", Roles: []string{domain.RoleAdmin}})
+	if err := service.UpdateOrderStatus(ctx, "1", "shipped", false); err != nil {
+		t.Fatalf("UpdateOrderStatus() error = %v", err)
+	}
+	if len(repo.history) != 1 || repo.history[0].ChangedBy != "42" {
+		t.Errorf("history = %+v, want one entry changed by 42", repo.history)
+	}
+}
+
+func TestGetOrderRejectsInvalidID(t *testing.T) {
+	invalid := []string{"", "   ", " 1", "abc", "0", "-1", "01", "1.5", "2147483648", "1; DROP TABLE orders"}
+
+	for _, id := range invalid {
+		t.Run(id, func(t *testing.T) {
+			repo := &MockOrderRepository{}
+			service := NewOrderService(repo, &MockTransactionManager{})
+
+			_, err := service.GetOrder(context.Background(), id)
+			if !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("GetOrder(%q) error = %v, want ErrInvalidInput", id, err)
+			}
+			if repo.findByIDCalls != 0 {
+				t.Errorf("GetOrder(%q) queried the repository", id)
+			}
+		})
+	}
+
+	repo := &MockOrderRepository{}
+	service := NewOrderService(repo, &MockTransactionManager{})
+	if _, err := service.GetOrder(context.Background(), "2147483647"); err != nil {
+		t.Errorf("GetOrder(max int32) error = %v", err)
+	}
+}
+
+func TestGetOrderByNumber(t *testing.T) {
+	tests := []struct {
+		name    string
+		number  string
+		userID  string
+		wantErr error
+	}{
+		{name: "own order", number: "ORD-2026-000123", userID: "user1"},
+		{name: "case-insensitive", number: " ord-2026-000123 ", userID: "user1"},
+		{name: "another user's order", number: "ORD-2026-000123", userID: "user2", wantErr: ErrUnauthorized},
+		{name: "unknown number", number: "ORD-2026-000124", userID: "user1", wantErr: ErrOrderNotFound},
+		{name: "malformed", number: "123", userID: "user1", wantErr: ErrInvalidInput},
+		{name: "short sequence", number: "ORD-2026-123", userID: "user1", wantErr: ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewOrderService(&MockOrderRepository{ownerID: "user1"}, &MockTransactionManager{})
+
+			order, err := service.GetOrderByNumber(context.Background(), tt.number, tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetOrderByNumber(%q) error = %v, want %v", tt.number, err, tt.wantErr)
+			}
+			if tt.wantErr == nil && order.ID != "123" {
+				t.Errorf("GetOrderByNumber(%q) = order %s, want 123", tt.number, order.ID)
+			}
+		})
+	}
+}
+
+func TestGetOrderItems(t *testing.T) {
+	repo := &MockOrderRepository{
+		ownerID:      "user1",
+		itemsByOrder: map[string][]domain.OrderItem{"1": {{ProductID: "101", Quantity: 2}, {ProductID: "102", Quantity: 1, Cancelled: true}}},
+	}
+	service := NewOrderService(repo, &MockTransactionManager{})
+	ctx := context.Background()
+
+	items, err := service.GetOrderItems(ctx, "1", "user1")
+	if err != nil {
+		t.Fatalf("GetOrderItems() error = %v", err)
+	}
+	if len(items) != 2 || items[0].ProductID != "101" || !items[1].Cancelled {
+		t.Errorf("GetOrderItems() = %+v, want p1 and cancelled p2", items)
+	}
+	if repo.findByIDCalls != 0 {
+		t.Errorf("GetOrderItems() loaded the full order %d times, want 0", repo.findByIDCalls)
+	}
+
+	if items, err := service.GetOrderItems(ctx, "2", "user1"); err != nil || items == nil || len(items) != 0 {
+		t.Errorf("GetOrderItems(no items) = %v, %v, want empty non-nil slice", items, err)
+	}
+	if _, err := service.GetOrderItems(ctx, "1", "user2"); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("GetOrderItems(other user) error = %v, want ErrUnauthorized", err)
+	}
+	if _, err := service.GetOrderItems(ctx, "abc", "user1"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("GetOrderItems(invalid id) error = %v, want ErrInvalidInput", err)
+	}
+}
+
+func TestGetOrderPurged(t *testing.T) {
+	repo := &MockOrderRepository{ownerID: "user1", purgedIDs: []string{"7"}}
+	service := NewOrderService(repo, &MockTransactionManager{})
+
+	_, err := service.GetUserOrder(context.Background(), "7", "user1")
+	if !errors.Is(err, ErrOrderGone) {
+		t.Errorf("GetUserOrder(purged) error = %v, want ErrOrderGone", err)
+	}
+	if !errors.Is(err, ErrOrderNotFound) {
+		t.Errorf("GetUserOrder(purged) error = %v, want it to wrap ErrOrderNotFound", err)
+	}
+
+	if _, err := service.GetOrder(context.Background(), "8"); err != nil {
+		t.Errorf("GetOrder(not purged) error = %v", err)
+	}
+}
+
+func TestGetOrderStatus(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		id         string
+		userID     string
+		findStatus func(ctx context.Context, id string) (domain.OrderStatus, error)
+		wantStatus domain.OrderStatus
+		wantErr    error
+	}{
+		{
+			name: "Owner", id: "1", userID: "alice",
+			findStatus: func(ctx context.Context, id string) (domain.OrderStatus, error) {
+				return domain.OrderStatusShipped, nil
+			},
+			wantStatus: domain.OrderStatusShipped,
+		},
+		{name: "Other user", id: "1", userID: "bob", wantErr: ErrUnauthorized},
+		{
+			name: "Not found", id: "404", userID: "alice",
+			findStatus: func(ctx context.Context, id string) (domain.OrderStatus, error) { return "", domain.ErrNotFound },
+			wantErr:    ErrOrderNotFound,
+		},
+		{name: "Invalid ID", id: "abc", userID: "alice", wantErr: ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockOrderRepository{ownerID: "alice", findStatusFunc: tt.findStatus}
+			service := NewOrderService(repo, &MockTransactionManager{})
+
+			info, err := service.GetOrderStatus(ctx, tt.id, tt.userID)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("GetOrderStatus() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetOrderStatus() error = %v", err)
+			}
+			if info.Status != tt.wantStatus || info.UpdatedAt.IsZero() {
+				t.Errorf("GetOrderStatus() = %+v, want status %s with updated_at", info, tt.wantStatus)
+			}
+			if repo.findByIDCalls != 0 {
+				t.Errorf("GetOrderStatus() loaded the full order")
+			}
+		})
+	}
+}
+
+func TestGetUserOrderOwnership(t *testing.T) {
+	ctx := context.Background()
+	service := NewOrderService(&MockOrderRepository{ownerID: "alice"}, &MockTransactionManager{})
+
+	order, err := service.GetUserOrder(ctx, "1", "alice")
+	if err != nil || order.UserID != "alice" {
+		t.Fatalf("GetUserOrder(owner) = %+v, %v", order, err)
+	}
+
+	// Another user's order (e.g. GET /orders/1/details by bob) is rejected before it is returned
+	order, err = service.GetUserOrder(ctx, "1", "bob")
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("GetUserOrder(other user) error = %v, want ErrUnauthorized", err)
+	}
+	if order != nil {
+		t.Errorf("GetUserOrder(other user) returned order %+v", order)
+	}
+}
+
+func TestUpdateOrderStatusFollowsTransitionTable(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		current     domain.OrderStatus
+		status      string
+		force       bool
+		wantErr     error
+		wantUpdated bool
+	}{
+		{name: "Paid to shipped", current: domain.OrderStatusPaid, status: "shipped", wantUpdated: true},
+		{name: "Pending to cancelled", current: domain.OrderStatusPending, status: "cancelled", wantUpdated: true},
+		{name: "Same status is a no-op", current: domain.OrderStatusShipped, status: "shipped"},
+		{name: "Same status forced", current: domain.OrderStatusShipped, status: "shipped", force: true, wantUpdated: true},
+		{name: "Force does not bypass the table", current: domain.OrderStatusShipped, status: "paid", force: true, wantErr: ErrInvalidOrderState},
+		{name: "Backwards", current: domain.OrderStatusShipped, status: "paid", wantErr: ErrInvalidOrderState},
+		{name: "Out of terminal state", current: domain.OrderStatusCancelled, status: "pending", wantErr: ErrInvalidOrderState},
+		{name: "Unknown status", current: domain.OrderStatusPaid, status: "lost", wantErr: ErrInvalidOrderState},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &MockOrderRepository{
+				findStatusFunc: func(ctx context.Context, id string) (domain.OrderStatus, error) {
+					return tt.current, nil
+				},
+			}
+			service := NewOrderService(mockRepo, &MockTransactionManager{})
+
+			err := service.UpdateOrderStatus(ctx, "1", tt.status, tt.force)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateOrderStatus() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := len(mockRepo.updatedStatuses) == 1; got != tt.wantUpdated {
+				t.Errorf("UpdateOrderStatus() updated = %v, want %v", got, tt.wantUpdated)
+			}
+			if tt.wantUpdated && (len(mockRepo.history) != 1 || mockRepo.history[0].Source != StatusSourceAPI) {
+				t.Errorf("history = %+v, want one api entry", mockRepo.history)
+			}
+			if !tt.wantUpdated && len(mockRepo.history) != 0 {
+				t.Errorf("history = %+v, want no entry", mockRepo.history)
+			}
+		})
+	}
+}
+
+func TestOrderMutationsTakeOrderLock(t *testing.T) {
+	ctx := context.Background()
+	paid := func(ctx context.Context, id string) (domain.OrderStatus, error) { return domain.OrderStatusPaid, nil }
+
+	repo := &MockOrderRepository{findStatusFunc: paid}
+	service := NewOrderService(repo, &MockTransactionManager{})
+	if err := service.UpdateOrderStatus(ctx, "7", "shipped", false); err != nil {
+		t.Fatalf("UpdateOrderStatus() error = %v", err)
+	}
+	if !slices.Equal(repo.lockedIDs, []string{"7"}) {
+		t.Errorf("locked orders = %v, want [7]", repo.lockedIDs)
+	}
+
+	lockErr := errors.New("lock timeout")
+	repo = &MockOrderRepository{findStatusFunc: paid, lockErr: lockErr}
+	service = NewOrderService(repo, &MockTransactionManager{})
+	if err := service.UpdateOrderStatus(ctx, "7", "shipped", false); !errors.Is(err, lockErr) {
+		t.Errorf("UpdateOrderStatus() with failing lock error = %v, want %v", err, lockErr)
+	}
+	if len(repo.updatedStatuses) != 0 {
+		t.Errorf("UpdateOrderStatus() with failing lock wrote %v", repo.updatedStatuses)
+	}
+}
+
+func TestGetOrderActions(t *testing.T) {
+	ctx := context.Background()
+	service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{})
+
+	// MockOrderRepository.FindByID returns an order with empty UserID and Status
+	if _, err := service.GetOrderActions(ctx, "1", "someone"); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("GetOrderActions() for non-owner error = %v, want ErrUnauthorized", err)
+	}
+
+	for from := range defaultTransitions {
+		for _, to := range defaultTransitions.Next(from) {
+			if orderActions[to] == "" {
+				t.Errorf("transition %q -> %q has no action name", from, to)
+			}
+			if !defaultTransitions.Allows(from, to) {
+				t.Errorf("Allows(%q, %q) = false for a listed transition", from, to)
+			}
+		}
+	}
+	if defaultTransitions.Allows(domain.OrderStatusShipped, domain.OrderStatusCancelled) {
+		t.Error("Allows(shipped, cancelled) = true, want false")
+	}
+}
+
+func TestGetStatusHistory(t *testing.T) {
+	ctx := context.Background()
+	repo := &MockOrderRepository{
+		history: []domain.StatusChange{
+			{OrderID: "1", FromStatus: domain.OrderStatusPending, ToStatus: domain.OrderStatusPaid, Source: "payment_webhook"},
+		},
+	}
+	service := NewOrderService(repo, &MockTransactionManager{})
+
+	// MockOrderRepository.FindByID returns an order with empty UserID
+	if _, _, err := service.GetStatusHistory(ctx, "1", "someone"); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("GetStatusHistory() for non-owner error = %v, want ErrUnauthorized", err)
+	}
+
+	order, history, err := service.GetStatusHistory(ctx, "1", "")
+	if err != nil {
+		t.Fatalf("GetStatusHistory() error = %v", err)
+	}
+	if order.ID != "1" || len(history) != 1 || history[0].ToStatus != domain.OrderStatusPaid {
+		t.Errorf("GetStatusHistory() = %+v, %+v", order, history)
+	}
+}
+
+func TestCancelOrderItem(t *testing.T) {
+	items := []domain.OrderItem{
+		{ProductID: "1", Quantity: 1, Price: 10, Subtotal: 10},
+		{ProductID: "2", Quantity: 2, Price: 20, Subtotal: 40, Weight: 1.5},
+		{ProductID: "3", Quantity: 1, Price: 5, Subtotal: 5, Weight: 4, Cancelled: true},
+	}
+
+	tests := []struct {
+		name          string
+		status        domain.OrderStatus
+		productID     string
+		items         []domain.OrderItem
+		wantErr       error
+		wantTotals    []float64
+		wantCancelled bool
+	}{
+		{
+			name:       "Cancel one of two active items",
+			status:     domain.OrderStatusPaid,
+			productID:  "1",
+			items:      items,
+			wantTotals: []float64{40, DefaultFlatShippingRate, 0, 0, 40 + DefaultFlatShippingRate, 3},
+		},
+		{
+			// 0.10 + 0.20 is 0.30000000000000004 in float64
+			name:      "Remaining totals rounded to cents",
+			status:    domain.OrderStatusPaid,
+			productID: "1",
+			items: []domain.OrderItem{
+				{ProductID: "1", Quantity: 1, Price: 10, Subtotal: 10},
+				{ProductID: "2", Quantity: 1, Price: 0.10, Subtotal: 0.10, Tax: 0.01},
+				{ProductID: "4", Quantity: 1, Price: 0.20, Subtotal: 0.20, Tax: 0.02},
+			},
+			wantTotals: []float64{0.30, DefaultFlatShippingRate, 0.03, 0, 0.33 + DefaultFlatShippingRate, 0},
+		},
+		{
+			name:          "Cancel last active item cancels order",
+			status:        domain.OrderStatusPending,
+			productID:     "2",
+			items:         items[1:],
+			wantTotals:    []float64{0, 0, 0, 0, 0, 0},
+			wantCancelled: true,
+		},
+		{
+			name:      "Already cancelled item",
+			status:    domain.OrderStatusPending,
+			productID: "3",
+			items:     items,
+			wantErr:   ErrItemNotFound,
+		},
+		{
+			name:      "Unknown product",
+			status:    domain.OrderStatusPending,
+			productID: "9",
+			items:     items,
+			wantErr:   ErrItemNotFound,
+		},
+		{
+			name:      "Shipped order",
+			status:    domain.OrderStatusShipped,
+			productID: "1",
+			items:     items,
+			wantErr:   ErrInvalidOrderState,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockOrderRepository{
+				findStatusFunc: func(ctx context.Context, id string) (domain.OrderStatus, error) {
+					return tt.status, nil
+				},
+				itemsByOrder: map[string][]domain.OrderItem{"1": tt.items},
+			}
+			service := NewOrderService(repo, &MockTransactionManager{})
+
+			// MockOrderRepository.FindByID returns an order with empty UserID
+			_, err := service.CancelOrderItem(context.Background(), "1", tt.productID, "")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CancelOrderItem() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if len(repo.cancelledItems) != 0 || repo.totals != nil || repo.revisionBumps != 0 {
+					t.Errorf("CancelOrderItem() wrote changes on error: %v, %v, %d revision bumps", repo.cancelledItems, repo.totals, repo.revisionBumps)
+				}
+				return
+			}
+			if repo.revisionBumps != 1 {
+				t.Errorf("revision bumps = %d, want 1", repo.revisionBumps)
+			}
+
+			if !slices.Equal(repo.totals, tt.wantTotals) {
+				t.Errorf("totals = %v, want %v", repo.totals, tt.wantTotals)
+			}
+			if cancelled := len(repo.history) == 1 && repo.history[0].ToStatus == domain.OrderStatusCancelled; cancelled != tt.wantCancelled {
+				t.Errorf("order cancelled = %v, want %v (history %+v)", cancelled, tt.wantCancelled, repo.history)
+			}
+		})
+	}
+
+	service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{})
+	if _, err := service.CancelOrderItem(context.Background(), "1", "1", "someone"); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("CancelOrderItem() for non-owner error = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestCancelOrderItemReevaluatesPromotions(t *testing.T) {
+	// Buy 3 units, get 10% off
+	engine := RulePromotionEngine{Rules: []PromotionRule{
+		QuantityPercentOff{ID: "bulk", MinUnits: 3, PercentOff: 0.10},
+	}}
+	placed := []domain.AppliedPromotion{{ID: "bulk", Amount: 7}}
+
+	tests := []struct {
+		name           string
+		items          []domain.OrderItem
+		wantTotals     []float64
+		wantPromotions []domain.AppliedPromotion
+	}{
+		{
+			name: "Falls below the threshold",
+			items: []domain.OrderItem{
+				{ProductID: "1", Quantity: 1, Price: 30, Subtotal: 30},
+				{ProductID: "2", Quantity: 2, Price: 20, Subtotal: 40},
+			},
+			wantTotals: []float64{40, DefaultFlatShippingRate, 0, 0, 40 + DefaultFlatShippingRate, 0},
+		},
+		{
+			name: "Still qualifies",
+			items: []domain.OrderItem{
+				{ProductID: "1", Quantity: 1, Price: 30, Subtotal: 30},
+				{ProductID: "2", Quantity: 3, Price: 20, Subtotal: 60},
+			},
+			wantTotals:     []float64{60, DefaultFlatShippingRate, 0, 6, 54 + DefaultFlatShippingRate, 0},
+			wantPromotions: []domain.AppliedPromotion{{ID: "bulk", Amount: 6}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockOrderRepository{
+				findStatusFunc: func(ctx context.Context, id string) (domain.OrderStatus, error) {
+					return domain.OrderStatusPaid, nil
+				},
+				itemsByOrder: map[string][]domain.OrderItem{"1": tt.items},
+				promotions:   placed,
+			}
+			service := NewOrderService(repo, &MockTransactionManager{}, WithPromotionEngine(engine))
+
+			if _, err := service.CancelOrderItem(context.Background(), "1", "1", ""); err != nil {
+				t.Fatalf("CancelOrderItem() error = %v", err)
+			}
+			if !slices.Equal(repo.totals, tt.wantTotals) {
+				t.Errorf("totals = %v, want %v", repo.totals, tt.wantTotals)
+			}
+			if !slices.Equal(repo.promotions, tt.wantPromotions) {
+				t.Errorf("promotions = %+v, want %+v", repo.promotions, tt.wantPromotions)
+			}
+		})
+	}
+}
+
+func TestUpdateShippingAddress(t *testing.T) {
+	valid := domain.ShippingAddress{
+		Name: " Jane Doe ", Line1: "1 Main St", City: "Springfield", PostalCode: "12345", Country: "us",
+	}
+
+	tests := []struct {
+		name    string
+		status  domain.OrderStatus
+		address domain.ShippingAddress
+		wantErr error
+	}{
+		{name: "Pending", status: domain.OrderStatusPending, address: valid},
+		{name: "Paid", status: domain.OrderStatusPaid, address: valid},
+		{name: "Processing", status: domain.OrderStatusProcessing, address: valid, wantErr: ErrInvalidOrderState},
+		{name: "Shipped", status: domain.OrderStatusShipped, address: valid, wantErr: ErrInvalidOrderState},
+		{
+			name:    "Missing city",
+			status:  domain.OrderStatusPending,
+			address: domain.ShippingAddress{Name: "Jane", Line1: "1 Main St", PostalCode: "12345", Country: "US"},
+			wantErr: ErrInvalidAddress,
+		},
+		{
+			name:    "Bad country",
+			status:  domain.OrderStatusPending,
+			address: domain.ShippingAddress{Name: "Jane", Line1: "1 Main St", City: "X", PostalCode: "1", Country: "USA"},
+			wantErr: ErrInvalidAddress,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockOrderRepository{
+				findStatusFunc: func(ctx context.Context, id string) (domain.OrderStatus, error) {
+					return tt.status, nil
+				},
+			}
+			service := NewOrderService(repo, &MockTransactionManager{})
+
+			// MockOrderRepository.FindByID returns an order with empty UserID
+			_, err := service.UpdateShippingAddress(context.Background(), "1", "", tt.address)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateShippingAddress() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if repo.shippingAddress != nil {
+					t.Errorf("address written on error: %+v", repo.shippingAddress)
+				}
+				return
+			}
+			if repo.shippingAddress == nil || repo.shippingAddress.Name != "Jane Doe" || repo.shippingAddress.Country != "US" {
+				t.Errorf("stored address = %+v, want normalized name and country", repo.shippingAddress)
+			}
+		})
+	}
+}
+
+func TestRecordFailedCartClear(t *testing.T) {
+	repo := &MockOrderRepository{}
+	service := NewOrderService(repo, &MockTransactionManager{})
+
+	err := service.RecordFailedCartClear(context.Background(), "42", "7", errors.New("cart service returned status 503"))
+	if err != nil {
+		t.Fatalf("RecordFailedCartClear() error = %v", err)
+	}
+	want := []string{"42/7: cart service returned status 503"}
+	if !slices.Equal(repo.failedCartClears, want) {
+		t.Errorf("failed cart clears = %v, want %v", repo.failedCartClears, want)
+	}
+}
+
+func TestCreateOrderSpanAttributes(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{})
+	_, err := service.CreateOrder(context.Background(), domain.CreateOrderRequest{
+		UserID: "user1",
+		Items: []domain.OrderItem{
+			{ProductID: "1", ProductName: "Gift for Jane Doe", Quantity: 2, Price: 10},
+			{ProductID: "2", Quantity: 1, Price: 5},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+
+	var attrs map[attribute.Key]attribute.Value
+	for _, span := range recorder.Ended() {
+		if span.Name() == "order.create" {
+			attrs = make(map[attribute.Key]attribute.Value)
+			for _, kv := range span.Attributes() {
+				attrs[kv.Key] = kv.Value
+			}
+		}
+	}
+	if attrs == nil {
+		t.Fatal("no order.create span recorded")
+	}
+	if got := attrs["order.item_count"].AsInt64(); got != 2 {
+		t.Errorf("order.item_count = %d, want 2", got)
+	}
+	if got := attrs["order.subtotal"].AsFloat64(); got != 25 {
+		t.Errorf("order.subtotal = %v, want 25", got)
+	}
+	if got := attrs["order.total"].AsFloat64(); got != 25+DefaultFlatShippingRate {
+		t.Errorf("order.total = %v, want %v", got, 25+DefaultFlatShippingRate)
+	}
+	for key, value := range attrs {
+		if strings.Contains(value.Emit(), "Jane") {
+			t.Errorf("span attribute %s leaks a product name: %q", key, value.Emit())
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"github.com/duynhne/order-service/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// EstimateShipping returns the shipping charge and delivery estimate CreateOrder would give the
+// cart in req if it were ordered now, without creating an order. The charge is QuoteOrder's
+// (shipping can depend on the subtotal), so the estimate matches the charge. An optional shipping
+// address is validated like on CreateOrder; the calculators do not price by destination.
+// Returns ErrInvalidOrder like CreateOrder.
+func (s *OrderService) EstimateShipping(ctx context.Context, req domain.CreateOrderRequest) (*domain.ShippingEstimate, error) {
+	ctx, span := middleware.StartSpan(ctx, "order.estimate_shipping", trace.WithAttributes(
+		attribute.String("layer", "logic"),
+		attribute.String("user.id", req.UserID),
+	))
+	defer span.End()
+
+	// Checked before QuoteOrder so a bad address fails without product lookups
+	if req.ShippingAddress != nil {
+		if _, err := normalizeShippingAddress(*req.ShippingAddress); err != nil {
+			return nil, fmt.Errorf("%v: %w", err, ErrInvalidOrder)
+		}
+	}
+
+	quote, err := s.QuoteOrder(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ShippingEstimate{
+		Priority:          quote.Priority,
+		Shipping:          quote.Shipping,
+		EstimatedDelivery: s.estimateDelivery(time.Now(), quote.Priority),
+	}, nil
+}
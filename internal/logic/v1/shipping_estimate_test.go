@@ -0,0 +1,86 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+)
+
+func TestEstimateShippingMatchesCreateOrder(t *testing.T) {
+	ctx := context.Background()
+	service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{},
+		WithShippingCalculator(ExpressShipping{Base: PerItemShipping{BaseFee: 5, PerUnitRate: 0.5}, Surcharge: 10}),
+		WithDeliveryLeadTime(5, -3),
+	)
+
+	for _, priority := range []string{"", "express"} {
+		req := domain.CreateOrderRequest{
+			UserID:   "user1",
+			Priority: priority,
+			Items:    []domain.OrderItem{{ProductID: "101", Quantity: 3, Price: 20.0}},
+		}
+		estimate, err := service.EstimateShipping(ctx, req)
+		if err != nil {
+			t.Fatalf("EstimateShipping(%q) error = %v", priority, err)
+		}
+		order, err := service.CreateOrder(ctx, req)
+		if err != nil {
+			t.Fatalf("CreateOrder(%q) error = %v", priority, err)
+		}
+
+		if estimate.Shipping != order.Shipping || estimate.Priority != order.Priority {
+			t.Errorf("estimate = %+v, order shipping %v priority %q, want equal", estimate, order.Shipping, order.Priority)
+		}
+		if order.EstimatedDelivery == nil || !estimate.EstimatedDelivery.Equal(*order.EstimatedDelivery) {
+			t.Errorf("estimated delivery = %v, order's = %v, want equal", estimate.EstimatedDelivery, order.EstimatedDelivery)
+		}
+	}
+}
+
+func TestEstimateShippingMatchesQuoteWithCatalogPrices(t *testing.T) {
+	ctx := context.Background()
+	// The catalog price (30 x 2) crosses the free-shipping threshold the client price (10 x 2) does not
+	catalog := &mockPriceCatalog{prices: map[string]float64{"101": 30}}
+	service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{},
+		WithShippingCalculator(FlatRateShipping{Rate: 5, FreeShippingThreshold: 50}),
+		WithPricePolicy(PriceTrustCatalog, catalog),
+	)
+	req := domain.CreateOrderRequest{UserID: "user1", Items: []domain.OrderItem{{ProductID: "101", Quantity: 2, Price: 10}}}
+
+	quote, err := service.QuoteOrder(ctx, req)
+	if err != nil {
+		t.Fatalf("QuoteOrder() error = %v", err)
+	}
+	estimate, err := service.EstimateShipping(ctx, req)
+	if err != nil {
+		t.Fatalf("EstimateShipping() error = %v", err)
+	}
+	if estimate.Shipping != quote.Shipping || estimate.Shipping != 0 {
+		t.Errorf("estimate shipping = %v, quote = %v, want both 0 (catalog subtotal above the threshold)", estimate.Shipping, quote.Shipping)
+	}
+}
+
+func TestEstimateShippingInvalid(t *testing.T) {
+	service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{})
+	items := []domain.OrderItem{{ProductID: "101", Quantity: 1, Price: 10}}
+
+	tests := []struct {
+		name string
+		req  domain.CreateOrderRequest
+	}{
+		{name: "No items", req: domain.CreateOrderRequest{}},
+		{name: "Invalid item", req: domain.CreateOrderRequest{Items: []domain.OrderItem{{ProductID: "<p1>", Quantity: 1, Price: 10}}}},
+		{name: "Unknown priority", req: domain.CreateOrderRequest{Items: items, Priority: "overnight"}},
+		{name: "Invalid address", req: domain.CreateOrderRequest{Items: items, ShippingAddress: &domain.ShippingAddress{Country: "Vietnam"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.EstimateShipping(context.Background(), tt.req); !errors.Is(err, ErrInvalidOrder) {
+				t.Errorf("EstimateShipping() error = %v, want ErrInvalidOrder", err)
+			}
+		})
+	}
+}
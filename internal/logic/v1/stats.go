@@ -0,0 +1,44 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"github.com/duynhne/order-service/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// MaxStatsWindow caps how far back order stats reach, keeping the created_at range scans small
+const MaxStatsWindow = 90 * 24 * time.Hour
+
+// GetOrderStats counts non-cancelled orders created at or after since and sums their totals
+// (admin only; role is enforced by the caller). Returns ErrInvalidInput if since is in the
+// future or more than MaxStatsWindow ago.
+func (s *OrderService) GetOrderStats(ctx context.Context, since time.Time) (*domain.OrderStats, error) {
+	ctx, span := middleware.StartSpan(ctx, "order.stats", trace.WithAttributes(
+		attribute.String("layer", "logic"),
+		attribute.String("stats.since", since.UTC().Format(time.RFC3339)),
+	))
+	defer span.End()
+
+	if window := time.Since(since); window < 0 || window > MaxStatsWindow {
+		return nil, fmt.Errorf("stats since %s (max %s ago): %w", since, MaxStatsWindow, ErrInvalidInput)
+	}
+
+	count, err := s.orderRepo.CountCreatedSince(ctx, since)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	revenue, err := s.orderRepo.SumRevenueSince(ctx, since)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("stats.order_count", count))
+	return &domain.OrderStats{Since: since, OrderCount: count, Revenue: revenue}, nil
+}
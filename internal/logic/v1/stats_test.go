@@ -0,0 +1,53 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGetOrderStats(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		since   time.Time
+		wantErr error
+	}{
+		{name: "Last 24h", since: now.Add(-24 * time.Hour)},
+		{name: "Max window", since: now.Add(-MaxStatsWindow + time.Minute)},
+		{name: "Future", since: now.Add(time.Hour), wantErr: ErrInvalidInput},
+		{name: "Beyond max window", since: now.Add(-MaxStatsWindow - time.Hour), wantErr: ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockOrderRepository{}
+			service := NewOrderService(repo, &MockTransactionManager{})
+
+			stats, err := service.GetOrderStats(ctx, tt.since)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("GetOrderStats() error = %v, want %v", err, tt.wantErr)
+				}
+				if len(repo.statsSince) != 0 {
+					t.Errorf("GetOrderStats() queried the repository for an invalid window")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetOrderStats() error = %v", err)
+			}
+			if stats.OrderCount != 3 || stats.Revenue != 125.5 || !stats.Since.Equal(tt.since) {
+				t.Errorf("GetOrderStats() = %+v", stats)
+			}
+			for _, since := range repo.statsSince {
+				if !since.Equal(tt.since) {
+					t.Errorf("repository queried since %v, want %v", since, tt.since)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+)
+
+// WithStockCheck makes CreateOrder ask inventory whether every item is in stock before the order
+// is written, failing with an *InsufficientStockError that lists all short items. A nil inventory
+// (the default) skips the check. Nothing is reserved: stock can still run out after the check.
+func WithStockCheck(inventory domain.InventoryChecker) Option {
+	return func(s *OrderService) {
+		s.inventory = inventory
+	}
+}
+
+// InsufficientStockError reports every item of an order the inventory cannot fill
+type InsufficientStockError struct {
+	Shortages []domain.StockShortage
+}
+
+func (e *InsufficientStockError) Error() string {
+	short := make([]string, len(e.Shortages))
+	for i, s := range e.Shortages {
+		item := s.ProductID
+		if s.SKU != "" {
+			item += "/" + s.SKU
+		}
+		short[i] = fmt.Sprintf("%s: %d requested, %d available", item, s.Requested, s.Available)
+	}
+	return fmt.Sprintf("%d items short (%s): %v", len(e.Shortages), strings.Join(short, "; "), ErrInsufficientStock)
+}
+
+func (e *InsufficientStockError) Unwrap() error {
+	return ErrInsufficientStock
+}
+
+// checkStock asks the inventory for the priced items of a new order; nil when no inventory is
+// configured. Lines of the same product and SKU are checked with their combined quantity, since
+// unmerged duplicates draw on the same stock.
+func (s *OrderService) checkStock(ctx context.Context, items []domain.OrderItem) error {
+	if s.inventory == nil {
+		return nil
+	}
+
+	wanted := make([]domain.OrderItem, 0, len(items))
+	index := make(map[itemKey]int, len(items))
+	for _, item := range items {
+		key := itemKey{productID: item.ProductID, sku: item.SKU}
+		if i, ok := index[key]; ok {
+			wanted[i].Quantity += item.Quantity
+			continue
+		}
+		index[key] = len(wanted)
+		wanted = append(wanted, domain.OrderItem{ProductID: item.ProductID, SKU: item.SKU, Quantity: item.Quantity})
+	}
+
+	shortages, err := s.inventory.CheckAvailability(ctx, wanted)
+	if err != nil {
+		return fmt.Errorf("check stock: %w", err)
+	}
+	if len(shortages) > 0 {
+		return &InsufficientStockError{Shortages: shortages}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+)
+
+// fakeInventory has stock per product ID and records the items it was asked about
+type fakeInventory struct {
+	stock   map[string]int
+	err     error
+	checked []domain.OrderItem
+}
+
+func (f *fakeInventory) CheckAvailability(ctx context.Context, items []domain.OrderItem) ([]domain.StockShortage, error) {
+	f.checked = items
+	if f.err != nil {
+		return nil, f.err
+	}
+	var short []domain.StockShortage
+	for _, item := range items {
+		if available := f.stock[item.ProductID]; item.Quantity > available {
+			short = append(short, domain.StockShortage{
+				ProductID: item.ProductID, SKU: item.SKU, Requested: item.Quantity, Available: available,
+			})
+		}
+	}
+	return short, nil
+}
+
+func TestCreateOrderStockCheck(t *testing.T) {
+	req := domain.CreateOrderRequest{
+		UserID: "user1",
+		Items: []domain.OrderItem{
+			{ProductID: "1", ProductName: "Widget", Quantity: 5, Price: 10},
+			{ProductID: "2", ProductName: "Gadget", Quantity: 1, Price: 10},
+			{ProductID: "3", ProductName: "Gizmo", Quantity: 2, Price: 10},
+			{ProductID: "3", ProductName: "Gizmo", Quantity: 2, Price: 10},
+		},
+	}
+
+	t.Run("Every short item is reported", func(t *testing.T) {
+		created := false
+		repo := &MockOrderRepository{
+			createWithTxFunc: func(ctx context.Context, tx domain.Transaction, order *domain.Order) error {
+				created = true
+				return nil
+			},
+		}
+		inventory := &fakeInventory{stock: map[string]int{"1": 2, "2": 10, "3": 3}}
+		service := NewOrderService(repo, &MockTransactionManager{}, WithStockCheck(inventory))
+
+		_, err := service.CreateOrder(context.Background(), req)
+		var stockErr *InsufficientStockError
+		if !errors.As(err, &stockErr) || !errors.Is(err, ErrInsufficientStock) {
+			t.Fatalf("CreateOrder() error = %v, want *InsufficientStockError", err)
+		}
+		want := []domain.StockShortage{
+			{ProductID: "1", Requested: 5, Available: 2},
+			{ProductID: "3", Requested: 4, Available: 3}, // both lines of product 3 together
+		}
+		if !slices.Equal(stockErr.Shortages, want) {
+			t.Errorf("shortages = %+v, want %+v", stockErr.Shortages, want)
+		}
+		if len(inventory.checked) != 3 {
+			t.Errorf("checked %d lines, want 3 (one per product)", len(inventory.checked))
+		}
+		if created {
+			t.Error("CreateOrder() wrote the order despite short stock")
+		}
+	})
+
+	t.Run("In stock", func(t *testing.T) {
+		inventory := &fakeInventory{stock: map[string]int{"1": 5, "2": 1, "3": 4}}
+		service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{}, WithStockCheck(inventory))
+		if _, err := service.CreateOrder(context.Background(), req); err != nil {
+			t.Fatalf("CreateOrder() error = %v", err)
+		}
+	})
+
+	t.Run("Inventory unavailable", func(t *testing.T) {
+		down := errors.New("connection refused")
+		service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{}, WithStockCheck(&fakeInventory{err: down}))
+		_, err := service.CreateOrder(context.Background(), req)
+		if !errors.Is(err, down) || errors.Is(err, ErrInsufficientStock) {
+			t.Errorf("CreateOrder() error = %v, want the inventory error", err)
+		}
+	})
+
+	t.Run("Check disabled", func(t *testing.T) {
+		service := NewOrderService(&MockOrderRepository{}, &MockTransactionManager{}, WithStockCheck(nil))
+		if _, err := service.CreateOrder(context.Background(), req); err != nil {
+			t.Fatalf("CreateOrder() without inventory error = %v", err)
+		}
+	})
+}
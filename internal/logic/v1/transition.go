@@ -0,0 +1,95 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+)
+
+// transitionStatus moves order id to status `to` inside a transaction (inTx) and records history.
+//
+// The transaction first takes the per-order lock (LockOrderWithTx), so concurrent mutations of
+// the same order run one after the other without locking anything else. The current status is
+// then read with a row lock and the move is refused with ErrInvalidOrderState
+// unless the service's Transitions allow it. When the order is already in `to`, nothing is written and
+// changed is false, unless force is set: then the status is rewritten (touching updated_at) and a
+// from == to history entry is recorded. Returns the status observed before the transition.
+func (s *OrderService) transitionStatus(
+	ctx context.Context,
+	id string,
+	to domain.OrderStatus,
+	source string,
+	force bool,
+) (from domain.OrderStatus, changed bool, err error) {
+	err = s.inTx(ctx, func(tx domain.Transaction) error {
+		if err := s.orderRepo.LockOrderWithTx(ctx, tx, id); err != nil {
+			return err
+		}
+		var err error
+		from, err = s.orderRepo.FindStatusForUpdateWithTx(ctx, tx, id)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return fmt.Errorf("transition order %q to %q: %w", id, to, ErrOrderNotFound)
+			}
+			return err
+		}
+
+		changed = from != to || force
+		switch {
+		case !changed:
+			return nil
+		case from == to:
+			return s.recordStatusWithTx(ctx, tx, id, from, to, source)
+		default:
+			return s.applyTransitionWithTx(ctx, tx, id, from, to, source)
+		}
+	})
+	if err != nil || !changed {
+		return from, false, err
+	}
+	s.invalidateOrder(ctx, id)
+	if from != to {
+		s.notifyStatusChange(ctx, id, from, to)
+	}
+	return from, true, nil
+}
+
+// applyTransitionWithTx checks the move from -> to against the service's Transitions, then updates the
+// order status and appends history within tx. The caller must hold the order row lock.
+func (s *OrderService) applyTransitionWithTx(
+	ctx context.Context,
+	tx domain.Transaction,
+	id string,
+	from, to domain.OrderStatus,
+	source string,
+) error {
+	if err := s.checkTransition(id, from, to); err != nil {
+		return err
+	}
+	return s.recordStatusWithTx(ctx, tx, id, from, to, source)
+}
+
+// recordStatusWithTx writes status `to` and appends the from -> to history entry within tx,
+// without consulting the service's Transitions
+func (s *OrderService) recordStatusWithTx(
+	ctx context.Context,
+	tx domain.Transaction,
+	id string,
+	from, to domain.OrderStatus,
+	source string,
+) error {
+	if err := s.orderRepo.UpdateStatusWithTx(ctx, tx, id, to); err != nil {
+		return err
+	}
+
+	change := &domain.StatusChange{
+		OrderID:    id,
+		FromStatus: from,
+		ToStatus:   to,
+		Source:     source,
+		ChangedBy:  actorID(ctx),
+	}
+	return s.orderRepo.AddStatusHistoryWithTx(ctx, tx, change)
+}
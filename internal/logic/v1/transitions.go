@@ -0,0 +1,160 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"github.com/duynhne/order-service/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Transitions is an order state machine: the statuses each status may move to. The service's
+// Transitions (WithTransitions, DefaultTransitions otherwise) is the single source of truth for
+// allowed status changes. Every mutation (UpdateOrderStatus, payment webhook, reconciliation,
+// cancellation) goes through transitionStatus, which enforces it, and the actions endpoint reads
+// it, so what clients are offered always matches what the service accepts.
+type Transitions map[domain.OrderStatus][]domain.OrderStatus
+
+// defaultTransitions is the built-in state machine.
+//
+// Fulfilment may skip intermediate states (e.g. paid -> completed) when shipping
+// reports progress late; nothing moves backwards and terminal states have no exits.
+var defaultTransitions = Transitions{
+	domain.OrderStatusDraft:   {domain.OrderStatusPending, domain.OrderStatusCancelled},
+	domain.OrderStatusPending: {domain.OrderStatusPaid, domain.OrderStatusCancelled},
+	domain.OrderStatusPaid: {
+		domain.OrderStatusProcessing, domain.OrderStatusShipped, domain.OrderStatusCompleted, domain.OrderStatusCancelled,
+	},
+	domain.OrderStatusProcessing: {domain.OrderStatusShipped, domain.OrderStatusCompleted, domain.OrderStatusCancelled},
+	domain.OrderStatusShipped:    {domain.OrderStatusCompleted},
+	domain.OrderStatusCompleted:  nil,
+	domain.OrderStatusCancelled:  nil,
+}
+
+// terminalStatuses must have no exits in any Transitions: purge, draft expiry and reconciliation
+// rely on orders in them never changing again
+var terminalStatuses = []domain.OrderStatus{domain.OrderStatusCompleted, domain.OrderStatusCancelled}
+
+// orderActions names the client-facing action that moves an order into each status
+var orderActions = map[domain.OrderStatus]string{
+	domain.OrderStatusPending:    "confirm",
+	domain.OrderStatusPaid:       "pay",
+	domain.OrderStatusProcessing: "process",
+	domain.OrderStatusShipped:    "ship",
+	domain.OrderStatusCompleted:  "complete",
+	domain.OrderStatusCancelled:  "cancel",
+}
+
+// DefaultTransitions returns a copy of the built-in state machine
+func DefaultTransitions() Transitions {
+	t := make(Transitions, len(defaultTransitions))
+	for from, to := range defaultTransitions {
+		t[from] = slices.Clone(to)
+	}
+	return t
+}
+
+// ParseTransitions reads a state machine from JSON shaped like
+// {"pending": ["paid", "cancelled"], ..., "cancelled": []} and checks that it is consistent:
+// every order status is listed (an empty list for a terminal one) and only known statuses are
+// named, no status lists itself or the same status twice, nothing moves into draft (drafts are
+// only created), and completed and cancelled stay terminal. Returns ErrInvalidInput otherwise.
+func ParseTransitions(data []byte) (Transitions, error) {
+	var raw map[string][]string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("transitions are not a JSON object of status lists: %v: %w", err, ErrInvalidInput)
+	}
+
+	t := make(Transitions, len(raw))
+	for rawFrom, rawTo := range raw {
+		from, err := domain.ParseOrderStatus(rawFrom)
+		if err != nil {
+			return nil, fmt.Errorf("transitions: %v: %w", err, ErrInvalidInput)
+		}
+		if _, dup := t[from]; dup {
+			return nil, fmt.Errorf("transitions: status %q listed twice: %w", from, ErrInvalidInput)
+		}
+		next := make([]domain.OrderStatus, 0, len(rawTo))
+		for _, r := range rawTo {
+			to, err := domain.ParseOrderStatus(r)
+			if err != nil {
+				return nil, fmt.Errorf("transitions from %q: %v: %w", from, err, ErrInvalidInput)
+			}
+			switch {
+			case to == from:
+				return nil, fmt.Errorf("transitions: %q cannot move to itself: %w", from, ErrInvalidInput)
+			case to == domain.OrderStatusDraft:
+				return nil, fmt.Errorf("transitions: %q cannot move to draft: %w", from, ErrInvalidInput)
+			case slices.Contains(next, to):
+				return nil, fmt.Errorf("transitions from %q list %q twice: %w", from, to, ErrInvalidInput)
+			}
+			next = append(next, to)
+		}
+		t[from] = next
+	}
+
+	for status := range defaultTransitions {
+		if _, ok := t[status]; !ok {
+			return nil, fmt.Errorf("transitions: status %q missing (use [] for a terminal status): %w", status, ErrInvalidInput)
+		}
+	}
+	for _, status := range terminalStatuses {
+		if len(t[status]) > 0 {
+			return nil, fmt.Errorf("transitions: %q is terminal and cannot move to %v: %w", status, t[status], ErrInvalidInput)
+		}
+	}
+	return t, nil
+}
+
+// Next returns the statuses an order in status from may move to (nil for terminal states)
+func (t Transitions) Next(from domain.OrderStatus) []domain.OrderStatus {
+	return slices.Clone(t[from])
+}
+
+// Allows reports whether an order may move from one status to another
+func (t Transitions) Allows(from, to domain.OrderStatus) bool {
+	return slices.Contains(t[from], to)
+}
+
+// checkTransition returns ErrInvalidOrderState unless the service's transitions allow from -> to
+func (s *OrderService) checkTransition(id string, from, to domain.OrderStatus) error {
+	if !s.transitions.Allows(from, to) {
+		return fmt.Errorf("order %q cannot move from %q to %q: %w", id, from, to, ErrInvalidOrderState)
+	}
+	return nil
+}
+
+// GetOrderActions returns the allowed next statuses and actions for an order owned by userID.
+// Returns ErrUnauthorized if the order belongs to another user.
+func (s *OrderService) GetOrderActions(ctx context.Context, id, userID string) (*domain.OrderActions, error) {
+	ctx, span := middleware.StartSpan(ctx, "order.actions", trace.WithAttributes(
+		attribute.String("layer", "logic"),
+		attribute.String("order.id", id),
+	))
+	defer span.End()
+
+	order, err := s.GetUserOrder(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	next := s.transitions.Next(order.Status)
+	actions := make([]string, 0, len(next))
+	for _, status := range next {
+		actions = append(actions, orderActions[status])
+	}
+	if next == nil {
+		next = []domain.OrderStatus{}
+	}
+
+	return &domain.OrderActions{
+		OrderID:      order.ID,
+		Status:       order.Status,
+		NextStatuses: next,
+		Actions:      actions,
+	}, nil
+}
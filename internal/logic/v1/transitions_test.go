@@ -0,0 +1,102 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+)
+
+// transitionsJSON is the default state machine with shipped orders also cancellable
+const transitionsJSON = `{
+	"draft": ["pending", "cancelled"],
+	"pending": ["paid", "cancelled"],
+	"paid": ["processing", "shipped", "completed", "cancelled"],
+	"processing": ["shipped", "completed", "cancelled"],
+	"shipped": ["completed", "cancelled"],
+	"completed": [],
+	"cancelled": []
+}`
+
+func TestParseTransitions(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr bool
+	}{
+		{name: "Valid", json: transitionsJSON},
+		{name: "Case-insensitive statuses", json: `{"DRAFT": ["Pending"], "pending": ["paid"], "paid": [], "processing": [], "shipped": [], "completed": [], "cancelled": null}`},
+		{name: "Not JSON", json: `pending -> paid`, wantErr: true},
+		{name: "Unknown status", json: `{"draft": [], "pending": ["lost"], "paid": [], "processing": [], "shipped": [], "completed": [], "cancelled": []}`, wantErr: true},
+		{name: "Status missing", json: `{"draft": [], "pending": ["paid"], "paid": [], "processing": [], "shipped": [], "completed": []}`, wantErr: true},
+		{name: "Status listed twice", json: `{"draft": [], "pending": [], "PENDING": [], "paid": [], "processing": [], "shipped": [], "completed": [], "cancelled": []}`, wantErr: true},
+		{name: "Self transition", json: `{"draft": [], "pending": ["pending"], "paid": [], "processing": [], "shipped": [], "completed": [], "cancelled": []}`, wantErr: true},
+		{name: "Duplicate target", json: `{"draft": [], "pending": ["paid", "paid"], "paid": [], "processing": [], "shipped": [], "completed": [], "cancelled": []}`, wantErr: true},
+		{name: "Back to draft", json: `{"draft": [], "pending": ["draft"], "paid": [], "processing": [], "shipped": [], "completed": [], "cancelled": []}`, wantErr: true},
+		{name: "Terminal with exit", json: `{"draft": [], "pending": [], "paid": [], "processing": [], "shipped": [], "completed": [], "cancelled": ["pending"]}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTransitions([]byte(tt.json))
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidInput) {
+					t.Errorf("ParseTransitions() error = %v, want ErrInvalidInput", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseTransitions() error = %v", err)
+			}
+			if len(got) != len(defaultTransitions) {
+				t.Errorf("ParseTransitions() has %d statuses, want %d", len(got), len(defaultTransitions))
+			}
+		})
+	}
+}
+
+func TestDefaultTransitionsParse(t *testing.T) {
+	data, err := json.Marshal(DefaultTransitions())
+	if err != nil {
+		t.Fatalf("marshal default transitions: %v", err)
+	}
+	got, err := ParseTransitions(data)
+	if err != nil {
+		t.Fatalf("the built-in state machine does not pass its own validation: %v", err)
+	}
+	for from, to := range defaultTransitions {
+		if !slices.Equal(got[from], to) {
+			t.Errorf("round-tripped %q -> %v, want %v", from, got[from], to)
+		}
+	}
+}
+
+func TestWithTransitions(t *testing.T) {
+	transitions, err := ParseTransitions([]byte(transitionsJSON))
+	if err != nil {
+		t.Fatalf("ParseTransitions() error = %v", err)
+	}
+	repo := &MockOrderRepository{
+		ownerID: "user1",
+		findStatusFunc: func(ctx context.Context, id string) (domain.OrderStatus, error) {
+			return domain.OrderStatusShipped, nil
+		},
+	}
+	ctx := context.Background()
+
+	// The built-in machine refuses shipped -> cancelled
+	if err := NewOrderService(repo, &MockTransactionManager{}).UpdateOrderStatus(ctx, "1", "cancelled", false); !errors.Is(err, ErrInvalidOrderState) {
+		t.Fatalf("UpdateOrderStatus() with default transitions error = %v, want ErrInvalidOrderState", err)
+	}
+
+	service := NewOrderService(repo, &MockTransactionManager{}, WithTransitions(transitions))
+	if err := service.UpdateOrderStatus(ctx, "1", "cancelled", false); err != nil {
+		t.Fatalf("UpdateOrderStatus() with configured transitions error = %v", err)
+	}
+	if !slices.Equal(repo.updatedStatuses, []domain.OrderStatus{domain.OrderStatusCancelled}) {
+		t.Errorf("updated statuses = %v, want [cancelled]", repo.updatedStatuses)
+	}
+}
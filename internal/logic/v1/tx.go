@@ -0,0 +1,53 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// maxTxAttempts bounds how often inTx runs a transaction that keeps losing its connection
+const maxTxAttempts = 3
+
+// txRetryBackoff is the pause before each re-run, multiplied by the attempt number
+const txRetryBackoff = 50 * time.Millisecond
+
+// inTx runs fn in a transaction and commits it. When Begin or a statement fails with
+// domain.ErrRetryable (PgCat dropped the connection; nothing was applied) the whole transaction is
+// run again on a fresh connection, up to maxTxAttempts runs in total. fn must therefore have no
+// effects outside tx and must set its results afresh on every run; post-commit work (cache
+// invalidation, notifications) belongs after inTx. Commit errors are never retried: a commit that
+// lost its connection may have been applied (ErrCommitUnknown).
+func (s *OrderService) inTx(ctx context.Context, fn func(tx domain.Transaction) error) error {
+	for attempt := 1; ; attempt++ {
+		err := s.runTx(ctx, fn)
+		if !errors.Is(err, domain.ErrRetryable) || attempt == maxTxAttempts {
+			return err
+		}
+		trace.SpanFromContext(ctx).AddEvent("tx.retry", trace.WithAttributes(attribute.Int("tx.attempt", attempt)))
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(time.Duration(attempt) * txRetryBackoff):
+		}
+	}
+}
+
+// runTx runs fn in one transaction, committing when it succeeds and rolling back otherwise
+func (s *OrderService) runTx(ctx context.Context, fn func(tx domain.Transaction) error) error {
+	tx, err := s.txManager.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }() // Rollback if not committed
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,87 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+)
+
+// commitFailingTx fails Commit with err
+type commitFailingTx struct {
+	MockTransaction
+	err error
+}
+
+func (t *commitFailingTx) Commit(ctx context.Context) error { return t.err }
+
+type commitFailingTxManager struct{ err error }
+
+func (m commitFailingTxManager) Begin(ctx context.Context) (domain.Transaction, error) {
+	return &commitFailingTx{err: m.err}, nil
+}
+
+func TestCreateOrderRetriesLostConnection(t *testing.T) {
+	req := domain.CreateOrderRequest{
+		UserID: "user1",
+		Items:  []domain.OrderItem{{ProductID: "1", ProductName: "Widget", Quantity: 1, Price: 10}},
+	}
+	lost := func(calls int) error { return errors.Join(domain.ErrRetryable, errors.New("conn closed")) }
+
+	tests := []struct {
+		name      string
+		txManager domain.TransactionManager
+		fail      func(call int) error // error of the call-th CreateWithTx, 1-based
+		wantErr   error
+		wantCalls int
+	}{
+		{
+			name:      "Re-run after lost connections",
+			txManager: &MockTransactionManager{},
+			fail: func(call int) error {
+				if call < maxTxAttempts {
+					return lost(call)
+				}
+				return nil
+			},
+			wantCalls: maxTxAttempts,
+		},
+		{name: "Gives up after max attempts", txManager: &MockTransactionManager{}, fail: lost, wantErr: domain.ErrRetryable, wantCalls: maxTxAttempts},
+		{
+			name:      "Other errors are not retried",
+			txManager: &MockTransactionManager{},
+			fail:      func(int) error { return domain.ErrConflict },
+			wantErr:   ErrDuplicateExternalRef,
+			wantCalls: 1,
+		},
+		{
+			name:      "Commit with unknown outcome is not retried",
+			txManager: commitFailingTxManager{err: domain.ErrCommitUnknown},
+			fail:      func(int) error { return nil },
+			wantErr:   ErrCommitUnknown,
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			repo := &MockOrderRepository{
+				createWithTxFunc: func(ctx context.Context, tx domain.Transaction, order *domain.Order) error {
+					calls++
+					return tt.fail(calls)
+				},
+			}
+			service := NewOrderService(repo, tt.txManager)
+
+			_, err := service.CreateOrder(context.Background(), req)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("CreateOrder() error = %v, want %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("transaction runs = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
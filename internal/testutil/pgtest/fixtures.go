@@ -0,0 +1,86 @@
+package pgtest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+)
+
+// OrderBuilder builds domain.Order fixtures with consistent totals.
+// Start from NewOrder; every With* call returns the builder for chaining.
+type OrderBuilder struct {
+	order domain.Order
+}
+
+// NewOrder starts a pending, standard-priority order for userID with no items and
+// shipping 5.00 (the default flat rate)
+func NewOrder(userID string) *OrderBuilder {
+	return &OrderBuilder{order: domain.Order{
+		UserID:   userID,
+		Status:   domain.OrderStatusPending,
+		Priority: domain.OrderPriorityStandard,
+		Shipping: 5.00,
+	}}
+}
+
+// WithItem adds an item; its subtotal is price x quantity
+func (b *OrderBuilder) WithItem(productID string, quantity int, price float64) *OrderBuilder {
+	b.order.Items = append(b.order.Items, domain.OrderItem{
+		ProductID:   productID,
+		ProductName: "Product " + productID,
+		Quantity:    quantity,
+		Price:       price,
+		Subtotal:    price * float64(quantity),
+	})
+	return b
+}
+
+// WithSKU sets the SKU of the item added last
+func (b *OrderBuilder) WithSKU(sku string) *OrderBuilder {
+	b.order.Items[len(b.order.Items)-1].SKU = sku
+	return b
+}
+
+// WithStatus sets the order status
+func (b *OrderBuilder) WithStatus(status domain.OrderStatus) *OrderBuilder {
+	b.order.Status = status
+	return b
+}
+
+// WithShipping sets the shipping charge
+func (b *OrderBuilder) WithShipping(shipping float64) *OrderBuilder {
+	b.order.Shipping = shipping
+	return b
+}
+
+// WithMetadata sets one metadata entry
+func (b *OrderBuilder) WithMetadata(key, value string) *OrderBuilder {
+	if b.order.Metadata == nil {
+		b.order.Metadata = map[string]string{}
+	}
+	b.order.Metadata[key] = value
+	return b
+}
+
+// Build returns the order with Subtotal and Total computed from its items
+func (b *OrderBuilder) Build() *domain.Order {
+	order := b.order
+	order.Items = append([]domain.OrderItem(nil), b.order.Items...)
+	order.Subtotal = 0
+	for _, item := range order.Items {
+		order.Subtotal += item.Subtotal
+	}
+	order.Total = order.Subtotal + order.Shipping
+	return &order
+}
+
+// Create builds the order and inserts it, failing t on error. The returned order has its ID set.
+func (b *OrderBuilder) Create(t testing.TB, db *DB) *domain.Order {
+	t.Helper()
+	order := b.Build()
+	if err := db.Orders.Create(context.Background(), order); err != nil {
+		t.Fatalf("pgtest: create order fixture: %v", err)
+	}
+	return order
+}
@@ -0,0 +1,337 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	logicv1 "github.com/duynhne/order-service/internal/logic/v1"
+	"github.com/duynhne/order-service/middleware"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// AdminHandler serves admin-only order endpoints.
+// Role enforcement is done by middleware.RequireRole on the router group.
+type AdminHandler struct {
+	orderService *logicv1.OrderService
+	cfg          HandlerConfig
+}
+
+// NewAdminHandler creates a new admin handler with dependency injection
+func NewAdminHandler(orderService *logicv1.OrderService, cfg HandlerConfig) *AdminHandler {
+	return &AdminHandler{orderService: orderService, cfg: cfg.withDefaults()}
+}
+
+// SearchOrders handles GET /order/v1/private/admin/orders/search?user_id=
+// Unlike ListOrders, results are not scoped to the caller.
+func (h *AdminHandler) SearchOrders(c *gin.Context) {
+	ctx, span := middleware.StartSpan(c.Request.Context(), "http.request", trace.WithAttributes(
+		attribute.String("layer", "web"),
+		attribute.String("method", c.Request.Method),
+		attribute.String("path", c.Request.URL.Path),
+	))
+	defer span.End()
+
+	zapLogger := middleware.GetLoggerFromGinContext(c)
+
+	page, err := h.cfg.parsePage(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pagination parameters"})
+		return
+	}
+
+	filter := domain.OrderSearchFilter{UserID: c.Query("user_id")}
+	orders, total, err := h.orderService.SearchOrders(ctx, filter, page)
+	if err != nil {
+		span.RecordError(err)
+		zapLogger.Error("Failed to search orders", zap.Error(err))
+
+		switch {
+		case errors.Is(err, logicv1.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "At least one search filter is required"})
+		default:
+			respondInternalError(c, err)
+		}
+		return
+	}
+
+	zapLogger.Info("Admin order search",
+		zap.String("admin_id", authUserID(c)),
+		zap.String("filter_user_id", filter.UserID),
+		zap.Int("count", len(orders)),
+	)
+	h.cfg.respondPage(c, page, total, orders, OrderListResponse{
+		Orders: orders,
+		Total:  total,
+		Limit:  page.Limit,
+		Offset: page.Offset,
+	})
+}
+
+// OrphanedItemListResponse wraps a page of orphaned order items with pagination metadata
+type OrphanedItemListResponse struct {
+	Items  []domain.OrphanedItem `json:"items"`
+	Total  int                   `json:"total"`
+	Limit  int                   `json:"limit"`
+	Offset int                   `json:"offset"`
+}
+
+// ListOrphanedItems handles GET /order/v1/private/admin/integrity/orphaned-items?limit=&offset=
+// Reports order items whose order no longer exists; nothing is deleted.
+func (h *AdminHandler) ListOrphanedItems(c *gin.Context) {
+	ctx, span := middleware.StartSpan(c.Request.Context(), "http.request", trace.WithAttributes(
+		attribute.String("layer", "web"),
+		attribute.String("method", c.Request.Method),
+		attribute.String("path", c.Request.URL.Path),
+	))
+	defer span.End()
+
+	zapLogger := middleware.GetLoggerFromGinContext(c)
+
+	page, err := h.cfg.parsePage(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pagination parameters"})
+		return
+	}
+
+	items, total, err := h.orderService.FindOrphanedItems(ctx, page)
+	if err != nil {
+		span.RecordError(err)
+		zapLogger.Error("Failed to find orphaned order items", zap.Error(err))
+		respondInternalError(c, err)
+		return
+	}
+
+	if total > 0 {
+		zapLogger.Warn("Orphaned order items found",
+			zap.String("admin_id", authUserID(c)),
+			zap.Int("total", total),
+		)
+	}
+	h.cfg.respondPage(c, page, total, items, OrphanedItemListResponse{
+		Items:  items,
+		Total:  total,
+		Limit:  page.Limit,
+		Offset: page.Offset,
+	})
+}
+
+// InternalNoteRequest is the body of PATCH .../internal-note
+type InternalNoteRequest struct {
+	InternalNote *string `json:"internal_note" binding:"required"`
+}
+
+// GetInternalNote handles GET /order/v1/private/admin/orders/:id/internal-note
+func (h *AdminHandler) GetInternalNote(c *gin.Context) {
+	ctx, span := middleware.StartSpan(c.Request.Context(), "http.request", trace.WithAttributes(
+		attribute.String("layer", "web"),
+		attribute.String("method", c.Request.Method),
+		attribute.String("path", c.Request.URL.Path),
+	))
+	defer span.End()
+
+	zapLogger := middleware.GetLoggerFromGinContext(c)
+	id := c.Param("id")
+	span.SetAttributes(attribute.String("order.id", id))
+
+	note, err := h.orderService.GetInternalNote(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		zapLogger.Error("Failed to get internal note", zap.Error(err))
+
+		switch {
+		case errors.Is(err, logicv1.ErrOrderNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Order not found"})
+		case errors.Is(err, logicv1.ErrUnauthorized):
+			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		default:
+			respondInternalError(c, err)
+		}
+		return
+	}
+
+	h.cfg.respond(c, http.StatusOK, note)
+}
+
+// UpdateInternalNote handles PATCH /order/v1/private/admin/orders/:id/internal-note
+// An empty internal_note clears the note.
+func (h *AdminHandler) UpdateInternalNote(c *gin.Context) {
+	ctx, span := middleware.StartSpan(c.Request.Context(), "http.request", trace.WithAttributes(
+		attribute.String("layer", "web"),
+		attribute.String("method", c.Request.Method),
+		attribute.String("path", c.Request.URL.Path),
+	))
+	defer span.End()
+
+	zapLogger := middleware.GetLoggerFromGinContext(c)
+	id := c.Param("id")
+	span.SetAttributes(attribute.String("order.id", id))
+
+	var req InternalNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.SetAttributes(attribute.Bool("request.valid", false))
+		span.RecordError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": bindErrorMessage(err)})
+		return
+	}
+
+	note, err := h.orderService.SetInternalNote(ctx, id, *req.InternalNote)
+	if err != nil {
+		span.RecordError(err)
+		zapLogger.Error("Failed to update internal note", zap.Error(err))
+
+		switch {
+		case errors.Is(err, logicv1.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("internal_note must be at most %d characters", logicv1.MaxInternalNoteLength),
+			})
+		case errors.Is(err, logicv1.ErrOrderNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Order not found"})
+		case errors.Is(err, logicv1.ErrUnauthorized):
+			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		default:
+			respondInternalError(c, err)
+		}
+		return
+	}
+
+	// Log who changed the note, not its content
+	zapLogger.Info("Internal note updated",
+		zap.String("admin_id", authUserID(c)),
+		zap.String("order_id", id),
+		zap.Int("note_length", len(note.Note)),
+	)
+	h.cfg.respond(c, http.StatusOK, note)
+}
+
+// defaultStatsWindow is the look-back of GetOrderStats when neither ?window= nor ?since= is given
+const defaultStatsWindow = 24 * time.Hour
+
+// GetOrderStats handles GET /order/v1/private/admin/orders/metrics?window=|since=
+// Returns the count and revenue of non-cancelled orders created in a rolling window.
+// window is a Go duration (e.g. 24h, default); since is an RFC 3339 timestamp or a
+// YYYY-MM-DD date (UTC midnight, e.g. today for "revenue today") and takes precedence.
+func (h *AdminHandler) GetOrderStats(c *gin.Context) {
+	ctx, span := middleware.StartSpan(c.Request.Context(), "http.request", trace.WithAttributes(
+		attribute.String("layer", "web"),
+		attribute.String("method", c.Request.Method),
+		attribute.String("path", c.Request.URL.Path),
+	))
+	defer span.End()
+
+	zapLogger := middleware.GetLoggerFromGinContext(c)
+
+	since := time.Now().Add(-defaultStatsWindow)
+	switch {
+	case c.Query("since") != "":
+		t, err := parseTimeOrDate(c.Query("since"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "since must be an RFC 3339 timestamp or YYYY-MM-DD date"})
+			return
+		}
+		since = t
+	case c.Query("window") != "":
+		window, err := time.ParseDuration(c.Query("window"))
+		if err != nil || window <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "window must be a positive duration such as 24h"})
+			return
+		}
+		since = time.Now().Add(-window)
+	}
+
+	stats, err := h.orderService.GetOrderStats(ctx, since)
+	if err != nil {
+		span.RecordError(err)
+		zapLogger.Error("Failed to compute order stats", zap.Error(err))
+
+		switch {
+		case errors.Is(err, logicv1.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("since must be in the past and at most %s ago", logicv1.MaxStatsWindow),
+			})
+		default:
+			respondInternalError(c, err)
+		}
+		return
+	}
+
+	h.cfg.respond(c, http.StatusOK, stats)
+}
+
+// PurgeOrders handles DELETE /order/v1/private/admin/orders/purge?older_than=90d&status=cancelled&confirm=true
+// Hard-deletes orders in status (default cancelled) not updated within older_than, for data retention.
+// confirm=true is required so a stray request cannot delete data; at most logicv1.MaxPurgeBatch
+// orders go per call, so repeat while purged equals that batch size.
+func (h *AdminHandler) PurgeOrders(c *gin.Context) {
+	ctx, span := middleware.StartSpan(c.Request.Context(), "http.request", trace.WithAttributes(
+		attribute.String("layer", "web"),
+		attribute.String("method", c.Request.Method),
+		attribute.String("path", c.Request.URL.Path),
+	))
+	defer span.End()
+
+	zapLogger := middleware.GetLoggerFromGinContext(c)
+
+	if confirm, err := strconv.ParseBool(c.Query("confirm")); err != nil || !confirm {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "confirm=true is required to purge orders"})
+		return
+	}
+	olderThan, err := parseAge(c.Query("older_than"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "older_than must be a number of days such as 90d or a duration such as 2160h"})
+		return
+	}
+	status := domain.OrderStatusCancelled
+	if raw := c.Query("status"); raw != "" {
+		status = domain.OrderStatus(raw)
+	}
+
+	result, err := h.orderService.PurgeOrders(ctx, status, olderThan)
+	if err != nil {
+		span.RecordError(err)
+		zapLogger.Error("Failed to purge orders", zap.Error(err))
+
+		switch {
+		case errors.Is(err, logicv1.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("only cancelled orders older than %s can be purged", logicv1.MinPurgeAge),
+			})
+		case errors.Is(err, logicv1.ErrUnauthorized):
+			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		default:
+			respondInternalError(c, err)
+		}
+		return
+	}
+
+	zapLogger.Info("Orders purged",
+		zap.String("admin_id", authUserID(c)),
+		zap.String("status", string(result.Status)),
+		zap.Time("before", result.Before),
+		zap.Int("purged", result.Purged),
+	)
+	h.cfg.respond(c, http.StatusOK, result)
+}
+
+// parseAge parses a retention age given in days ("90d") or as a Go duration ("2160h")
+func parseAge(raw string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(raw, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid age %q", raw)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid age %q", raw)
+	}
+	return d, nil
+}
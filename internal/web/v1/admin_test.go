@@ -0,0 +1,68 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	logicv1 "github.com/duynhne/order-service/internal/logic/v1"
+	"github.com/duynhne/order-service/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func TestAdminSearchOrders(t *testing.T) {
+	tests := []struct {
+		name       string
+		roles      []string
+		query      string
+		wantStatus int
+		wantCount  int
+	}{
+		{name: "Admin searches another user's orders", roles: []string{middleware.RoleAdmin}, query: "?user_id=user2", wantStatus: http.StatusOK, wantCount: 2},
+		{name: "Admin among other roles", roles: []string{"support", middleware.RoleAdmin}, query: "?user_id=user2", wantStatus: http.StatusOK, wantCount: 2},
+		{name: "Admin without a filter", roles: []string{middleware.RoleAdmin}, query: "", wantStatus: http.StatusBadRequest},
+		{name: "Admin with an empty filter", roles: []string{middleware.RoleAdmin}, query: "?user_id=", wantStatus: http.StatusBadRequest},
+		{name: "Non-admin", roles: []string{"customer"}, query: "?user_id=user2", wantStatus: http.StatusForbidden},
+		{name: "No roles", query: "?user_id=user2", wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeOrderRepository(
+				domain.Order{ID: "1", UserID: "user1", Status: domain.OrderStatusPending},
+				domain.Order{ID: "2", UserID: "user2", Status: domain.OrderStatusPaid},
+				domain.Order{ID: "3", UserID: "user2", Status: domain.OrderStatusShipped},
+			)
+			service := logicv1.NewOrderService(repo, fakeTransactionManager{})
+			handler := NewAdminHandler(service, HandlerConfig{})
+
+			router := gin.New()
+			admin := router.Group("/order/v1/private/admin", asUser("user1", tt.roles...), middleware.RequireRole(middleware.RoleAdmin))
+			admin.GET("/orders/search", handler.SearchOrders)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/order/v1/private/admin/orders/search"+tt.query, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var resp OrderListResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if len(resp.Orders) != tt.wantCount || resp.Total != tt.wantCount {
+				t.Errorf("orders = %d of %d, want %d", len(resp.Orders), resp.Total, tt.wantCount)
+			}
+			for _, order := range resp.Orders {
+				if order.UserID != "user2" {
+					t.Errorf("order %s of %s in a search for user2", order.ID, order.UserID)
+				}
+			}
+		})
+	}
+}
@@ -1,13 +1,16 @@
 package v1
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
+	neturl "net/url"
+	"sync"
 	"time"
 
+	"github.com/duynhne/order-service/internal/core/domain"
 	"github.com/duynhne/order-service/middleware"
 	"github.com/gin-gonic/gin"
 	"go.opentelemetry.io/otel/attribute"
@@ -19,8 +22,9 @@ import (
 
 // ShippingClient handles HTTP calls to the shipping service
 type ShippingClient struct {
-	baseURL    string
-	httpClient *http.Client
+	baseURL      string
+	pathTemplate string // shipment-by-order path with one %s for the order ID
+	httpClient   *http.Client
 }
 
 // Shipment represents a shipment response from the shipping service
@@ -41,10 +45,27 @@ type OrderDetailsResponse struct {
 	Shipment *Shipment   `json:"shipment,omitempty"`
 }
 
-// NewShippingClient creates a new shipping service client
-func NewShippingClient(baseURL string) *ShippingClient {
+// OrderDetailsListResponse is the aggregated list response, one entry per order
+type OrderDetailsListResponse struct {
+	Orders []OrderDetailsResponse `json:"orders"`
+	Total  int                    `json:"total"`
+	Limit  int                    `json:"limit"`
+	Offset int                    `json:"offset"`
+}
+
+const (
+	// shipmentFetchConcurrency caps in-flight shipping-service calls per list request
+	shipmentFetchConcurrency = 8
+	// shipmentBatchTimeout is the shared deadline for all shipment fetches of one list request
+	shipmentBatchTimeout = 3 * time.Second
+)
+
+// NewShippingClient creates a new shipping service client.
+// pathTemplate is validated by config (SHIPPING_PATH_TEMPLATE) and must contain exactly one %s.
+func NewShippingClient(baseURL, pathTemplate string) *ShippingClient {
 	return &ShippingClient{
-		baseURL: baseURL,
+		baseURL:      baseURL,
+		pathTemplate: pathTemplate,
 		httpClient: &http.Client{
 			Timeout: 5 * time.Second,
 		},
@@ -54,7 +75,7 @@ func NewShippingClient(baseURL string) *ShippingClient {
 // GetShipmentByOrderID fetches shipment info for an order
 func (c *ShippingClient) GetShipmentByOrderID(ctx context.Context, orderID string) (*Shipment, error) {
 	// Internal shipping endpoint — not routed through Kong, reached via in-cluster DNS.
-	url := fmt.Sprintf("%s/shipping/v1/internal/orders/%s", c.baseURL, orderID)
+	url := c.baseURL + fmt.Sprintf(c.pathTemplate, neturl.PathEscape(orderID))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -84,17 +105,42 @@ func (c *ShippingClient) GetShipmentByOrderID(ctx context.Context, orderID strin
 	return &shipment, nil
 }
 
-// Global shipping client (set during init)
-var shippingClient *ShippingClient
+// UpdateShipmentAddress tells the shipping service that an order's delivery address changed.
+// PUT {shipment path}/address; notified is false when the order has no shipment yet (404).
+func (c *ShippingClient) UpdateShipmentAddress(
+	ctx context.Context, orderID string, address domain.ShippingAddress,
+) (notified bool, err error) {
+	url := c.baseURL + fmt.Sprintf(c.pathTemplate, neturl.PathEscape(orderID)) + "/address"
+
+	body, err := json.Marshal(address)
+	if err != nil {
+		return false, fmt.Errorf("encode shipping address: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("create shipping request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
 
-// SetShippingClient sets the shipping client for aggregation handlers
-func SetShippingClient(client *ShippingClient) {
-	shippingClient = client
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("shipping service call failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		// No shipment yet - it will be created with the order's current address
+		return false, nil
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return false, fmt.Errorf("shipping service returned status %d", resp.StatusCode)
+	}
+	return true, nil
 }
 
 // GetOrderDetails handles GET /order/v1/private/orders/:id/details
 // Returns order with shipment info (aggregation endpoint)
-func GetOrderDetails(c *gin.Context) {
+func (h *OrderHandler) GetOrderDetails(c *gin.Context) {
 	ctx, span := middleware.StartSpan(c.Request.Context(), "http.request", trace.WithAttributes(
 		attribute.String("layer", "web"),
 		attribute.String("method", c.Request.Method),
@@ -107,35 +153,38 @@ func GetOrderDetails(c *gin.Context) {
 	orderID := c.Param("id")
 	span.SetAttributes(attribute.String("order.id", orderID))
 
-	// Get order from handler (use the global handler)
-	if handler == nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Service not initialized"})
+	userID := authUserID(c)
+	if userID == "" {
+		zapLogger.Warn("GetOrderDetails: no user_id in context")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
 		return
 	}
 
-	order, err := handler.orderService.GetOrder(ctx, orderID)
+	// Concurrent requests for the same order share one DB + shipping round trip.
+	// The key includes the caller so the ownership check is never shared across users.
+	// The shared call must not be cancelled by whichever caller started it disconnecting, but
+	// it keeps its own deadline so a slow query cannot hold the callers or a connection forever.
+	result, err, shared := h.detailsGroup.Do(userID+"/"+orderID, func() (any, error) {
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), h.cfg.DetailsTimeout)
+		defer cancel()
+		return h.loadOrderDetails(loadCtx, orderID, userID)
+	})
+	span.SetAttributes(attribute.Bool("singleflight.shared", shared))
 	if err != nil {
 		span.RecordError(err)
 		zapLogger.Error("Failed to get order", zap.Error(err), zap.String("order_id", orderID))
-
-		switch {
-		case errors.Is(err, logicv1.ErrOrderNotFound):
-			c.JSON(http.StatusNotFound, gin.H{"error": "Order not found"})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
-		}
+		h.respondOrderLookupError(c, err)
 		return
 	}
 
-	// Try to get shipment (non-blocking - order may not have shipment yet)
-	var shipment *Shipment
-	if shippingClient != nil {
-		shipment, err = shippingClient.GetShipmentByOrderID(ctx, orderID)
-		if err != nil {
-			// Log but don't fail - shipment is optional
-			zapLogger.Warn("Could not fetch shipment", zap.Error(err), zap.String("order_id", orderID))
-			span.SetAttributes(attribute.Bool("shipment.fetch_error", true))
-		}
+	details := result.(*orderDetails)
+	order, shipment := details.order, details.shipment
+	if details.shipmentErr != nil {
+		// Log but don't fail - shipment is optional
+		zapLogger.Warn("Could not fetch shipment", zap.Error(details.shipmentErr), zap.String("order_id", orderID))
+		span.SetAttributes(attribute.Bool("shipment.fetch_error", true))
+	}
+	if h.shippingClient != nil {
 		if shipment != nil {
 			span.SetAttributes(
 				attribute.Bool("shipment.found", true),
@@ -155,5 +204,155 @@ func GetOrderDetails(c *gin.Context) {
 		zap.String("order_id", orderID),
 		zap.Bool("has_shipment", shipment != nil),
 	)
-	c.JSON(http.StatusOK, response)
+	h.cfg.respond(c, http.StatusOK, response)
+}
+
+// orderDetails is the result of one order + shipment fetch, shared between
+// concurrent GetOrderDetails callers. Callers must treat it as read-only.
+type orderDetails struct {
+	order       *domain.Order
+	shipment    *Shipment
+	shipmentErr error // shipment is optional; a failed lookup does not fail the request
+}
+
+// loadOrderDetails fetches userID's order and, if a shipping client is configured, its shipment.
+// The shipment fetch gets its own ShippingAggregationTimeout, so a slow shipping service only
+// costs the shipment (reported as shipmentErr), not the order.
+func (h *OrderHandler) loadOrderDetails(ctx context.Context, orderID, userID string) (*orderDetails, error) {
+	order, err := h.orderService.GetUserOrder(ctx, orderID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	details := &orderDetails{order: order}
+	if h.shippingClient != nil {
+		details.shipment, details.shipmentErr = h.fetchShipment(ctx, orderID)
+		details.order = withShipmentEstimate(order, details.shipment)
+	}
+	return details, nil
+}
+
+// withShipmentEstimate returns order with EstimatedDelivery taken from the shipment when the
+// shipping service has its own estimate, which is more accurate than the order-time one.
+// order is never modified (it may be cached or shared); a copy is returned when it changes.
+func withShipmentEstimate(order *domain.Order, shipment *Shipment) *domain.Order {
+	if shipment == nil || shipment.EstimatedDelivery == nil {
+		return order
+	}
+	estimate, err := parseTimeOrDate(*shipment.EstimatedDelivery)
+	if err != nil {
+		return order
+	}
+	updated := *order
+	updated.EstimatedDelivery = &estimate
+	return &updated
+}
+
+// ListOrderDetails handles GET /order/v1/private/orders/details
+// Returns one page of the caller's orders, each with shipment info fetched concurrently (aggregation endpoint)
+func (h *OrderHandler) ListOrderDetails(c *gin.Context) {
+	ctx, span := middleware.StartSpan(c.Request.Context(), "http.request", trace.WithAttributes(
+		attribute.String("layer", "web"),
+		attribute.String("method", c.Request.Method),
+		attribute.String("path", c.Request.URL.Path),
+		attribute.String("endpoint.type", "aggregation"),
+	))
+	defer span.End()
+
+	zapLogger := middleware.GetLoggerFromGinContext(c)
+
+	userID := authUserID(c)
+	if userID == "" {
+		zapLogger.Warn("ListOrderDetails: no user_id in context")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	page, err := h.cfg.parsePage(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pagination parameters"})
+		return
+	}
+
+	orders, total, err := h.orderService.ListOrders(ctx, userID, page, logicv1.ListOptions{})
+	if err != nil {
+		span.RecordError(err)
+		zapLogger.Error("Failed to list orders", zap.Error(err))
+		respondInternalError(c, err)
+		return
+	}
+
+	shipments, failed := h.fetchShipments(ctx, orders, zapLogger)
+	span.SetAttributes(
+		attribute.Int("orders.count", len(orders)),
+		attribute.Int("shipment.fetch_errors", failed),
+	)
+
+	response := OrderDetailsListResponse{
+		Orders: make([]OrderDetailsResponse, len(orders)),
+		Total:  total,
+		Limit:  page.Limit,
+		Offset: page.Offset,
+	}
+	for i := range orders {
+		order := withShipmentEstimate(&orders[i], shipments[i])
+		response.Orders[i] = OrderDetailsResponse{Order: order, Shipment: shipments[i]}
+	}
+
+	zapLogger.Info("Order details listed",
+		zap.Int("count", len(orders)),
+		zap.Int("shipment_fetch_errors", failed),
+	)
+	h.cfg.respondPage(c, page, total, response.Orders, response)
+}
+
+// fetchShipments fetches shipments for orders concurrently, bounded by shipmentFetchConcurrency
+// and sharing one shipmentBatchTimeout deadline. A failed fetch leaves a nil entry and does not
+// cancel the others. Returns shipments aligned with orders and the number of failed fetches.
+func (h *OrderHandler) fetchShipments(
+	ctx context.Context, orders []domain.Order, logger *zap.Logger,
+) ([]*Shipment, int) {
+	shipments := make([]*Shipment, len(orders))
+	if h.shippingClient == nil || len(orders) == 0 {
+		return shipments, 0
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, shipmentBatchTimeout)
+	defer cancel()
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed int
+	)
+	sem := make(chan struct{}, shipmentFetchConcurrency)
+	for i := range orders {
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			shipment, err := h.shippingClient.GetShipmentByOrderID(ctx, orders[i].ID)
+			if err != nil {
+				logger.Warn("Could not fetch shipment", zap.Error(err), zap.String("order_id", orders[i].ID))
+				mu.Lock()
+				failed++
+				mu.Unlock()
+				return
+			}
+			// Each goroutine writes only its own index; no lock needed.
+			shipments[i] = shipment
+		})
+	}
+	wg.Wait()
+
+	return shipments, failed
+}
+
+// ShipmentStatus implements domain.ShipmentStatusProvider for background reconciliation
+func (c *ShippingClient) ShipmentStatus(ctx context.Context, orderID string) (string, bool, error) {
+	shipment, err := c.GetShipmentByOrderID(ctx, orderID)
+	if err != nil || shipment == nil {
+		return "", false, err
+	}
+	return shipment.Status, true, nil
 }
@@ -0,0 +1,49 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	logicv1 "github.com/duynhne/order-service/internal/logic/v1"
+	"github.com/gin-gonic/gin"
+)
+
+// stalledOrderRepository never answers FindByID: it waits until the lookup's context ends
+type stalledOrderRepository struct {
+	*fakeOrderRepository
+}
+
+func (r stalledOrderRepository) FindByID(ctx context.Context, id string) (*domain.Order, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestGetOrderDetailsSharedLookupTimesOut(t *testing.T) {
+	repo := stalledOrderRepository{newFakeOrderRepository(domain.Order{ID: "1", UserID: "user1"})}
+	service := logicv1.NewOrderService(repo, fakeTransactionManager{})
+	handler := NewOrderHandler(service, nil, nil, nil, HandlerConfig{DetailsTimeout: 50 * time.Millisecond})
+
+	router := gin.New()
+	router.GET("/order/v1/private/orders/:id/details", asUser("user1"), handler.GetOrderDetails)
+
+	// The request itself has no deadline: only DetailsTimeout can end the shared lookup
+	done := make(chan int, 1)
+	go func() {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/order/v1/private/orders/1/details", nil))
+		done <- w.Code
+	}()
+
+	select {
+	case code := <-done:
+		if code != http.StatusInternalServerError {
+			t.Errorf("status = %d, want %d", code, http.StatusInternalServerError)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("GetOrderDetails did not return: the shared lookup has no deadline")
+	}
+}
@@ -2,19 +2,39 @@ package v1
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
-	"go.uber.org/zap"
+	"github.com/duynhne/order-service/internal/core/domain"
 )
 
+// Cart clear retry policy: cartClearAttempts includes the first call; the wait before each
+// retry starts at cartClearInitialBackoff and doubles.
+const (
+	cartClearAttempts       = 3
+	cartClearInitialBackoff = 100 * time.Millisecond
+)
+
+// cartStatusError is a non-2xx response from the cart service
+type cartStatusError struct {
+	StatusCode int
+}
+
+func (e *cartStatusError) Error() string {
+	return fmt.Sprintf("cart service returned status %d", e.StatusCode)
+}
+
 // CartClient handles HTTP calls to the cart service
 type CartClient struct {
 	baseURL    string
 	httpClient *http.Client
 }
 
+// NewCartClient creates a new cart service client
 func NewCartClient(baseURL string) *CartClient {
 	return &CartClient{
 		baseURL: baseURL,
@@ -44,22 +64,95 @@ func (c *CartClient) ClearCart(ctx context.Context, authHeader string) error {
 
 	// Treat any non-2xx as error (best-effort caller decides what to do)
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("cart service returned status %d", resp.StatusCode)
+		return &cartStatusError{StatusCode: resp.StatusCode}
 	}
 	return nil
 }
 
-// Global cart client (set during init)
-var cartClient *CartClient
+// cartResponse is the cart service's view of the authenticated user's cart
+type cartResponse struct {
+	Items []cartItem `json:"items"`
+}
+
+// cartItem is one cart line; the order service prices it itself, so only these fields are read
+type cartItem struct {
+	ProductID   string  `json:"product_id"`
+	ProductName string  `json:"product_name"`
+	SKU         string  `json:"sku"`
+	Quantity    int     `json:"quantity"`
+	Price       float64 `json:"price"`
+}
+
+// GetCart fetches the authenticated user's cart as order items, forwarding the original
+// Authorization header like ClearCart. An empty cart returns no items and no error.
+func (c *CartClient) GetCart(ctx context.Context, authHeader string) ([]domain.OrderItem, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/cart/v1/private/cart", nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	if authHeader != "" {
+		req.Header.Set("Authorization", authHeader)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request cart service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, &cartStatusError{StatusCode: resp.StatusCode}
+	}
+
+	var cart cartResponse
+	if err := json.NewDecoder(resp.Body).Decode(&cart); err != nil {
+		return nil, fmt.Errorf("decode cart response: %w", err)
+	}
+	items := make([]domain.OrderItem, 0, len(cart.Items))
+	for _, item := range cart.Items {
+		items = append(items, domain.OrderItem{
+			ProductID:   item.ProductID,
+			ProductName: item.ProductName,
+			SKU:         item.SKU,
+			Quantity:    item.Quantity,
+			Price:       item.Price,
+		})
+	}
+	return items, nil
+}
+
+// ClearCartWithRetry calls ClearCart up to cartClearAttempts times with exponential backoff.
+// Only transient failures are retried (transport errors, 429 and 5xx); a 4xx such as an
+// expired token fails immediately. Returns the number of attempts made and the last error.
+func (c *CartClient) ClearCartWithRetry(ctx context.Context, authHeader string) (int, error) {
+	backoff := cartClearInitialBackoff
+	for attempt := 1; ; attempt++ {
+		err := c.ClearCart(ctx, authHeader)
+		if err == nil || attempt == cartClearAttempts || !retryableCartError(err) {
+			return attempt, err
+		}
 
-func SetCartClient(client *CartClient) {
-	cartClient = client
+		select {
+		case <-ctx.Done():
+			return attempt, fmt.Errorf("%w (retry aborted: %v)", err, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
 }
 
-func getCartClient(logger *zap.Logger) *CartClient {
-	if cartClient == nil && logger != nil {
-		logger.Warn("Cart client not initialized")
+// retryableCartError reports whether a failed cart clear may succeed if repeated
+func retryableCartError(err error) bool {
+	var statusErr *cartStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500
 	}
-	return cartClient
+	return true
 }
 
+// isBearerAuthorization reports whether header has the form "Bearer <token>"
+// with a non-empty token containing no whitespace.
+func isBearerAuthorization(header string) bool {
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	return ok && token != "" && !strings.ContainsAny(token, " \t\r\n")
+}
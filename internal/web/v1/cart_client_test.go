@@ -0,0 +1,94 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	logicv1 "github.com/duynhne/order-service/internal/logic/v1"
+	"github.com/gin-gonic/gin"
+)
+
+// newFlakyCartServer answers the cart clears it receives with statuses in turn, repeating the last
+func newFlakyCartServer(t *testing.T, calls *atomic.Int32, statuses ...int) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(calls.Add(1))
+		w.WriteHeader(statuses[min(n, len(statuses))-1])
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestCartClientClearCartWithRetry(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		wantAttempts int
+		wantErr      bool
+	}{
+		{name: "First call succeeds", statuses: []int{http.StatusNoContent}, wantAttempts: 1},
+		{name: "Succeeds after a 503", statuses: []int{http.StatusServiceUnavailable, http.StatusNoContent}, wantAttempts: 2},
+		{name: "Succeeds after a 429", statuses: []int{http.StatusTooManyRequests, http.StatusOK}, wantAttempts: 2},
+		{name: "Fails every attempt", statuses: []int{http.StatusBadGateway}, wantAttempts: cartClearAttempts, wantErr: true},
+		{name: "Expired token is not retried", statuses: []int{http.StatusUnauthorized}, wantAttempts: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			client := NewCartClient(newFlakyCartServer(t, &calls, tt.statuses...).URL)
+
+			attempts, err := client.ClearCartWithRetry(context.Background(), "Bearer token")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ClearCartWithRetry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if attempts != tt.wantAttempts || int(calls.Load()) != tt.wantAttempts {
+				t.Errorf("attempts = %d (%d requests), want %d", attempts, calls.Load(), tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestClearCartDeadLettersAfterFinalFailure(t *testing.T) {
+	tests := []struct {
+		name           string
+		statuses       []int
+		wantCalls      int32
+		wantDeadLetter []string
+	}{
+		{name: "Recovered on retry", statuses: []int{http.StatusServiceUnavailable, http.StatusNoContent}, wantCalls: 2},
+		{name: "Every attempt fails", statuses: []int{http.StatusServiceUnavailable}, wantCalls: cartClearAttempts, wantDeadLetter: []string{"user1/1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeOrderRepository(domain.Order{ID: "1", UserID: "user1", Status: domain.OrderStatusDraft})
+			service := logicv1.NewOrderService(repo, fakeTransactionManager{}, logicv1.WithDraftOrders(true))
+			var calls atomic.Int32
+			cart := NewCartClient(newFlakyCartServer(t, &calls, tt.statuses...).URL)
+			handler := NewOrderHandler(service, nil, cart, nil, HandlerConfig{})
+
+			router := gin.New()
+			router.POST("/orders/:id/confirm", asUser("user1"), handler.ConfirmOrder)
+			req := httptest.NewRequest(http.MethodPost, "/orders/1/confirm", nil)
+			req.Header.Set("Authorization", "Bearer token")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Errorf("status = %d, want 200 whatever happens to the cart (body %s)", w.Code, w.Body)
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("cart clear requests = %d, want %d", got, tt.wantCalls)
+			}
+			if !slices.Equal(repo.failedCartClears, tt.wantDeadLetter) {
+				t.Errorf("dead-lettered cart clears = %v, want %v", repo.failedCartClears, tt.wantDeadLetter)
+			}
+		})
+	}
+}
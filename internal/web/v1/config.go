@@ -0,0 +1,69 @@
+package v1
+
+import "time"
+
+// HandlerConfig holds HTTP-layer settings shared by all handlers
+type HandlerConfig struct {
+	DefaultPageSize int // Page size when the client sends no ?limit=
+	MaxPageSize     int // Upper bound applied to any client-supplied ?limit=
+	// RevealForbidden answers 403 for another user's order instead of 404.
+	// Off by default: a 404 does not confirm that the order ID exists, at the cost of
+	// less precise errors for clients (ORDER_NOTFOUND_ON_FORBIDDEN=false turns it on).
+	RevealForbidden bool
+	// GoneForPurged answers 410 for an order removed by the admin purge instead of 404, so clients can
+	// tell "never existed" from "removed" (ORDER_GONE_FOR_PURGED). Off by default, like RevealForbidden:
+	// a 410 confirms the ID once existed.
+	GoneForPurged bool
+	// ResponseEnvelope wraps success bodies as {"data": ..., "meta": {...}} (API_RESPONSE_ENVELOPE).
+	// Off by default so existing clients keep the bare shapes.
+	ResponseEnvelope bool
+	// StrictJSON rejects order create/quote bodies with unknown fields, and unknown ?fields= names,
+	// with 400 (STRICT_JSON). Off by default: unknown fields are ignored, as encoding/json does.
+	StrictJSON bool
+	// JSONCase is the key casing of success bodies (API_JSON_CASE): JSONCaseSnake (default, the
+	// struct tags as-is) or JSONCaseCamel. Errors, webhooks and the NDJSON export stay snake_case.
+	JSONCase string
+	// CartClearTimeout bounds the cart clear after an order commits (CART_CLEAR_TIMEOUT).
+	// Default DefaultCartClearTimeout.
+	CartClearTimeout time.Duration
+	// ShippingAggregationTimeout bounds the shipment fetch of GET /orders/:id/details and
+	// ?expand=shipment (SHIPPING_AGGREGATION_TIMEOUT). Default DefaultShippingAggregationTimeout.
+	ShippingAggregationTimeout time.Duration
+	// DetailsTimeout bounds the shared order lookup of GET /orders/:id/details, which runs detached
+	// from any one caller's request (HTTP_WRITE_TIMEOUT). Default DefaultDetailsTimeout.
+	DetailsTimeout time.Duration
+}
+
+// DefaultCartClearTimeout bounds the post-commit cart clear when HandlerConfig does not set one
+const DefaultCartClearTimeout = 5 * time.Second
+
+// DefaultShippingAggregationTimeout bounds the order-details shipment fetch when HandlerConfig does not set one
+const DefaultShippingAggregationTimeout = 2 * time.Second
+
+// DefaultDetailsTimeout bounds the shared order-details lookup when HandlerConfig does not set one;
+// it matches the HTTP_WRITE_TIMEOUT default, after which no caller could be answered anyway
+const DefaultDetailsTimeout = 60 * time.Second
+
+// withDefaults fills unset fields with package defaults
+func (cfg HandlerConfig) withDefaults() HandlerConfig {
+	if cfg.MaxPageSize <= 0 {
+		cfg.MaxPageSize = DefaultMaxPageSize
+	}
+	if cfg.DefaultPageSize <= 0 {
+		cfg.DefaultPageSize = DefaultPageSize
+	}
+	cfg.DefaultPageSize = min(cfg.DefaultPageSize, cfg.MaxPageSize)
+	if cfg.JSONCase == "" {
+		cfg.JSONCase = JSONCaseSnake
+	}
+	if cfg.CartClearTimeout <= 0 {
+		cfg.CartClearTimeout = DefaultCartClearTimeout
+	}
+	if cfg.ShippingAggregationTimeout <= 0 {
+		cfg.ShippingAggregationTimeout = DefaultShippingAggregationTimeout
+	}
+	if cfg.DetailsTimeout <= 0 {
+		cfg.DetailsTimeout = DefaultDetailsTimeout
+	}
+	return cfg
+}
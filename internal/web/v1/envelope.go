@@ -0,0 +1,63 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// ResponseEnvelope is the success body when HandlerConfig.ResponseEnvelope is set:
+// the handler's usual payload under data, list pagination under meta.
+// Error responses keep their {"error": ...} shape either way.
+type ResponseEnvelope struct {
+	Data any          `json:"data"`
+	Meta ResponseMeta `json:"meta"`
+}
+
+// ResponseMeta is empty ({}) for single resources and carries pagination for lists
+type ResponseMeta struct {
+	*PageMeta
+}
+
+// PageMeta describes the page returned by a list endpoint; Limit is the effective page size
+type PageMeta struct {
+	Total  int `json:"total"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+// respond writes a success response: body as-is, or wrapped as {"data": body, "meta": {}}
+func (cfg HandlerConfig) respond(c *gin.Context, status int, body any) {
+	if cfg.ResponseEnvelope {
+		body = ResponseEnvelope{Data: body}
+	}
+	cfg.writeJSON(c, status, body)
+}
+
+// respondPage writes one page of a list with pagination headers. The body is raw (whatever the
+// endpoint returned before the envelope existed: a bare array for GET /orders, a wrapper object
+// for others), or {"data": items, "meta": {...}}.
+func (cfg HandlerConfig) respondPage(c *gin.Context, page domain.Page, total int, items, raw any) {
+	setPaginationHeaders(c, page, total)
+	if cfg.ResponseEnvelope {
+		raw = ResponseEnvelope{
+			Data: items,
+			Meta: ResponseMeta{&PageMeta{Total: total, Limit: page.Limit, Offset: page.Offset}},
+		}
+	}
+	cfg.writeJSON(c, http.StatusOK, raw)
+}
+
+// writeJSON writes a success body in the configured JSONCase
+func (cfg HandlerConfig) writeJSON(c *gin.Context, status int, body any) {
+	if cfg.JSONCase == JSONCaseCamel {
+		camel, err := toCamelJSON(body)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+		body = camel
+	}
+	c.JSON(status, body)
+}
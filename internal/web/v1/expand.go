@@ -0,0 +1,82 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// expandableOrderFields is the whitelist of sub-resources ?expand= can inline into an order
+var expandableOrderFields = map[string]bool{
+	"shipment": true, // from the shipping service; null when there is none or it is unavailable
+	"history":  true, // status changes, oldest first
+}
+
+// orderExpansions are the sub-resources requested with ?expand=
+type orderExpansions struct {
+	shipment bool
+	history  bool
+}
+
+func (e orderExpansions) any() bool {
+	return e.shipment || e.history
+}
+
+// parseExpand reads the comma-separated ?expand= query param. Unlike ?fields=, an unknown value
+// is always rejected: the client expects the sub-resource in the response.
+func parseExpand(c *gin.Context) (orderExpansions, error) {
+	var e orderExpansions
+	for _, name := range strings.Split(c.Query("expand"), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch {
+		case name == "":
+		case !expandableOrderFields[name]:
+			return e, fmt.Errorf("unknown value %q in expand", name)
+		case name == "shipment":
+			e.shipment = true
+		case name == "history":
+			e.history = true
+		}
+	}
+	return e, nil
+}
+
+// expandOrder returns order as a JSON object of its selected fields, with the expanded
+// sub-resources added as top-level keys
+func expandOrder(
+	order *domain.Order, fields []string, e orderExpansions, shipment *Shipment, history []domain.StatusChange,
+) (map[string]json.RawMessage, error) {
+	object, err := orderObject(order, fields)
+	if err != nil {
+		return nil, err
+	}
+	if e.shipment {
+		if object["shipment"], err = json.Marshal(shipment); err != nil {
+			return nil, err
+		}
+	}
+	if e.history {
+		if history == nil {
+			history = []domain.StatusChange{}
+		}
+		if object["history"], err = json.Marshal(history); err != nil {
+			return nil, err
+		}
+	}
+	return object, nil
+}
+
+// fetchShipment looks up the order's shipment within ShippingAggregationTimeout, so a slow
+// shipping service only costs the shipment. Returns nil, nil without a shipping client.
+func (h *OrderHandler) fetchShipment(ctx context.Context, orderID string) (*Shipment, error) {
+	if h.shippingClient == nil {
+		return nil, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, h.cfg.ShippingAggregationTimeout)
+	defer cancel()
+	return h.shippingClient.GetShipmentByOrderID(ctx, orderID)
+}
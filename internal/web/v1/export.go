@@ -0,0 +1,100 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	logicv1 "github.com/duynhne/order-service/internal/logic/v1"
+	"github.com/duynhne/order-service/middleware"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// ndjsonContentType is the media type of newline-delimited JSON exports
+const ndjsonContentType = "application/x-ndjson"
+
+// exportWriteTimeout is how long an export may wait on a slow client for each line. The deadline
+// is pushed forward line by line, so a long export outlives the server's HTTP_WRITE_TIMEOUT
+// while a stalled client is still dropped.
+const exportWriteTimeout = 30 * time.Second
+
+// ExportOrders handles GET /order/v1/private/admin/orders/export?from=&to=
+// Streams orders created in [from, to) as newline-delimited JSON, one order (with items) per line.
+// from/to are RFC 3339 timestamps or YYYY-MM-DD dates (UTC midnight); the range is capped at
+// logicv1.MaxExportRange. Once streaming has started, a failure truncates the response.
+func (h *AdminHandler) ExportOrders(c *gin.Context) {
+	ctx, span := middleware.StartSpan(c.Request.Context(), "http.request", trace.WithAttributes(
+		attribute.String("layer", "web"),
+		attribute.String("method", c.Request.Method),
+		attribute.String("path", c.Request.URL.Path),
+		attribute.String("endpoint.type", "export"),
+	))
+	defer span.End()
+
+	zapLogger := middleware.GetLoggerFromGinContext(c)
+
+	from, errFrom := parseTimeOrDate(c.Query("from"))
+	to, errTo := parseTimeOrDate(c.Query("to"))
+	if errFrom != nil || errTo != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from and to must be RFC 3339 timestamps or YYYY-MM-DD dates"})
+		return
+	}
+
+	enc := json.NewEncoder(c.Writer)
+	rc := http.NewResponseController(c.Writer)
+	n, err := h.orderService.ExportOrders(ctx, from, to, func(order domain.Order) error {
+		// Not supported only without a real connection (tests); nothing to extend then
+		_ = rc.SetWriteDeadline(time.Now().Add(exportWriteTimeout))
+		if !c.Writer.Written() {
+			c.Header("Content-Type", ndjsonContentType)
+			c.Status(http.StatusOK)
+		}
+		if err := enc.Encode(order); err != nil {
+			return fmt.Errorf("write export line: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		zapLogger.Error("Order export failed", zap.Error(err), zap.Int("exported", n))
+
+		switch {
+		case c.Writer.Written():
+			// Headers are gone; the truncated stream is the only signal left
+		case errors.Is(err, logicv1.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("to must be after from and at most %s later", logicv1.MaxExportRange),
+			})
+		default:
+			respondInternalError(c, err)
+		}
+		return
+	}
+	if !c.Writer.Written() {
+		c.Header("Content-Type", ndjsonContentType)
+		c.Status(http.StatusOK)
+		c.Writer.WriteHeaderNow()
+	}
+
+	span.SetAttributes(attribute.Int("export.count", n))
+	zapLogger.Info("Orders exported",
+		zap.String("admin_id", authUserID(c)),
+		zap.Time("from", from),
+		zap.Time("to", to),
+		zap.Int("count", n),
+	)
+}
+
+// parseTimeOrDate parses an RFC 3339 timestamp or a YYYY-MM-DD date (UTC midnight)
+func parseTimeOrDate(raw string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, raw)
+}
@@ -0,0 +1,68 @@
+package v1
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	logicv1 "github.com/duynhne/order-service/internal/logic/v1"
+	"github.com/gin-gonic/gin"
+)
+
+// slowExportRepository takes delay to find the orders to export
+type slowExportRepository struct {
+	*fakeOrderRepository
+	delay  time.Duration
+	orders []domain.Order
+}
+
+func (r *slowExportRepository) FindCreatedBetween(ctx context.Context, from, to time.Time, after domain.OrderCursor, limit int) ([]domain.Order, error) {
+	if after.ID != "" {
+		return nil, nil
+	}
+	time.Sleep(r.delay)
+	return r.orders, nil
+}
+
+func (r *slowExportRepository) FindItemsByOrderIDs(ctx context.Context, orderIDs []string) (map[string][]domain.OrderItem, error) {
+	return map[string][]domain.OrderItem{}, nil
+}
+
+func TestExportOrdersOutlivesServerWriteTimeout(t *testing.T) {
+	const writeTimeout = 100 * time.Millisecond
+	repo := &slowExportRepository{
+		fakeOrderRepository: newFakeOrderRepository(),
+		delay:               3 * writeTimeout,
+		orders:              []domain.Order{{ID: "1", UserID: "user1"}, {ID: "2", UserID: "user2"}},
+	}
+	handler := NewAdminHandler(logicv1.NewOrderService(repo, fakeTransactionManager{}), HandlerConfig{})
+	router := gin.New()
+	router.GET("/export", handler.ExportOrders)
+
+	srv := httptest.NewUnstartedServer(router)
+	srv.Config.WriteTimeout = writeTimeout
+	srv.Start()
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/export?from=2026-01-01&to=2026-01-02")
+	if err != nil {
+		t.Fatalf("GET /export error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	lines := 0
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		lines++
+	}
+	if err := scanner.Err(); err != nil {
+		t.Errorf("export stream cut off: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || lines != len(repo.orders) {
+		t.Errorf("export = %d with %d lines, want 200 with %d", resp.StatusCode, lines, len(repo.orders))
+	}
+}
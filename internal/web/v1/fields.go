@@ -0,0 +1,113 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// selectableOrderFields is the whitelist of top-level order fields a client may pick with
+// ?fields=. It mirrors the JSON names of domain.Order; a field missing here can never be selected.
+var selectableOrderFields = map[string]bool{
+	"id":                 true,
+	"order_number":       true,
+	"user_id":            true,
+	"status":             true,
+	"priority":           true,
+	"items":              true,
+	"subtotal":           true,
+	"shipping":           true,
+	"tax":                true,
+	"discount":           true,
+	"total":              true,
+	"promotions":         true,
+	"total_weight":       true,
+	"created_at":         true,
+	"metadata":           true,
+	"shipping_address":   true,
+	"external_ref":       true,
+	"estimated_delivery": true,
+	"revision":           true,
+}
+
+// unknownFieldError is returned by parseFields for a non-selectable field in strict mode
+type unknownFieldError struct{ field string }
+
+func (e unknownFieldError) Error() string {
+	return fmt.Sprintf("unknown field %q in fields", e.field)
+}
+
+// parseFields reads the comma-separated ?fields= query param against selectableOrderFields.
+// Returns nil when the param is absent or names no selectable field, meaning "all fields".
+// Unknown fields are ignored, or rejected with unknownFieldError when StrictJSON is set.
+// With JSONCaseCamel, names are accepted in camelCase, as the client sees them.
+func (cfg HandlerConfig) parseFields(c *gin.Context) ([]string, error) {
+	raw, ok := c.GetQuery("fields")
+	if !ok {
+		return nil, nil
+	}
+
+	var fields []string
+	for _, f := range strings.Split(raw, ",") {
+		f = strings.TrimSpace(f)
+		if cfg.JSONCase == JSONCaseCamel {
+			f = camelToSnake(f)
+		}
+		switch {
+		case f == "":
+		case selectableOrderFields[f]:
+			fields = append(fields, f)
+		case cfg.StrictJSON:
+			return nil, unknownFieldError{field: f}
+		}
+	}
+	return fields, nil
+}
+
+// selectOrderFields returns order as a JSON object holding only fields. Fields omitted from the
+// order's JSON (omitempty) stay omitted. A nil fields selects everything and returns order as-is.
+func selectOrderFields(order *domain.Order, fields []string) (any, error) {
+	if fields == nil {
+		return order, nil
+	}
+	return orderObject(order, fields)
+}
+
+// orderObject returns order as a JSON object, holding only fields unless fields is nil
+func orderObject(order *domain.Order, fields []string) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(order)
+	if err != nil {
+		return nil, err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	if fields == nil {
+		return all, nil
+	}
+
+	selected := make(map[string]json.RawMessage, len(fields))
+	for _, f := range fields {
+		if v, ok := all[f]; ok {
+			selected[f] = v
+		}
+	}
+	return selected, nil
+}
+
+// selectOrdersFields applies selectOrderFields to each order of a list, preserving order
+func selectOrdersFields(orders []domain.Order, fields []string) ([]any, error) {
+	selected := make([]any, len(orders))
+	for i := range orders {
+		s, err := selectOrderFields(&orders[i], fields)
+		if err != nil {
+			return nil, err
+		}
+		selected[i] = s
+	}
+	return selected, nil
+}
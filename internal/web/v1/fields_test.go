@@ -0,0 +1,143 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	logicv1 "github.com/duynhne/order-service/internal/logic/v1"
+	"github.com/gin-gonic/gin"
+)
+
+func TestParseFields(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		cfg         HandlerConfig
+		want        []string
+		wantUnknown string
+	}{
+		{name: "No fields param", query: "", want: nil},
+		{name: "Selected fields in request order", query: "?fields=status,id", want: []string{"status", "id"}},
+		{name: "Whitespace and empty entries", query: "?fields=+id+,,status,", want: []string{"id", "status"}},
+		{name: "Duplicate entries", query: "?fields=id,id,status", want: []string{"id", "id", "status"}},
+		{name: "Only empty entries", query: "?fields=,,", want: nil},
+		{name: "Unknown field ignored", query: "?fields=id,password", want: []string{"id"}},
+		{name: "Only unknown fields select everything", query: "?fields=password", want: nil},
+		{name: "Unknown field in strict mode", query: "?fields=id,password", cfg: HandlerConfig{StrictJSON: true}, wantUnknown: "password"},
+		{name: "Empty entries in strict mode", query: "?fields=id,,status", cfg: HandlerConfig{StrictJSON: true}, want: []string{"id", "status"}},
+		{name: "camelCase names", query: "?fields=orderNumber,totalWeight", cfg: HandlerConfig{JSONCase: JSONCaseCamel}, want: []string{"order_number", "total_weight"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/orders"+tt.query, nil)
+
+			got, err := tt.cfg.parseFields(c)
+			if tt.wantUnknown != "" {
+				var unknown unknownFieldError
+				if !errors.As(err, &unknown) || unknown.field != tt.wantUnknown {
+					t.Errorf("parseFields() error = %v, want unknown field %q", err, tt.wantUnknown)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseFields() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("parseFields() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectOrderFields(t *testing.T) {
+	order := &domain.Order{ID: "1", UserID: "user1", Status: domain.OrderStatusPaid, Total: 12.5}
+
+	if got, err := selectOrderFields(order, nil); err != nil || got != any(order) {
+		t.Errorf("selectOrderFields(nil) = %v, %v; want the order itself", got, err)
+	}
+
+	got, err := selectOrderFields(order, []string{"status", "total", "total", "metadata"})
+	if err != nil {
+		t.Fatalf("selectOrderFields() error = %v", err)
+	}
+	data, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("marshal selection: %v", err)
+	}
+	// metadata is omitempty on the order, so it stays left out
+	if want := `{"status":"paid","total":12.5}`; string(data) != want {
+		t.Errorf("selection = %s, want %s", data, want)
+	}
+}
+
+func TestListOrdersFields(t *testing.T) {
+	orders := []domain.Order{
+		{ID: "1", UserID: "user1", Status: domain.OrderStatusPending, Total: 10},
+		{ID: "2", UserID: "user1", Status: domain.OrderStatusPaid, Total: 20},
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		strict     bool
+		wantStatus int
+		want       []map[string]any
+	}{
+		{
+			name:       "Projection of every order",
+			query:      "?fields=id,status",
+			wantStatus: http.StatusOK,
+			want:       []map[string]any{{"id": "1", "status": "pending"}, {"id": "2", "status": "paid"}},
+		},
+		{
+			name:       "Unknown field ignored",
+			query:      "?fields=total,secret",
+			wantStatus: http.StatusOK,
+			want:       []map[string]any{{"total": 10.0}, {"total": 20.0}},
+		},
+		{name: "Unknown field in strict mode", query: "?fields=total,secret", strict: true, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := logicv1.NewOrderService(newFakeOrderRepository(orders...), fakeTransactionManager{})
+			handler := NewOrderHandler(service, nil, nil, nil, HandlerConfig{DefaultPageSize: 10, MaxPageSize: 10, StrictJSON: tt.strict})
+			router := gin.New()
+			router.GET("/orders", asUser("user1"), handler.ListOrders)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders"+tt.query, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var got []map[string]any
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("body = %s, want %v", w.Body, tt.want)
+			}
+			for i := range got {
+				if len(got[i]) != len(tt.want[i]) {
+					t.Errorf("order %d = %v, want %v", i, got[i], tt.want[i])
+				}
+				for k, v := range tt.want[i] {
+					if got[i][k] != v {
+						t.Errorf("order %d %s = %v, want %v", i, k, got[i][k], v)
+					}
+				}
+			}
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package v1
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/duynhne/order-service/internal/core/domain"
@@ -11,16 +13,73 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
 )
 
-// OrderHandler holds the order service dependency
+// ErrCodeBelowMinimumTotal is the error code returned when an order is below ORDER_MIN_TOTAL
+const ErrCodeBelowMinimumTotal = "ORDER_BELOW_MINIMUM_TOTAL"
+
+// ErrCodeTooManyProducts is the error code returned when an order exceeds ORDER_MAX_DISTINCT_PRODUCTS
+const ErrCodeTooManyProducts = "ORDER_TOO_MANY_PRODUCTS"
+
+// ErrCodeCartEmpty is the error code returned when an order is requested from an empty cart
+const ErrCodeCartEmpty = "ORDER_CART_EMPTY"
+
+// ErrCodeInsufficientStock is the error code returned when the stock pre-check finds short items
+const ErrCodeInsufficientStock = "ORDER_INSUFFICIENT_STOCK"
+
+// authUserID returns the caller's user ID from the request's domain.AuthContext (set by
+// middleware.AuthMiddleware), or "" for an unauthenticated request
+func authUserID(c *gin.Context) string {
+	auth, _ := domain.AuthFromContext(c.Request.Context())
+	return auth.UserID
+}
+
+// OrderHandler holds the order service and downstream client dependencies.
+// shippingClient and cartClient are optional; a nil client disables the
+// corresponding aggregation or best-effort call.
 type OrderHandler struct {
-	orderService *logicv1.OrderService
+	orderService   *logicv1.OrderService
+	shippingClient *ShippingClient
+	cartClient     *CartClient
+	createQueue    *logicv1.OrderQueue // optional; non-nil switches CreateOrder to async (202)
+	cfg            HandlerConfig
+	detailsGroup   singleflight.Group // dedupes concurrent GetOrderDetails calls per order ID
 }
 
-// NewOrderHandler creates a new order handler with dependency injection
-func NewOrderHandler(orderService *logicv1.OrderService) *OrderHandler {
-	return &OrderHandler{orderService: orderService}
+// NewOrderHandler creates a new order handler with dependency injection.
+// createQueue is optional; when nil, orders are created synchronously.
+func NewOrderHandler(
+	orderService *logicv1.OrderService,
+	shippingClient *ShippingClient,
+	cartClient *CartClient,
+	createQueue *logicv1.OrderQueue,
+	cfg HandlerConfig,
+) *OrderHandler {
+	return &OrderHandler{
+		orderService:   orderService,
+		shippingClient: shippingClient,
+		cartClient:     cartClient,
+		createQueue:    createQueue,
+		cfg:            cfg.withDefaults(),
+	}
+}
+
+// respondOrderLookupError writes the response for a failed single-order lookup
+// (invalid ID, missing order, purged order per GoneForPurged, or another user's order per RevealForbidden)
+func (h *OrderHandler) respondOrderLookupError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, logicv1.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order ID"})
+	case errors.Is(err, logicv1.ErrUnauthorized) && h.cfg.RevealForbidden:
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access to this order is forbidden"})
+	case errors.Is(err, logicv1.ErrOrderGone) && h.cfg.GoneForPurged:
+		c.JSON(http.StatusGone, gin.H{"error": "Order has been removed"})
+	case errors.Is(err, logicv1.ErrOrderNotFound), errors.Is(err, logicv1.ErrUnauthorized):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Order not found"})
+	default:
+		respondInternalError(c, err)
+	}
 }
 
 func (h *OrderHandler) ListOrders(c *gin.Context) {
@@ -34,23 +93,48 @@ func (h *OrderHandler) ListOrders(c *gin.Context) {
 	zapLogger := middleware.GetLoggerFromGinContext(c)
 
 	// Get userID from auth context (required - no fallback)
-	userID := c.GetString("user_id")
+	userID := authUserID(c)
 	if userID == "" {
 		zapLogger.Warn("ListOrders: no user_id in context")
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
 		return
 	}
 
-	orders, err := h.orderService.ListOrders(ctx, userID)
+	page, err := h.cfg.parsePage(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pagination parameters"})
+		return
+	}
+	fields, err := h.cfg.parseFields(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	opts := logicv1.ListOptions{IncludeItems: includes(c, "items"), IncludeDrafts: includes(c, "drafts")}
+	orders, total, err := h.orderService.ListOrders(ctx, userID, page, opts)
 	if err != nil {
 		span.RecordError(err)
 		zapLogger.Error("Failed to list orders", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		respondInternalError(c, err)
 		return
 	}
 
-	zapLogger.Info("Orders listed", zap.Int("count", len(orders)))
-	c.JSON(http.StatusOK, orders)
+	zapLogger.Info("Orders listed", zap.Int("count", len(orders)), zap.Int("total", total))
+	if fields != nil {
+		selected, err := selectOrdersFields(orders, fields)
+		if err != nil {
+			span.RecordError(err)
+			zapLogger.Error("Failed to select order fields", zap.Error(err))
+			respondInternalError(c, err)
+			return
+		}
+		h.cfg.respondPage(c, page, total, selected, selected)
+		return
+	}
+	// The body stays the bare array existing clients parse; page metadata is in the headers
+	// (and in meta with API_RESPONSE_ENVELOPE)
+	h.cfg.respondPage(c, page, total, orders, orders)
 }
 
 func (h *OrderHandler) GetOrder(c *gin.Context) {
@@ -65,22 +149,222 @@ func (h *OrderHandler) GetOrder(c *gin.Context) {
 	id := c.Param("id")
 	span.SetAttributes(attribute.String("order.id", id))
 
-	order, err := h.orderService.GetOrder(ctx, id)
+	userID := authUserID(c)
+	if userID == "" {
+		zapLogger.Warn("GetOrder: no user_id in context")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+	fields, err := h.cfg.parseFields(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	expand, err := parseExpand(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	span.SetAttributes(
+		attribute.Bool("expand.shipment", expand.shipment),
+		attribute.Bool("expand.history", expand.history),
+	)
+
+	var (
+		order   *domain.Order
+		history []domain.StatusChange
+	)
+	if expand.history {
+		order, history, err = h.orderService.GetStatusHistory(ctx, id, userID)
+	} else {
+		order, err = h.orderService.GetUserOrder(ctx, id, userID)
+	}
 	if err != nil {
 		span.RecordError(err)
 		zapLogger.Error("Failed to get order", zap.Error(err))
+		h.respondOrderLookupError(c, err)
+		return
+	}
+
+	// An expanded shipment is optional like on /details: a failed lookup is logged and inlined as null
+	var shipment *Shipment
+	if expand.shipment {
+		if shipment, err = h.fetchShipment(ctx, id); err != nil {
+			zapLogger.Warn("Could not fetch shipment", zap.Error(err), zap.String("order_id", id))
+			span.SetAttributes(attribute.Bool("shipment.fetch_error", true))
+		}
+		order = withShipmentEstimate(order, shipment)
+	}
+
+	var body any
+	if expand.any() {
+		body, err = expandOrder(order, fields, expand, shipment, history)
+	} else {
+		body, err = selectOrderFields(order, fields)
+	}
+	if err != nil {
+		span.RecordError(err)
+		zapLogger.Error("Failed to select order fields", zap.Error(err))
+		respondInternalError(c, err)
+		return
+	}
+
+	zapLogger.Info("Order retrieved", zap.String("order_id", id))
+	h.cfg.respond(c, http.StatusOK, body)
+}
 
+// GetOrderByExternalRef handles GET /order/v1/private/orders/by-ref/:ref
+// Looks up the caller's order by the external_ref it was created with.
+func (h *OrderHandler) GetOrderByExternalRef(c *gin.Context) {
+	ctx, span := middleware.StartSpan(c.Request.Context(), "http.request", trace.WithAttributes(
+		attribute.String("layer", "web"),
+		attribute.String("method", c.Request.Method),
+		attribute.String("path", c.Request.URL.Path),
+	))
+	defer span.End()
+
+	zapLogger := middleware.GetLoggerFromGinContext(c)
+	ref := c.Param("ref")
+
+	userID := authUserID(c)
+	if userID == "" {
+		zapLogger.Warn("GetOrderByExternalRef: no user_id in context")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	order, err := h.orderService.GetOrderByExternalRef(ctx, userID, ref)
+	if err != nil {
+		span.RecordError(err)
+		zapLogger.Error("Failed to get order by external ref", zap.Error(err))
 		switch {
+		case errors.Is(err, logicv1.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid external reference"})
 		case errors.Is(err, logicv1.ErrOrderNotFound):
 			c.JSON(http.StatusNotFound, gin.H{"error": "Order not found"})
 		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			respondInternalError(c, err)
 		}
 		return
 	}
 
-	zapLogger.Info("Order retrieved", zap.String("order_id", id))
-	c.JSON(http.StatusOK, order)
+	span.SetAttributes(attribute.String("order.id", order.ID))
+	zapLogger.Info("Order retrieved by external ref", zap.String("order_id", order.ID))
+	h.cfg.respond(c, http.StatusOK, order)
+}
+
+// GetOrderByNumber handles GET /order/v1/private/orders/by-number/:number
+// Looks up the caller's order by its human-friendly order number (ORD-2026-000123).
+func (h *OrderHandler) GetOrderByNumber(c *gin.Context) {
+	ctx, span := middleware.StartSpan(c.Request.Context(), "http.request", trace.WithAttributes(
+		attribute.String("layer", "web"),
+		attribute.String("method", c.Request.Method),
+		attribute.String("path", c.Request.URL.Path),
+	))
+	defer span.End()
+
+	zapLogger := middleware.GetLoggerFromGinContext(c)
+	number := c.Param("number")
+
+	userID := authUserID(c)
+	if userID == "" {
+		zapLogger.Warn("GetOrderByNumber: no user_id in context")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	order, err := h.orderService.GetOrderByNumber(ctx, number, userID)
+	if err != nil {
+		span.RecordError(err)
+		zapLogger.Error("Failed to get order by number", zap.Error(err))
+		if errors.Is(err, logicv1.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order number"})
+			return
+		}
+		h.respondOrderLookupError(c, err)
+		return
+	}
+
+	span.SetAttributes(attribute.String("order.id", order.ID))
+	zapLogger.Info("Order retrieved by number", zap.String("order_id", order.ID))
+	h.cfg.respond(c, http.StatusOK, order)
+}
+
+// ExternalRefsRequest is the body of POST .../orders/by-refs
+type ExternalRefsRequest struct {
+	ExternalRefs []string `json:"external_refs" binding:"required"`
+}
+
+// ExternalRefsResponse lists the caller's orders matching the requested references, with the
+// references that matched no order in missing (request order, duplicates removed)
+type ExternalRefsResponse struct {
+	Orders  []domain.Order `json:"orders"`
+	Missing []string       `json:"missing"`
+}
+
+// GetOrdersByExternalRefs handles POST /order/v1/private/orders/by-refs
+// Bulk variant of GetOrderByExternalRef for reconciliation: at most logicv1.MaxExternalRefsPerLookup references.
+func (h *OrderHandler) GetOrdersByExternalRefs(c *gin.Context) {
+	ctx, span := middleware.StartSpan(c.Request.Context(), "http.request", trace.WithAttributes(
+		attribute.String("layer", "web"),
+		attribute.String("method", c.Request.Method),
+		attribute.String("path", c.Request.URL.Path),
+	))
+	defer span.End()
+
+	zapLogger := middleware.GetLoggerFromGinContext(c)
+
+	var req ExternalRefsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.SetAttributes(attribute.Bool("request.valid", false))
+		span.RecordError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": bindErrorMessage(err)})
+		return
+	}
+
+	userID := authUserID(c)
+	if userID == "" {
+		zapLogger.Warn("GetOrdersByExternalRefs: no user_id in context")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	orders, err := h.orderService.GetOrdersByExternalRefs(ctx, userID, req.ExternalRefs)
+	if err != nil {
+		span.RecordError(err)
+		zapLogger.Error("Failed to get orders by external refs", zap.Error(err))
+		switch {
+		case errors.Is(err, logicv1.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("external_refs must hold 1 to %d valid references", logicv1.MaxExternalRefsPerLookup),
+			})
+		default:
+			respondInternalError(c, err)
+		}
+		return
+	}
+
+	found := make(map[string]bool, len(orders))
+	for _, order := range orders {
+		found[order.ExternalRef] = true
+	}
+	resp := ExternalRefsResponse{Orders: orders, Missing: []string{}}
+	if resp.Orders == nil {
+		resp.Orders = []domain.Order{}
+	}
+	for _, ref := range req.ExternalRefs {
+		if !found[ref] {
+			found[ref] = true // report each missing reference once
+			resp.Missing = append(resp.Missing, ref)
+		}
+	}
+
+	span.SetAttributes(attribute.Int("orders.count", len(orders)), attribute.Int("refs.missing", len(resp.Missing)))
+	zapLogger.Info("Orders retrieved by external refs",
+		zap.Int("requested", len(req.ExternalRefs)),
+		zap.Int("found", len(orders)),
+	)
+	h.cfg.respond(c, http.StatusOK, resp)
 }
 
 func (h *OrderHandler) CreateOrder(c *gin.Context) {
@@ -94,16 +378,16 @@ func (h *OrderHandler) CreateOrder(c *gin.Context) {
 	zapLogger := middleware.GetLoggerFromGinContext(c)
 
 	var req domain.CreateOrderRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := h.cfg.bindCreateOrderRequest(c, &req); err != nil {
 		span.SetAttributes(attribute.Bool("request.valid", false))
 		span.RecordError(err)
 		zapLogger.Error("Invalid request", zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": sanitizeValidationError(err)})
+		c.JSON(http.StatusBadRequest, gin.H{"error": bindErrorMessage(err)})
 		return
 	}
 
 	// Inject user_id from auth context - never trust client
-	userID := c.GetString("user_id")
+	userID := authUserID(c)
 	if userID == "" {
 		zapLogger.Warn("CreateOrder: no user_id in context")
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
@@ -112,56 +396,534 @@ func (h *OrderHandler) CreateOrder(c *gin.Context) {
 	req.UserID = userID
 
 	span.SetAttributes(attribute.Bool("request.valid", true))
+	if h.createQueue != nil {
+		h.enqueueOrder(c, req)
+		return
+	}
+
 	order, err := h.orderService.CreateOrder(ctx, req)
 	if err != nil {
 		span.RecordError(err)
 		zapLogger.Error("Failed to create order", zap.Error(err))
+		respondCreateOrderError(c, err)
+		return
+	}
+
+	zapLogger.Info("Order created", zap.String("order_id", order.ID))
+
+	h.clearCart(ctx, c.GetHeader("Authorization"), order, zapLogger)
+
+	h.cfg.respond(c, http.StatusCreated, order)
+}
+
+// CreateOrderFromCartRequest is the optional body of POST .../orders/from-cart: everything of
+// an order except its items, which come from the caller's cart
+type CreateOrderFromCartRequest struct {
+	Metadata map[string]string `json:"metadata"`
+	// Priority is "standard" (default when empty) or "express"
+	Priority        string                  `json:"priority"`
+	ShippingAddress *domain.ShippingAddress `json:"shipping_address"`
+	ExternalRef     string                  `json:"external_ref"`
+}
+
+// CreateOrderFromCart handles POST /order/v1/private/orders/from-cart
+// Fetches the caller's cart from the cart service, creates the order from its items, then clears
+// the cart like CreateOrder. Always synchronous, even with the async create queue enabled.
+func (h *OrderHandler) CreateOrderFromCart(c *gin.Context) {
+	ctx, span := middleware.StartSpan(c.Request.Context(), "http.request", trace.WithAttributes(
+		attribute.String("layer", "web"),
+		attribute.String("method", c.Request.Method),
+		attribute.String("path", c.Request.URL.Path),
+	))
+	defer span.End()
+
+	zapLogger := middleware.GetLoggerFromGinContext(c)
+
+	var body CreateOrderFromCartRequest
+	if c.Request.ContentLength != 0 {
+		var err error
+		if h.cfg.StrictJSON {
+			err = c.ShouldBindWith(&body, strictJSONBinding{})
+		} else {
+			err = c.ShouldBindJSON(&body)
+		}
+		if err != nil {
+			span.SetAttributes(attribute.Bool("request.valid", false))
+			span.RecordError(err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": bindErrorMessage(err)})
+			return
+		}
+	}
+
+	userID := authUserID(c)
+	if userID == "" {
+		zapLogger.Warn("CreateOrderFromCart: no user_id in context")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+	if h.cartClient == nil {
+		zapLogger.Warn("CreateOrderFromCart: cart client not initialized")
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Cart service is not configured"})
+		return
+	}
+
+	authHeader := c.GetHeader("Authorization")
+	cart, err := h.cartClient.GetCart(ctx, authHeader)
+	if err != nil {
+		span.RecordError(err)
+		zapLogger.Error("Failed to fetch cart", zap.Error(err))
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Could not fetch cart"})
+		return
+	}
+	span.SetAttributes(attribute.Int("cart.items", len(cart)))
+
+	order, err := h.orderService.CreateOrderFromCart(ctx, domain.CreateOrderRequest{
+		UserID:          userID,
+		Metadata:        body.Metadata,
+		Priority:        body.Priority,
+		ShippingAddress: body.ShippingAddress,
+		ExternalRef:     body.ExternalRef,
+	}, cart)
+	if err != nil {
+		span.RecordError(err)
+		zapLogger.Error("Failed to create order from cart", zap.Error(err))
+		respondCreateOrderError(c, err)
+		return
+	}
+
+	zapLogger.Info("Order created from cart", zap.String("order_id", order.ID))
+
+	h.clearCart(ctx, authHeader, order, zapLogger)
+
+	h.cfg.respond(c, http.StatusCreated, order)
+}
+
+// databaseBusyRetryAfter is the Retry-After hint (seconds) sent with 503 when no database connection was free
+const databaseBusyRetryAfter = "2"
+
+// ErrCodeOutcomeUnknown is the error code returned when a change may or may not have been saved
+const ErrCodeOutcomeUnknown = "OUTCOME_UNKNOWN"
+
+// respondInternalError writes the response for an error the handler has no specific mapping for:
+// 503 with Retry-After when the database connection pool is exhausted (ErrDatabaseBusy), else 500.
+// A 500 for a commit that lost its connection says so, since blindly repeating could apply it twice.
+func respondInternalError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, logicv1.ErrDatabaseBusy), errors.Is(err, logicv1.ErrRetryable):
+		c.Header("Retry-After", databaseBusyRetryAfter)
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Database busy, please retry"})
+	case errors.Is(err, logicv1.ErrCommitUnknown):
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "The change may or may not have been saved; check before retrying",
+			"code":  ErrCodeOutcomeUnknown,
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+	}
+}
+
+// respondCreateOrderError writes the error response for a failed order creation.
+// Short stock lists every unavailable item so clients can adjust the whole cart at once.
+func respondCreateOrderError(c *gin.Context, err error) {
+	var stockErr *logicv1.InsufficientStockError
+	switch {
+	case errors.Is(err, logicv1.ErrInvalidOrder):
+		respondInvalidOrder(c, err)
+	case errors.Is(err, logicv1.ErrDuplicateExternalRef):
+		c.JSON(http.StatusConflict, gin.H{"error": "An order with this external_ref already exists"})
+	case errors.As(err, &stockErr):
+		c.JSON(http.StatusConflict, gin.H{
+			"error":             "Insufficient stock",
+			"code":              ErrCodeInsufficientStock,
+			"unavailable_items": stockErr.Shortages,
+		})
+	default:
+		respondInternalError(c, err)
+	}
+}
+
+// respondInvalidOrder writes the 400 response for an order rejected by validation or pricing.
+// A below-minimum subtotal or too many distinct products gets a machine-readable code and the
+// limit so clients can prompt the user; so does an empty cart.
+func respondInvalidOrder(c *gin.Context, err error) {
+	if errors.Is(err, logicv1.ErrEmptyCart) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Cart is empty", "code": ErrCodeCartEmpty})
+		return
+	}
+	var minErr *logicv1.BelowMinimumTotalError
+	if errors.As(err, &minErr) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":         "Order subtotal is below the minimum order total",
+			"code":          ErrCodeBelowMinimumTotal,
+			"minimum_total": minErr.Minimum,
+		})
+		return
+	}
+	var productsErr *logicv1.TooManyProductsError
+	if errors.As(err, &productsErr) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":                 "Order has too many distinct products",
+			"code":                  ErrCodeTooManyProducts,
+			"max_distinct_products": productsErr.Maximum,
+		})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order"})
+}
+
+// clearCart clears the caller's cart after an order is committed, retrying transient failures.
+// Best-effort: do NOT fail the order if cart clearing fails (order is already committed);
+// a clear that still fails is dead-lettered for the reconciliation job.
+// It runs detached from the request (bounded by CartClearTimeout), so a client that disconnects
+// once the order is committed does not cancel the clear.
+// A draft keeps the cart until ConfirmOrder places it, so a draft that expires leaves the cart intact.
+func (h *OrderHandler) clearCart(ctx context.Context, authHeader string, order *domain.Order, zapLogger *zap.Logger) {
+	ctx = context.WithoutCancel(ctx)
+	span := trace.SpanFromContext(ctx)
+	switch {
+	case order.Status == domain.OrderStatusDraft:
+		span.SetAttributes(attribute.Bool("cart.clear_deferred", true))
+	case h.cartClient == nil:
+		zapLogger.Warn("Cart client not initialized")
+	case !isBearerAuthorization(authHeader):
+		// Don't forward a header the cart service will reject anyway.
+		span.SetAttributes(attribute.Bool("cart.clear_skipped", true))
+		zapLogger.Warn("Skipping cart clear: Authorization header is not a well-formed bearer token")
+	default:
+		clearCtx, cancel := context.WithTimeout(ctx, h.cfg.CartClearTimeout)
+		attempts, err := h.cartClient.ClearCartWithRetry(clearCtx, authHeader)
+		cancel()
+		span.SetAttributes(attribute.Int("cart.clear_attempts", attempts))
+		if err == nil {
+			return
+		}
+		span.RecordError(err)
+		zapLogger.Warn("Best-effort cart clear failed",
+			zap.Error(err),
+			zap.String("order_id", order.ID),
+			zap.Int("attempts", attempts),
+		)
+		if err := h.orderService.RecordFailedCartClear(ctx, order.UserID, order.ID, err); err != nil {
+			zapLogger.Error("Failed to record failed cart clear", zap.Error(err), zap.String("order_id", order.ID))
+		}
+	}
+}
+
+func (h *OrderHandler) QuoteOrder(c *gin.Context) {
+	ctx, span := middleware.StartSpan(c.Request.Context(), "http.request", trace.WithAttributes(
+		attribute.String("layer", "web"),
+		attribute.String("method", c.Request.Method),
+		attribute.String("path", c.Request.URL.Path),
+	))
+	defer span.End()
+
+	zapLogger := middleware.GetLoggerFromGinContext(c)
+
+	var req domain.CreateOrderRequest
+	if err := h.cfg.bindCreateOrderRequest(c, &req); err != nil {
+		span.SetAttributes(attribute.Bool("request.valid", false))
+		span.RecordError(err)
+		zapLogger.Error("Invalid request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": bindErrorMessage(err)})
+		return
+	}
+	req.UserID = authUserID(c)
+
+	span.SetAttributes(attribute.Bool("request.valid", true))
+	quote, err := h.orderService.QuoteOrder(ctx, req)
+	if err != nil {
+		span.RecordError(err)
+		zapLogger.Error("Failed to quote order", zap.Error(err))
 
 		switch {
 		case errors.Is(err, logicv1.ErrInvalidOrder):
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order"})
+			respondInvalidOrder(c, err)
 		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			respondInternalError(c, err)
 		}
 		return
 	}
 
-	zapLogger.Info("Order created", zap.String("order_id", order.ID))
+	h.cfg.respond(c, http.StatusOK, quote)
+}
 
-	// Best-effort: clear cart after successful order creation.
-	// Do NOT fail the order if cart clearing fails (order is already committed).
-	if client := getCartClient(zapLogger); client != nil {
-		authHeader := c.GetHeader("Authorization")
-		if err := client.ClearCart(ctx, authHeader); err != nil {
-			span.RecordError(err)
-			zapLogger.Warn("Best-effort cart clear failed", zap.Error(err))
+// EstimateShipping handles POST /order/v1/private/shipping/estimate
+// Returns the shipping charge and estimated delivery of a cart (same body as POST /orders) without creating an order.
+func (h *OrderHandler) EstimateShipping(c *gin.Context) {
+	ctx, span := middleware.StartSpan(c.Request.Context(), "http.request", trace.WithAttributes(
+		attribute.String("layer", "web"),
+		attribute.String("method", c.Request.Method),
+		attribute.String("path", c.Request.URL.Path),
+	))
+	defer span.End()
+
+	zapLogger := middleware.GetLoggerFromGinContext(c)
+
+	var req domain.CreateOrderRequest
+	if err := h.cfg.bindCreateOrderRequest(c, &req); err != nil {
+		span.SetAttributes(attribute.Bool("request.valid", false))
+		span.RecordError(err)
+		zapLogger.Error("Invalid request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": bindErrorMessage(err)})
+		return
+	}
+	req.UserID = authUserID(c)
+
+	span.SetAttributes(attribute.Bool("request.valid", true))
+	estimate, err := h.orderService.EstimateShipping(ctx, req)
+	if err != nil {
+		span.RecordError(err)
+		zapLogger.Error("Failed to estimate shipping", zap.Error(err))
+
+		switch {
+		case errors.Is(err, logicv1.ErrInvalidOrder):
+			respondInvalidOrder(c, err)
+		default:
+			respondInternalError(c, err)
 		}
+		return
+	}
+
+	h.cfg.respond(c, http.StatusOK, estimate)
+}
+
+// GetOrderActions handles GET /order/v1/private/orders/:id/actions
+// Returns the statuses/actions allowed next for the caller's order (from the central transition table).
+func (h *OrderHandler) GetOrderActions(c *gin.Context) {
+	ctx, span := middleware.StartSpan(c.Request.Context(), "http.request", trace.WithAttributes(
+		attribute.String("layer", "web"),
+		attribute.String("method", c.Request.Method),
+		attribute.String("path", c.Request.URL.Path),
+	))
+	defer span.End()
+
+	zapLogger := middleware.GetLoggerFromGinContext(c)
+	id := c.Param("id")
+	span.SetAttributes(attribute.String("order.id", id))
+
+	userID := authUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	actions, err := h.orderService.GetOrderActions(ctx, id, userID)
+	if err != nil {
+		span.RecordError(err)
+		zapLogger.Warn("Failed to get order actions", zap.Error(err))
+		h.respondOrderLookupError(c, err)
+		return
 	}
 
-	c.JSON(http.StatusCreated, order)
+	h.cfg.respond(c, http.StatusOK, actions)
 }
 
-// Legacy function wrappers for backward compatibility
-var handler *OrderHandler
+// GetOrderStatus handles GET /order/v1/private/orders/:id/status
+// Returns only {status, updated_at} of the caller's order, a cheap target for status polling.
+func (h *OrderHandler) GetOrderStatus(c *gin.Context) {
+	ctx, span := middleware.StartSpan(c.Request.Context(), "http.request", trace.WithAttributes(
+		attribute.String("layer", "web"),
+		attribute.String("method", c.Request.Method),
+		attribute.String("path", c.Request.URL.Path),
+	))
+	defer span.End()
+
+	zapLogger := middleware.GetLoggerFromGinContext(c)
+	id := c.Param("id")
+	span.SetAttributes(attribute.String("order.id", id))
+
+	userID := authUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	info, err := h.orderService.GetOrderStatus(ctx, id, userID)
+	if err != nil {
+		span.RecordError(err)
+		zapLogger.Warn("Failed to get order status", zap.Error(err))
+		h.respondOrderLookupError(c, err)
+		return
+	}
+
+	h.cfg.respond(c, http.StatusOK, info)
+}
+
+// GetOrderItems handles GET /order/v1/private/orders/:id/items
+// Returns only the line items of the caller's order, for clients that do not need the full order.
+func (h *OrderHandler) GetOrderItems(c *gin.Context) {
+	ctx, span := middleware.StartSpan(c.Request.Context(), "http.request", trace.WithAttributes(
+		attribute.String("layer", "web"),
+		attribute.String("method", c.Request.Method),
+		attribute.String("path", c.Request.URL.Path),
+	))
+	defer span.End()
+
+	zapLogger := middleware.GetLoggerFromGinContext(c)
+	id := c.Param("id")
+	span.SetAttributes(attribute.String("order.id", id))
 
-func SetOrderService(orderService *logicv1.OrderService) {
-	handler = NewOrderHandler(orderService)
+	userID := authUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	items, err := h.orderService.GetOrderItems(ctx, id, userID)
+	if err != nil {
+		span.RecordError(err)
+		zapLogger.Warn("Failed to get order items", zap.Error(err))
+		h.respondOrderLookupError(c, err)
+		return
+	}
+
+	h.cfg.respond(c, http.StatusOK, items)
 }
 
-func ListOrders(c *gin.Context) {
-	if handler != nil {
-		handler.ListOrders(c)
+// CancelOrderItem handles POST /order/v1/private/orders/:id/items/:product_id/cancel
+// Cancels one product's items in the caller's order before it ships; cancelling the last item cancels the order.
+func (h *OrderHandler) CancelOrderItem(c *gin.Context) {
+	ctx, span := middleware.StartSpan(c.Request.Context(), "http.request", trace.WithAttributes(
+		attribute.String("layer", "web"),
+		attribute.String("method", c.Request.Method),
+		attribute.String("path", c.Request.URL.Path),
+	))
+	defer span.End()
+
+	zapLogger := middleware.GetLoggerFromGinContext(c)
+	id := c.Param("id")
+	productID := c.Param("product_id")
+	span.SetAttributes(
+		attribute.String("order.id", id),
+		attribute.String("item.product_id", productID),
+	)
+
+	userID := authUserID(c)
+	if userID == "" {
+		zapLogger.Warn("CancelOrderItem: no user_id in context")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	order, err := h.orderService.CancelOrderItem(ctx, id, productID, userID)
+	if err != nil {
+		span.RecordError(err)
+		zapLogger.Warn("Failed to cancel order item", zap.Error(err))
+
+		switch {
+		case errors.Is(err, logicv1.ErrItemNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Order item not found"})
+		case errors.Is(err, logicv1.ErrInvalidOrderState):
+			c.JSON(http.StatusConflict, gin.H{"error": "Items can only be cancelled before the order ships"})
+		default:
+			h.respondOrderLookupError(c, err)
+		}
+		return
 	}
+
+	zapLogger.Info("Order item cancelled",
+		zap.String("order_id", id),
+		zap.String("product_id", productID),
+		zap.String("status", order.Status.String()),
+	)
+	h.cfg.respond(c, http.StatusOK, order)
 }
 
-func GetOrder(c *gin.Context) {
-	if handler != nil {
-		handler.GetOrder(c)
+// ConfirmOrder handles POST /order/v1/private/orders/:id/confirm
+// Places the caller's draft order (draft -> pending). Confirming an already placed order answers 200 again.
+func (h *OrderHandler) ConfirmOrder(c *gin.Context) {
+	ctx, span := middleware.StartSpan(c.Request.Context(), "http.request", trace.WithAttributes(
+		attribute.String("layer", "web"),
+		attribute.String("method", c.Request.Method),
+		attribute.String("path", c.Request.URL.Path),
+	))
+	defer span.End()
+
+	zapLogger := middleware.GetLoggerFromGinContext(c)
+	id := c.Param("id")
+	span.SetAttributes(attribute.String("order.id", id))
+
+	userID := authUserID(c)
+	if userID == "" {
+		zapLogger.Warn("ConfirmOrder: no user_id in context")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	order, alreadyConfirmed, err := h.orderService.ConfirmOrder(ctx, id, userID)
+	if err != nil {
+		span.RecordError(err)
+		zapLogger.Warn("Failed to confirm order", zap.Error(err))
+
+		if errors.Is(err, logicv1.ErrInvalidOrderState) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Order was cancelled and can no longer be confirmed"})
+			return
+		}
+		h.respondOrderLookupError(c, err)
+		return
+	}
+
+	zapLogger.Info("Order confirmed", zap.String("order_id", id), zap.Bool("already_confirmed", alreadyConfirmed))
+	if !alreadyConfirmed {
+		h.clearCart(ctx, c.GetHeader("Authorization"), order, zapLogger)
 	}
+	h.cfg.respond(c, http.StatusOK, order)
 }
 
-func CreateOrder(c *gin.Context) {
-	if handler != nil {
-		handler.CreateOrder(c)
+// UpdateShippingAddress handles PUT /order/v1/private/orders/:id/address
+// Replaces the address of the caller's pending/paid order, then best-effort notifies the shipping service.
+func (h *OrderHandler) UpdateShippingAddress(c *gin.Context) {
+	ctx, span := middleware.StartSpan(c.Request.Context(), "http.request", trace.WithAttributes(
+		attribute.String("layer", "web"),
+		attribute.String("method", c.Request.Method),
+		attribute.String("path", c.Request.URL.Path),
+	))
+	defer span.End()
+
+	zapLogger := middleware.GetLoggerFromGinContext(c)
+	id := c.Param("id")
+	span.SetAttributes(attribute.String("order.id", id))
+
+	userID := authUserID(c)
+	if userID == "" {
+		zapLogger.Warn("UpdateShippingAddress: no user_id in context")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	var address domain.ShippingAddress
+	if err := c.ShouldBindJSON(&address); err != nil {
+		span.SetAttributes(attribute.Bool("request.valid", false))
+		c.JSON(http.StatusBadRequest, gin.H{"error": bindErrorMessage(err)})
+		return
+	}
+
+	order, err := h.orderService.UpdateShippingAddress(ctx, id, userID, address)
+	if err != nil {
+		span.RecordError(err)
+		zapLogger.Warn("Failed to update shipping address", zap.Error(err))
+
+		switch {
+		case errors.Is(err, logicv1.ErrInvalidAddress):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid shipping address"})
+		case errors.Is(err, logicv1.ErrInvalidOrderState):
+			c.JSON(http.StatusConflict, gin.H{"error": "Address can only be changed before the order is processed"})
+		default:
+			h.respondOrderLookupError(c, err)
+		}
+		return
+	}
+
+	// Best-effort: the order is already updated; a failed notification is logged for follow-up
+	if h.shippingClient != nil {
+		notified, err := h.shippingClient.UpdateShipmentAddress(ctx, id, *order.ShippingAddress)
+		if err != nil {
+			span.RecordError(err)
+			zapLogger.Error("Failed to notify shipping of address change", zap.Error(err), zap.String("order_id", id))
+		}
+		span.SetAttributes(attribute.Bool("shipment.notified", notified))
 	}
+
+	zapLogger.Info("Shipping address updated", zap.String("order_id", id))
+	h.cfg.respond(c, http.StatusOK, order)
 }
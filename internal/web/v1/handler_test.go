@@ -0,0 +1,198 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	logicv1 "github.com/duynhne/order-service/internal/logic/v1"
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// fakeOrderRepository keeps orders in memory. Methods a test does not need are left to the
+// embedded nil interface and panic when called.
+type fakeOrderRepository struct {
+	domain.OrderRepository
+
+	mu               sync.Mutex
+	orders           map[string]*domain.Order
+	failedCartClears []string // "userID/orderID" per AddFailedCartClear
+}
+
+func newFakeOrderRepository(orders ...domain.Order) *fakeOrderRepository {
+	repo := &fakeOrderRepository{orders: make(map[string]*domain.Order)}
+	for i := range orders {
+		repo.orders[orders[i].ID] = &orders[i]
+	}
+	return repo
+}
+
+func (r *fakeOrderRepository) FindByID(ctx context.Context, id string) (*domain.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	order, ok := r.orders[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	clone := *order
+	return &clone, nil
+}
+
+// FindByUserID returns userID's orders in ID order; drafts are not left out
+func (r *fakeOrderRepository) FindByUserID(ctx context.Context, userID string, page domain.Page, includeDrafts bool) ([]domain.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var orders []domain.Order
+	for _, order := range r.orders {
+		if order.UserID == userID {
+			orders = append(orders, *order)
+		}
+	}
+	slices.SortFunc(orders, func(a, b domain.Order) int { return strings.Compare(a.ID, b.ID) })
+	start := min(page.Offset, len(orders))
+	return orders[start:min(start+page.Limit, len(orders))], nil
+}
+
+func (r *fakeOrderRepository) CountByUserID(ctx context.Context, userID string, includeDrafts bool) (int, error) {
+	orders, err := r.FindByUserID(ctx, userID, domain.Page{Limit: len(r.orders)}, includeDrafts)
+	return len(orders), err
+}
+
+// Search filters by user only, the one filter there is
+func (r *fakeOrderRepository) Search(ctx context.Context, filter domain.OrderSearchFilter, page domain.Page) ([]domain.Order, int, error) {
+	orders, err := r.FindByUserID(ctx, filter.UserID, page, true)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := r.CountByUserID(ctx, filter.UserID, true)
+	return orders, total, err
+}
+
+func (r *fakeOrderRepository) FindStatus(ctx context.Context, id string) (*domain.OrderStatusInfo, error) {
+	order, err := r.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.OrderStatusInfo{UserID: order.UserID, Status: order.Status}, nil
+}
+
+func (r *fakeOrderRepository) LockOrderWithTx(ctx context.Context, tx domain.Transaction, id string) error {
+	return nil
+}
+
+func (r *fakeOrderRepository) FindStatusForUpdateWithTx(ctx context.Context, tx domain.Transaction, id string) (domain.OrderStatus, error) {
+	info, err := r.FindStatus(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return info.Status, nil
+}
+
+func (r *fakeOrderRepository) UpdateStatusWithTx(ctx context.Context, tx domain.Transaction, id string, status domain.OrderStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	order, ok := r.orders[id]
+	if !ok {
+		return domain.ErrNotFound
+	}
+	order.Status = status
+	return nil
+}
+
+func (r *fakeOrderRepository) AddStatusHistoryWithTx(ctx context.Context, tx domain.Transaction, change *domain.StatusChange) error {
+	return nil
+}
+
+func (r *fakeOrderRepository) AddFailedCartClear(ctx context.Context, userID, orderID, reason string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failedCartClears = append(r.failedCartClears, userID+"/"+orderID)
+	return nil
+}
+
+type fakeTransaction struct{}
+
+func (fakeTransaction) Commit(ctx context.Context) error   { return nil }
+func (fakeTransaction) Rollback(ctx context.Context) error { return nil }
+
+type fakeTransactionManager struct{}
+
+func (fakeTransactionManager) Begin(ctx context.Context) (domain.Transaction, error) {
+	return fakeTransaction{}, nil
+}
+
+// asUser runs the rest of the chain as an authenticated userID, as the auth middleware would
+func asUser(userID string, roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := domain.WithAuthContext(c.Request.Context(), domain.AuthContext{UserID: userID, Roles: roles})
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// newCartServer counts the cart clears it receives
+func newCartServer(t *testing.T, clears *atomic.Int32) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete && r.URL.Path == "/cart/v1/private/cart" {
+			clears.Add(1)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestConfirmOrder(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         string
+		wantStatus int
+		wantClears int32
+	}{
+		{name: "Draft is placed and the cart cleared", id: "1", wantStatus: http.StatusOK, wantClears: 1},
+		{name: "Already placed order answers 200 again", id: "2", wantStatus: http.StatusOK, wantClears: 0},
+		{name: "Cancelled draft", id: "3", wantStatus: http.StatusConflict, wantClears: 0},
+		{name: "Unknown order", id: "404", wantStatus: http.StatusNotFound, wantClears: 0},
+		{name: "Another user's order", id: "4", wantStatus: http.StatusNotFound, wantClears: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeOrderRepository(
+				domain.Order{ID: "1", UserID: "user1", Status: domain.OrderStatusDraft},
+				domain.Order{ID: "2", UserID: "user1", Status: domain.OrderStatusPending},
+				domain.Order{ID: "3", UserID: "user1", Status: domain.OrderStatusCancelled},
+				domain.Order{ID: "4", UserID: "user2", Status: domain.OrderStatusDraft},
+			)
+			service := logicv1.NewOrderService(repo, fakeTransactionManager{}, logicv1.WithDraftOrders(true))
+			var clears atomic.Int32
+			cart := NewCartClient(newCartServer(t, &clears).URL)
+			handler := NewOrderHandler(service, nil, cart, nil, HandlerConfig{})
+
+			router := gin.New()
+			router.POST("/orders/:id/confirm", asUser("user1"), handler.ConfirmOrder)
+			req := httptest.NewRequest(http.MethodPost, "/orders/"+tt.id+"/confirm", nil)
+			req.Header.Set("Authorization", "Bearer token")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body)
+			}
+			if got := clears.Load(); got != tt.wantClears {
+				t.Errorf("cart clears = %d, want %d", got, tt.wantClears)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/duynhne/order-service/middleware"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// downstreamHealthPath is the health endpoint probed on the shipping and cart services
+const downstreamHealthPath = "/health"
+
+// Dependency statuses reported by GET /health/dependencies
+const (
+	DependencyUp       = "up"
+	DependencyDown     = "down"
+	DependencyDisabled = "disabled" // not configured; never counts against overall health
+)
+
+// DependencyProbe checks one dependency of the service
+type DependencyProbe struct {
+	Name string
+	// Critical marks a dependency the service cannot serve requests without (the database):
+	// when it is down the endpoint answers 503 instead of 207
+	Critical bool
+	// Check returns nil when the dependency is reachable; a nil Check reports it disabled
+	Check func(ctx context.Context) error
+}
+
+// DependencyStatus is the probe result of one dependency. The endpoint is unauthenticated, so
+// why a probe failed (hosts, addresses) is only logged, never returned.
+type DependencyStatus struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+}
+
+// DependencyHealthResponse is the body of GET /health/dependencies
+type DependencyHealthResponse struct {
+	Status       string             `json:"status"` // ok, degraded or down
+	Dependencies []DependencyStatus `json:"dependencies"`
+}
+
+// NewDependencyHealthHandler handles GET /health/dependencies: probes every dependency
+// concurrently, each bounded by timeout, and reports status and latency per dependency.
+// Answers 200 when all are up, 207 when only non-critical ones are down and 503 when a
+// critical one is. Probe errors are logged.
+func NewDependencyHealthHandler(probes []DependencyProbe, timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		zapLogger := middleware.GetLoggerFromGinContext(c)
+		statuses := make([]DependencyStatus, len(probes))
+		var wg sync.WaitGroup
+		for i, probe := range probes {
+			statuses[i] = DependencyStatus{Name: probe.Name, Status: DependencyDisabled}
+			if probe.Check == nil {
+				continue
+			}
+			wg.Go(func() {
+				ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+				defer cancel()
+
+				start := time.Now()
+				err := probe.Check(ctx)
+				// Each goroutine writes only its own index; no lock needed.
+				statuses[i].LatencyMS = float64(time.Since(start).Microseconds()) / 1000
+				statuses[i].Status = DependencyUp
+				if err != nil {
+					statuses[i].Status = DependencyDown
+					zapLogger.Warn("Dependency check failed",
+						zap.String("dependency", probe.Name),
+						zap.Bool("critical", probe.Critical),
+						zap.Error(err),
+					)
+				}
+			})
+		}
+		wg.Wait()
+
+		code, overall := http.StatusOK, "ok"
+		for i, probe := range probes {
+			if statuses[i].Status != DependencyDown {
+				continue
+			}
+			if probe.Critical {
+				code, overall = http.StatusServiceUnavailable, "down"
+				break
+			}
+			code, overall = http.StatusMultiStatus, "degraded"
+		}
+		c.JSON(code, DependencyHealthResponse{Status: overall, Dependencies: statuses})
+	}
+}
+
+// pingHealth GETs baseURL + downstreamHealthPath; any non-2xx answer is an error
+func pingHealth(ctx context.Context, client *http.Client, baseURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+downstreamHealthPath, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("health check returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Ping checks that the shipping service answers its health endpoint
+func (c *ShippingClient) Ping(ctx context.Context) error {
+	return pingHealth(ctx, c.httpClient, c.baseURL)
+}
+
+// Ping checks that the cart service answers its health endpoint
+func (c *CartClient) Ping(ctx context.Context) error {
+	return pingHealth(ctx, c.httpClient, c.baseURL)
+}
@@ -0,0 +1,76 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestDependencyHealthHandler(t *testing.T) {
+	up := func(ctx context.Context) error { return nil }
+	down := func(ctx context.Context) error {
+		return errors.New("dial tcp 10.0.3.7:5432: connect: connection refused")
+	}
+
+	tests := []struct {
+		name        string
+		probes      []DependencyProbe
+		wantCode    int
+		wantOverall string
+	}{
+		{
+			name:        "All up",
+			probes:      []DependencyProbe{{Name: "database", Critical: true, Check: up}, {Name: "cart-service", Check: up}},
+			wantCode:    http.StatusOK,
+			wantOverall: "ok",
+		},
+		{
+			name:        "Disabled dependency does not count",
+			probes:      []DependencyProbe{{Name: "database", Critical: true, Check: up}, {Name: "cart-service"}},
+			wantCode:    http.StatusOK,
+			wantOverall: "ok",
+		},
+		{
+			name:        "Non-critical down",
+			probes:      []DependencyProbe{{Name: "database", Critical: true, Check: up}, {Name: "cart-service", Check: down}},
+			wantCode:    http.StatusMultiStatus,
+			wantOverall: "degraded",
+		},
+		{
+			name:        "Critical down",
+			probes:      []DependencyProbe{{Name: "database", Critical: true, Check: down}, {Name: "cart-service", Check: down}},
+			wantCode:    http.StatusServiceUnavailable,
+			wantOverall: "down",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/health/dependencies", NewDependencyHealthHandler(tt.probes, time.Second))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/dependencies", nil))
+
+			if w.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantCode)
+			}
+			if strings.Contains(w.Body.String(), "10.0.3.7") {
+				t.Errorf("body %s leaks the probe error", w.Body)
+			}
+			var resp DependencyHealthResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if resp.Status != tt.wantOverall || len(resp.Dependencies) != len(tt.probes) {
+				t.Errorf("response = %+v, want status %q for %d dependencies", resp, tt.wantOverall, len(tt.probes))
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/duynhne/order-service/middleware"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// GetInternalOrder handles GET /order/v1/internal/orders/:id
+// Returns any order regardless of its owner, for trusted services (shipping, notifications).
+// The route is guarded by middleware.ServiceTokenMiddleware instead of a user token, and the
+// body is always the plain order: response envelope and JSON case settings are for customer routes.
+func (h *OrderHandler) GetInternalOrder(c *gin.Context) {
+	ctx, span := middleware.StartSpan(c.Request.Context(), "http.request", trace.WithAttributes(
+		attribute.String("layer", "web"),
+		attribute.String("method", c.Request.Method),
+		attribute.String("path", c.Request.URL.Path),
+		attribute.String("endpoint.type", "internal"),
+	))
+	defer span.End()
+
+	zapLogger := middleware.GetLoggerFromGinContext(c)
+	id := c.Param("id")
+	span.SetAttributes(attribute.String("order.id", id))
+
+	order, err := h.orderService.GetOrder(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		zapLogger.Error("Failed to get order", zap.Error(err))
+		h.respondOrderLookupError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
@@ -0,0 +1,68 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	logicv1 "github.com/duynhne/order-service/internal/logic/v1"
+	"github.com/duynhne/order-service/middleware"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+func TestGetInternalOrder(t *testing.T) {
+	const token = "s3cret-service-token"
+
+	tests := []struct {
+		name       string
+		configured string // INTERNAL_SERVICE_TOKEN
+		sent       string // X-Service-Token; "" leaves the header out
+		id         string
+		wantStatus int
+	}{
+		{name: "Valid token reads another user's order", configured: token, sent: token, id: "1", wantStatus: http.StatusOK},
+		{name: "Valid token, unknown order", configured: token, sent: token, id: "404", wantStatus: http.StatusNotFound},
+		{name: "Missing token", configured: token, id: "1", wantStatus: http.StatusUnauthorized},
+		{name: "Wrong token", configured: token, sent: "s3cret-service-tokem", id: "1", wantStatus: http.StatusUnauthorized},
+		{name: "Token prefix", configured: token, sent: token[:6], id: "1", wantStatus: http.StatusUnauthorized},
+		{name: "INTERNAL_SERVICE_TOKEN unset", sent: token, id: "1", wantStatus: http.StatusUnauthorized},
+		{name: "INTERNAL_SERVICE_TOKEN unset, no header", id: "1", wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeOrderRepository(domain.Order{ID: "1", UserID: "user2", Status: domain.OrderStatusPaid})
+			service := logicv1.NewOrderService(repo, fakeTransactionManager{})
+			handler := NewOrderHandler(service, nil, nil, nil, HandlerConfig{})
+
+			router := gin.New()
+			internal := router.Group("/order/v1/internal")
+			internal.Use(middleware.ServiceTokenMiddleware(tt.configured, zap.NewNop()))
+			internal.GET("/orders/:id", handler.GetInternalOrder)
+
+			req := httptest.NewRequest(http.MethodGet, "/order/v1/internal/orders/"+tt.id, nil)
+			if tt.sent != "" {
+				req.Header.Set(middleware.ServiceTokenHeader, tt.sent)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var order domain.Order
+			if err := json.Unmarshal(w.Body.Bytes(), &order); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if order.ID != "1" || order.UserID != "user2" {
+				t.Errorf("order = %+v, want order 1 of user2", order)
+			}
+		})
+	}
+}
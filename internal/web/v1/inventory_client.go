@@ -0,0 +1,80 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+)
+
+// inventoryAvailabilityPath is the inventory service endpoint that checks stock for a list of items
+const inventoryAvailabilityPath = "/inventory/v1/internal/availability"
+
+// InventoryClient implements domain.InventoryChecker with the inventory service
+type InventoryClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewInventoryClient creates a new inventory service client
+func NewInventoryClient(baseURL string) *InventoryClient {
+	return &InventoryClient{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout: 3 * time.Second,
+		},
+	}
+}
+
+// availabilityItem is one line of an availability check
+type availabilityItem struct {
+	ProductID string `json:"product_id"`
+	SKU       string `json:"sku,omitempty"`
+	Quantity  int    `json:"quantity"`
+}
+
+type availabilityRequest struct {
+	Items []availabilityItem `json:"items"`
+}
+
+// availabilityResponse lists the items that cannot be filled; empty when all can
+type availabilityResponse struct {
+	Unavailable []domain.StockShortage `json:"unavailable"`
+}
+
+// CheckAvailability checks all items with one call; any non-200 response is an error
+func (c *InventoryClient) CheckAvailability(ctx context.Context, items []domain.OrderItem) ([]domain.StockShortage, error) {
+	check := availabilityRequest{Items: make([]availabilityItem, len(items))}
+	for i, item := range items {
+		check.Items[i] = availabilityItem{ProductID: item.ProductID, SKU: item.SKU, Quantity: item.Quantity}
+	}
+	body, err := json.Marshal(check)
+	if err != nil {
+		return nil, fmt.Errorf("encode availability request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+inventoryAvailabilityPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("inventory service call failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("inventory service returned status %d", resp.StatusCode)
+	}
+
+	var availability availabilityResponse
+	if err := json.NewDecoder(resp.Body).Decode(&availability); err != nil {
+		return nil, fmt.Errorf("failed to decode availability response: %w", err)
+	}
+	return availability.Unavailable, nil
+}
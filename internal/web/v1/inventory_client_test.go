@@ -0,0 +1,127 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	logicv1 "github.com/duynhne/order-service/internal/logic/v1"
+	"github.com/gin-gonic/gin"
+)
+
+func TestInventoryClientCheckAvailability(t *testing.T) {
+	items := []domain.OrderItem{
+		{ProductID: "101", SKU: "TSHIRT-M", Quantity: 2, Price: 10},
+		{ProductID: "102", Quantity: 5, Price: 4},
+	}
+
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    []domain.StockShortage
+		wantErr bool
+	}{
+		{name: "All in stock", status: http.StatusOK, body: `{"unavailable": []}`, want: []domain.StockShortage{}},
+		{
+			name:   "Short items",
+			status: http.StatusOK,
+			body:   `{"unavailable": [{"product_id": "102", "requested": 5, "available": 3}]}`,
+			want:   []domain.StockShortage{{ProductID: "102", Requested: 5, Available: 3}},
+		},
+		{name: "Service error", status: http.StatusInternalServerError, body: `{"error": "boom"}`, wantErr: true},
+		{name: "Unknown route", status: http.StatusNotFound, wantErr: true},
+		{name: "Malformed body", status: http.StatusOK, body: `{"unavailable": `, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got availabilityRequest
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != inventoryAvailabilityPath {
+					t.Errorf("request = %s %s, want POST %s", r.Method, r.URL.Path, inventoryAvailabilityPath)
+				}
+				if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+					t.Errorf("Content-Type = %q, want application/json", ct)
+				}
+				if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+					t.Errorf("decode request: %v", err)
+				}
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer srv.Close()
+
+			shortages, err := NewInventoryClient(srv.URL).CheckAvailability(context.Background(), items)
+
+			wantReq := availabilityRequest{Items: []availabilityItem{
+				{ProductID: "101", SKU: "TSHIRT-M", Quantity: 2},
+				{ProductID: "102", Quantity: 5},
+			}}
+			if !reflect.DeepEqual(got, wantReq) {
+				t.Errorf("request body = %+v, want %+v", got, wantReq)
+			}
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("CheckAvailability() = %v, want an error", shortages)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CheckAvailability() error = %v", err)
+			}
+			if !reflect.DeepEqual(shortages, tt.want) {
+				t.Errorf("CheckAvailability() = %+v, want %+v", shortages, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateOrderInsufficientStock(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"unavailable": [
+			{"product_id": "101", "sku": "TSHIRT-M", "requested": 2, "available": 1},
+			{"product_id": "102", "requested": 5, "available": 0}
+		]}`)
+	}))
+	defer srv.Close()
+
+	service := logicv1.NewOrderService(newFakeOrderRepository(), fakeTransactionManager{},
+		logicv1.WithStockCheck(NewInventoryClient(srv.URL)))
+	handler := NewOrderHandler(service, nil, nil, nil, HandlerConfig{})
+	router := gin.New()
+	router.POST("/orders", asUser("user1"), handler.CreateOrder)
+
+	body := `{"items": [
+		{"product_id": "101", "product_name": "T-shirt", "sku": "TSHIRT-M", "quantity": 2, "price": 10},
+		{"product_id": "102", "product_name": "Mug", "quantity": 5, "price": 4}
+	]}`
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want 409 (body %s)", w.Code, w.Body)
+	}
+	var resp struct {
+		Code             string                 `json:"code"`
+		UnavailableItems []domain.StockShortage `json:"unavailable_items"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	want := []domain.StockShortage{
+		{ProductID: "101", SKU: "TSHIRT-M", Requested: 2, Available: 1},
+		{ProductID: "102", Requested: 5, Available: 0},
+	}
+	if resp.Code != ErrCodeInsufficientStock || !reflect.DeepEqual(resp.UnavailableItems, want) {
+		t.Errorf("body = %+v, want code %s and unavailable_items %+v", resp, ErrCodeInsufficientStock, want)
+	}
+}
@@ -0,0 +1,87 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// JSON key casings for success bodies (API_JSON_CASE)
+const (
+	JSONCaseSnake = "snake" // the casing of the struct tags; bodies are written as-is
+	JSONCaseCamel = "camel"
+)
+
+// opaqueJSONKeys hold caller-defined maps whose keys are data, not field names, and are never recased
+var opaqueJSONKeys = map[string]bool{"metadata": true}
+
+// snakeToCamel converts a snake_case key to camelCase ("order_number" -> "orderNumber")
+func snakeToCamel(key string) string {
+	if !strings.Contains(key, "_") {
+		return key
+	}
+	parts := strings.Split(key, "_")
+	var b strings.Builder
+	b.WriteString(parts[0])
+	for _, p := range parts[1:] {
+		if p == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(p[:1]))
+		b.WriteString(p[1:])
+	}
+	return b.String()
+}
+
+// camelToSnake converts a camelCase key to snake_case ("orderNumber" -> "order_number")
+func camelToSnake(key string) string {
+	var b strings.Builder
+	for i, r := range key {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// toCamelJSON re-encodes body with every object key in camelCase (values of opaqueJSONKeys
+// are kept as they are). Numbers keep their exact encoding.
+func toCamelJSON(body any) (any, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return recaseKeys(v), nil
+}
+
+// recaseKeys returns a decoded JSON value with its object keys in camelCase
+func recaseKeys(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, val := range v {
+			if !opaqueJSONKeys[k] {
+				val = recaseKeys(val)
+			}
+			out[snakeToCamel(k)] = val
+		}
+		return out
+	case []any:
+		for i := range v {
+			v[i] = recaseKeys(v[i])
+		}
+		return v
+	default:
+		return v
+	}
+}
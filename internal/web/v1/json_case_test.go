@@ -0,0 +1,80 @@
+package v1
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSnakeToCamel(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{key: "id", want: "id"},
+		{key: "order_number", want: "orderNumber"},
+		{key: "estimated_delivery_date", want: "estimatedDeliveryDate"},
+		{key: "orderNumber", want: "orderNumber"},
+		{key: "line1", want: "line1"},
+		{key: "address_line2", want: "addressLine2"},
+		{key: "v2_total", want: "v2Total"},
+		{key: "line_2", want: "line2"},
+		{key: "double__underscore", want: "doubleUnderscore"},
+		{key: "trailing_", want: "trailing"},
+	}
+
+	for _, tt := range tests {
+		if got := snakeToCamel(tt.key); got != tt.want {
+			t.Errorf("snakeToCamel(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestCamelToSnake(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{key: "id", want: "id"},
+		{key: "orderNumber", want: "order_number"},
+		{key: "estimatedDeliveryDate", want: "estimated_delivery_date"},
+		{key: "order_number", want: "order_number"},
+		{key: "line1", want: "line1"},
+		{key: "addressLine2", want: "address_line2"},
+		{key: "v2Total", want: "v2_total"},
+	}
+
+	for _, tt := range tests {
+		if got := camelToSnake(tt.key); got != tt.want {
+			t.Errorf("camelToSnake(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestToCamelJSON(t *testing.T) {
+	body := map[string]any{
+		"order_number": "ORD-2026-000123",
+		"total":        json.Number("114.970"),
+		"items": []any{
+			map[string]any{"product_id": "101", "tax_rate": 0.08},
+			map[string]any{"productName": "Mug"},
+		},
+		"shipping_address": map[string]any{"postal_code": "10000", "line1": "1 Main St"},
+		"metadata":         map[string]any{"gift_note": "yes", "nested_map": map[string]any{"inner_key": 1}},
+		"promotions":       []any{[]any{map[string]any{"applied_at": "now"}}},
+	}
+
+	got, err := toCamelJSON(body)
+	if err != nil {
+		t.Fatalf("toCamelJSON() error = %v", err)
+	}
+	data, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("marshal result: %v", err)
+	}
+	want := `{"items":[{"productId":"101","taxRate":0.08},{"productName":"Mug"}],` +
+		`"metadata":{"gift_note":"yes","nested_map":{"inner_key":1}},"orderNumber":"ORD-2026-000123",` +
+		`"promotions":[[{"appliedAt":"now"}]],"shippingAddress":{"line1":"1 Main St","postalCode":"10000"},"total":114.970}`
+	if string(data) != want {
+		t.Errorf("toCamelJSON() =\n%s\nwant\n%s", data, want)
+	}
+}
@@ -0,0 +1,56 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+)
+
+// notificationPath is the notification service endpoint that fans a status change out to the
+// customer's channels (email, SMS, push)
+const notificationPath = "/notification/v1/internal/order-status"
+
+// NotificationClient implements domain.Notifier by posting status changes to the notification service
+type NotificationClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewNotificationClient creates a new notification service client
+func NewNotificationClient(baseURL string) *NotificationClient {
+	return &NotificationClient{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout: 3 * time.Second,
+		},
+	}
+}
+
+// Notify posts n to the notification service; any non-2xx response is an error
+func (c *NotificationClient) Notify(ctx context.Context, n domain.StatusNotification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("encode notification: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+notificationPath, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request notification service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notification service returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultPageSize is used when HandlerConfig.DefaultPageSize is not set
+	DefaultPageSize = 20
+	// DefaultMaxPageSize is used when HandlerConfig.MaxPageSize is not set
+	DefaultMaxPageSize = 100
+)
+
+var errInvalidPagination = errors.New("invalid pagination parameters")
+
+// OrderListResponse wraps a page of orders with pagination metadata.
+// Limit is the effective (possibly clamped) page size.
+type OrderListResponse struct {
+	Orders []domain.Order `json:"orders"`
+	Total  int            `json:"total"`
+	Limit  int            `json:"limit"`
+	Offset int            `json:"offset"`
+}
+
+// includes reports whether the comma-separated ?include= query param lists value
+func includes(c *gin.Context, value string) bool {
+	for _, v := range strings.Split(c.Query("include"), ",") {
+		if strings.TrimSpace(v) == value {
+			return true
+		}
+	}
+	return false
+}
+
+// parsePage reads ?limit= and ?offset= query params.
+// A missing limit uses the configured default; a larger one than MaxPageSize is clamped to it.
+// Non-numeric values, limits below 1 and negative offsets are rejected.
+func (cfg HandlerConfig) parsePage(c *gin.Context) (domain.Page, error) {
+	page := domain.Page{Limit: cfg.DefaultPageSize}
+
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 {
+			return domain.Page{}, errInvalidPagination
+		}
+		page.Limit = min(limit, cfg.MaxPageSize)
+	}
+
+	if raw := c.Query("offset"); raw != "" {
+		offset, err := strconv.Atoi(raw)
+		if err != nil || offset < 0 {
+			return domain.Page{}, errInvalidPagination
+		}
+		page.Offset = offset
+	}
+
+	return page, nil
+}
+
+// PageLimitHeader carries the effective page size, so clients see when their ?limit= was clamped
+const PageLimitHeader = "X-Page-Limit"
+
+// setPaginationHeaders sets X-Total-Count, X-Page-Limit and an RFC 8288 Link header with next/prev
+// page URLs (relative, preserving the request's other query params) for clients that paginate from headers.
+func setPaginationHeaders(c *gin.Context, page domain.Page, total int) {
+	c.Header("X-Total-Count", strconv.Itoa(total))
+	c.Header(PageLimitHeader, strconv.Itoa(page.Limit))
+
+	var links []string
+	if page.Offset+page.Limit < total {
+		links = append(links, pageLink(c, page.Limit, page.Offset+page.Limit, "next"))
+	}
+	if page.Offset > 0 {
+		links = append(links, pageLink(c, page.Limit, max(page.Offset-page.Limit, 0), "prev"))
+	}
+	if len(links) > 0 {
+		c.Header("Link", strings.Join(links, ", "))
+	}
+}
+
+// pageLink renders one Link header entry for the current URL with limit/offset replaced
+func pageLink(c *gin.Context, limit, offset int, rel string) string {
+	query := c.Request.URL.Query()
+	query.Set("limit", strconv.Itoa(limit))
+	query.Set("offset", strconv.Itoa(offset))
+	return fmt.Sprintf(`<%s?%s>; rel="%s"`, c.Request.URL.Path, query.Encode(), rel)
+}
@@ -0,0 +1,69 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	logicv1 "github.com/duynhne/order-service/internal/logic/v1"
+	"github.com/gin-gonic/gin"
+)
+
+func TestListOrdersPagination(t *testing.T) {
+	orders := make([]domain.Order, 5)
+	for i := range orders {
+		orders[i] = domain.Order{ID: strconv.Itoa(i + 1), UserID: "user1", Status: domain.OrderStatusPending}
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantCount  int
+		wantLimit  string
+	}{
+		{name: "Default page size", query: "", wantStatus: http.StatusOK, wantCount: 3, wantLimit: "3"},
+		{name: "Limit within range", query: "?limit=2", wantStatus: http.StatusOK, wantCount: 2, wantLimit: "2"},
+		{name: "Limit clamped to the maximum", query: "?limit=1000", wantStatus: http.StatusOK, wantCount: 4, wantLimit: "4"},
+		{name: "Zero limit", query: "?limit=0", wantStatus: http.StatusBadRequest},
+		{name: "Negative limit", query: "?limit=-1", wantStatus: http.StatusBadRequest},
+		{name: "Non-numeric limit", query: "?limit=ten", wantStatus: http.StatusBadRequest},
+		{name: "Negative offset", query: "?offset=-1", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := logicv1.NewOrderService(newFakeOrderRepository(orders...), fakeTransactionManager{})
+			handler := NewOrderHandler(service, nil, nil, nil, HandlerConfig{DefaultPageSize: 3, MaxPageSize: 4})
+			router := gin.New()
+			router.GET("/orders", asUser("user1"), handler.ListOrders)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders"+tt.query, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			// The body is the bare array existing clients parse
+			var got []domain.Order
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("body %s is not an order array: %v", w.Body, err)
+			}
+			if len(got) != tt.wantCount {
+				t.Errorf("orders = %d, want %d", len(got), tt.wantCount)
+			}
+			if h := w.Header().Get(PageLimitHeader); h != tt.wantLimit {
+				t.Errorf("%s = %q, want %q", PageLimitHeader, h, tt.wantLimit)
+			}
+			if h := w.Header().Get("X-Total-Count"); h != "5" {
+				t.Errorf("X-Total-Count = %q, want 5", h)
+			}
+		})
+	}
+}
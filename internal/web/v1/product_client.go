@@ -0,0 +1,108 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// productPathTemplate is the product service's product-by-ID endpoint
+const productPathTemplate = "/product/v1/public/products/%s"
+
+const (
+	// productLookupConcurrency caps the product requests one Prices call has in flight
+	productLookupConcurrency = 8
+	// productLookupTimeout bounds a whole Prices call, however many products it looks up
+	productLookupTimeout = 5 * time.Second
+)
+
+// ProductClient implements domain.PriceCatalog with the product service
+type ProductClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewProductClient creates a new product service client
+func NewProductClient(baseURL string) *ProductClient {
+	return &ProductClient{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout: 3 * time.Second,
+		},
+	}
+}
+
+// productResponse is the part of the product service response used for pricing
+type productResponse struct {
+	Price float64 `json:"price"`
+}
+
+// Prices looks up each distinct product's catalog price; unknown products (404) are left out.
+// Lookups run productLookupConcurrency at a time, all within productLookupTimeout; the first
+// failure cancels the rest.
+func (c *ProductClient) Prices(ctx context.Context, productIDs []string) (map[string]float64, error) {
+	ctx, cancel := context.WithTimeout(ctx, productLookupTimeout)
+	defer cancel()
+
+	var (
+		mu     sync.Mutex
+		prices = make(map[string]float64, len(productIDs))
+		seen   = make(map[string]struct{}, len(productIDs))
+	)
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(productLookupConcurrency)
+	for _, id := range productIDs {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		g.Go(func() error {
+			price, found, err := c.price(ctx, id)
+			if err != nil || !found {
+				return err
+			}
+			mu.Lock()
+			prices[id] = price
+			mu.Unlock()
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return prices, nil
+}
+
+// price fetches one product's price; found is false when the product does not exist
+func (c *ProductClient) price(ctx context.Context, productID string) (price float64, found bool, err error) {
+	url := c.baseURL + fmt.Sprintf(productPathTemplate, neturl.PathEscape(productID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, false, fmt.Errorf("create product request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, false, fmt.Errorf("product service call failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return 0, false, nil
+	case resp.StatusCode != http.StatusOK:
+		return 0, false, fmt.Errorf("product service returned status %d", resp.StatusCode)
+	}
+
+	var product productResponse
+	if err := json.NewDecoder(resp.Body).Decode(&product); err != nil {
+		return 0, false, fmt.Errorf("failed to decode product response: %w", err)
+	}
+	return product.Price, true, nil
+}
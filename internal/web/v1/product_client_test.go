@@ -0,0 +1,91 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestProductClientPrices(t *testing.T) {
+	tests := []struct {
+		name       string
+		ids        []string
+		wantPrices map[string]float64
+		wantErr    bool
+		wantCalls  int32
+	}{
+		{name: "Known products", ids: []string{"1", "2"}, wantPrices: map[string]float64{"1": 10, "2": 4.5}, wantCalls: 2},
+		{name: "Unknown product left out", ids: []string{"1", "404"}, wantPrices: map[string]float64{"1": 10}, wantCalls: 2},
+		{name: "Duplicates fetched once", ids: []string{"1", "1", "1"}, wantPrices: map[string]float64{"1": 10}, wantCalls: 1},
+		{name: "Service error", ids: []string{"1", "500"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				switch id := strings.TrimPrefix(r.URL.Path, "/product/v1/public/products/"); id {
+				case "1":
+					fmt.Fprint(w, `{"id": "1", "price": 10}`)
+				case "2":
+					fmt.Fprint(w, `{"id": "2", "price": 4.5}`)
+				case "500":
+					w.WriteHeader(http.StatusInternalServerError)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer srv.Close()
+
+			prices, err := NewProductClient(srv.URL).Prices(context.Background(), tt.ids)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Prices() = %v, want an error", prices)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Prices() error = %v", err)
+			}
+			if !maps.Equal(prices, tt.wantPrices) {
+				t.Errorf("Prices() = %v, want %v", prices, tt.wantPrices)
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("product requests = %d, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestProductClientPricesInParallel(t *testing.T) {
+	const delay = 100 * time.Millisecond
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(delay)
+		fmt.Fprint(w, `{"price": 1}`)
+	}))
+	defer srv.Close()
+
+	ids := make([]string, productLookupConcurrency)
+	for i := range ids {
+		ids[i] = fmt.Sprint(i + 1)
+	}
+
+	start := time.Now()
+	prices, err := NewProductClient(srv.URL).Prices(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("Prices() error = %v", err)
+	}
+	if len(prices) != len(ids) {
+		t.Errorf("Prices() returned %d prices, want %d", len(prices), len(ids))
+	}
+	if elapsed := time.Since(start); elapsed >= 4*delay {
+		t.Errorf("%d lookups took %s, want them in parallel (each %s)", len(ids), elapsed, delay)
+	}
+}
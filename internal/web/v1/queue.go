@@ -0,0 +1,92 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	logicv1 "github.com/duynhne/order-service/internal/logic/v1"
+	"github.com/duynhne/order-service/middleware"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// createJobPath is the poll URL prefix for async order creation jobs
+const createJobPath = "/order/v1/private/orders/jobs/"
+
+// queueFullRetryAfter is the Retry-After hint (seconds) sent with 503 when the queue is full
+const queueFullRetryAfter = "5"
+
+// CreateJobResponse is the 202 body for an async order creation
+type CreateJobResponse struct {
+	JobID     string                  `json:"job_id"`
+	Status    logicv1.CreateJobStatus `json:"status"`
+	StatusURL string                  `json:"status_url"`
+}
+
+// enqueueOrder is the async branch of CreateOrder: queue the validated request and reply 202
+func (h *OrderHandler) enqueueOrder(c *gin.Context, req domain.CreateOrderRequest) {
+	span := trace.SpanFromContext(c.Request.Context())
+	zapLogger := middleware.GetLoggerFromGinContext(c)
+	authHeader := c.GetHeader("Authorization")
+
+	job, err := h.createQueue.Enqueue(c.Request.Context(), req, func(ctx context.Context, order *domain.Order) {
+		h.clearCart(ctx, authHeader, order, zapLogger)
+	})
+	if err != nil {
+		span.RecordError(err)
+
+		switch {
+		case errors.Is(err, logicv1.ErrInvalidOrder):
+			respondInvalidOrder(c, err)
+		case errors.Is(err, logicv1.ErrQueueFull):
+			zapLogger.Warn("Order queue full, rejecting request")
+			c.Header("Retry-After", queueFullRetryAfter)
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Too many orders in progress, retry later"})
+		default:
+			zapLogger.Error("Failed to enqueue order", zap.Error(err))
+			respondInternalError(c, err)
+		}
+		return
+	}
+
+	span.SetAttributes(attribute.String("job.id", job.ID), attribute.Bool("order.async", true))
+	zapLogger.Info("Order queued", zap.String("job_id", job.ID))
+
+	statusURL := createJobPath + job.ID
+	c.Header("Location", statusURL)
+	h.cfg.respond(c, http.StatusAccepted, CreateJobResponse{JobID: job.ID, Status: job.Status, StatusURL: statusURL})
+}
+
+// GetCreateJob handles GET /order/v1/private/orders/jobs/:job_id
+// Returns the status of an async order creation; order_id is set once completed.
+func (h *OrderHandler) GetCreateJob(c *gin.Context) {
+	_, span := middleware.StartSpan(c.Request.Context(), "http.request", trace.WithAttributes(
+		attribute.String("layer", "web"),
+		attribute.String("method", c.Request.Method),
+		attribute.String("path", c.Request.URL.Path),
+	))
+	defer span.End()
+
+	userID := authUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+	if h.createQueue == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		return
+	}
+
+	job, err := h.createQueue.Job(c.Param("job_id"), userID)
+	if err != nil {
+		// Unknown, expired and other users' jobs are indistinguishable
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		return
+	}
+
+	h.cfg.respond(c, http.StatusOK, job)
+}
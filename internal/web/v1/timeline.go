@@ -0,0 +1,127 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"github.com/duynhne/order-service/middleware"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Timeline entry sources
+const (
+	TimelineSourceOrder    = "order"
+	TimelineSourceShipping = "shipping"
+)
+
+// TimelineEntry is one event in an order's timeline
+type TimelineEntry struct {
+	Timestamp   time.Time `json:"timestamp"`
+	Source      string    `json:"source"`
+	Description string    `json:"description"`
+}
+
+// OrderTimelineResponse is the merged, chronologically sorted timeline of an order.
+// Degraded is true when shipment events could not be included (shipping service unavailable).
+type OrderTimelineResponse struct {
+	OrderID  string          `json:"order_id"`
+	Events   []TimelineEntry `json:"events"`
+	Degraded bool            `json:"degraded"`
+}
+
+// GetOrderTimeline handles GET /order/v1/private/orders/:id/timeline
+// Merges local status history with shipment events from the shipping service (aggregation endpoint)
+func (h *OrderHandler) GetOrderTimeline(c *gin.Context) {
+	ctx, span := middleware.StartSpan(c.Request.Context(), "http.request", trace.WithAttributes(
+		attribute.String("layer", "web"),
+		attribute.String("method", c.Request.Method),
+		attribute.String("path", c.Request.URL.Path),
+		attribute.String("endpoint.type", "aggregation"),
+	))
+	defer span.End()
+
+	zapLogger := middleware.GetLoggerFromGinContext(c)
+	orderID := c.Param("id")
+	span.SetAttributes(attribute.String("order.id", orderID))
+
+	userID := authUserID(c)
+	if userID == "" {
+		zapLogger.Warn("GetOrderTimeline: no user_id in context")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	order, history, err := h.orderService.GetStatusHistory(ctx, orderID, userID)
+	if err != nil {
+		span.RecordError(err)
+		zapLogger.Error("Failed to get order history", zap.Error(err), zap.String("order_id", orderID))
+		h.respondOrderLookupError(c, err)
+		return
+	}
+
+	// Shipment events are optional: without them the timeline is still served, flagged as degraded
+	var shipment *Shipment
+	degraded := h.shippingClient == nil
+	if h.shippingClient != nil {
+		shipment, err = h.shippingClient.GetShipmentByOrderID(ctx, orderID)
+		if err != nil {
+			degraded = true
+			zapLogger.Warn("Could not fetch shipment", zap.Error(err), zap.String("order_id", orderID))
+		}
+	}
+	span.SetAttributes(attribute.Bool("timeline.degraded", degraded))
+
+	h.cfg.respond(c, http.StatusOK, OrderTimelineResponse{
+		OrderID:  order.ID,
+		Events:   buildTimeline(order, history, shipment),
+		Degraded: degraded,
+	})
+}
+
+// buildTimeline merges order creation, status changes and shipment events, oldest first.
+// Shipment timestamps that fail to parse are skipped rather than guessed.
+func buildTimeline(order *domain.Order, history []domain.StatusChange, shipment *Shipment) []TimelineEntry {
+	events := make([]TimelineEntry, 0, len(history)+3)
+	events = append(events, TimelineEntry{
+		Timestamp:   order.CreatedAt,
+		Source:      TimelineSourceOrder,
+		Description: "Order placed",
+	})
+	for _, change := range history {
+		events = append(events, TimelineEntry{
+			Timestamp:   change.CreatedAt,
+			Source:      TimelineSourceOrder,
+			Description: fmt.Sprintf("Status changed from %s to %s (%s)", change.FromStatus, change.ToStatus, change.Source),
+		})
+	}
+
+	if shipment != nil {
+		created, createdErr := time.Parse(time.RFC3339, shipment.CreatedAt)
+		if createdErr == nil {
+			description := "Shipment created"
+			if shipment.TrackingNumber != "" {
+				description += ", tracking number " + shipment.TrackingNumber
+			}
+			events = append(events, TimelineEntry{Timestamp: created, Source: TimelineSourceShipping, Description: description})
+		}
+		if updated, err := time.Parse(time.RFC3339, shipment.UpdatedAt); err == nil &&
+			(createdErr != nil || updated.After(created)) {
+			events = append(events, TimelineEntry{
+				Timestamp:   updated,
+				Source:      TimelineSourceShipping,
+				Description: "Shipment status: " + shipment.Status,
+			})
+		}
+	}
+
+	slices.SortStableFunc(events, func(a, b TimelineEntry) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+	return events
+}
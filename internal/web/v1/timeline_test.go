@@ -0,0 +1,103 @@
+package v1
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+)
+
+func TestBuildTimeline(t *testing.T) {
+	placed := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) time.Time { return placed.Add(d) }
+	order := &domain.Order{ID: "7", CreatedAt: placed}
+	// History arrives in insertion order, which need not be chronological
+	history := []domain.StatusChange{
+		{FromStatus: domain.OrderStatusPaid, ToStatus: domain.OrderStatusShipped, Source: "api", CreatedAt: at(5 * time.Hour)},
+		{FromStatus: domain.OrderStatusPending, ToStatus: domain.OrderStatusPaid, Source: "webhook", CreatedAt: at(time.Hour)},
+	}
+
+	tests := []struct {
+		name     string
+		shipment *Shipment
+		want     []TimelineEntry
+	}{
+		{
+			name: "Without a shipment",
+			want: []TimelineEntry{
+				{Timestamp: placed, Source: TimelineSourceOrder, Description: "Order placed"},
+				{Timestamp: at(time.Hour), Source: TimelineSourceOrder, Description: "Status changed from pending to paid (webhook)"},
+				{Timestamp: at(5 * time.Hour), Source: TimelineSourceOrder, Description: "Status changed from paid to shipped (api)"},
+			},
+		},
+		{
+			name: "Shipment events interleaved with the history",
+			shipment: &Shipment{
+				TrackingNumber: "TRK123",
+				Status:         "in_transit",
+				CreatedAt:      at(3 * time.Hour).Format(time.RFC3339),
+				UpdatedAt:      at(8 * time.Hour).Format(time.RFC3339),
+			},
+			want: []TimelineEntry{
+				{Timestamp: placed, Source: TimelineSourceOrder, Description: "Order placed"},
+				{Timestamp: at(time.Hour), Source: TimelineSourceOrder, Description: "Status changed from pending to paid (webhook)"},
+				{Timestamp: at(3 * time.Hour), Source: TimelineSourceShipping, Description: "Shipment created, tracking number TRK123"},
+				{Timestamp: at(5 * time.Hour), Source: TimelineSourceOrder, Description: "Status changed from paid to shipped (api)"},
+				{Timestamp: at(8 * time.Hour), Source: TimelineSourceShipping, Description: "Shipment status: in_transit"},
+			},
+		},
+		{
+			name: "Shipment never updated",
+			shipment: &Shipment{
+				Status:    "created",
+				CreatedAt: at(3 * time.Hour).Format(time.RFC3339),
+				UpdatedAt: at(3 * time.Hour).Format(time.RFC3339),
+			},
+			want: []TimelineEntry{
+				{Timestamp: placed, Source: TimelineSourceOrder, Description: "Order placed"},
+				{Timestamp: at(time.Hour), Source: TimelineSourceOrder, Description: "Status changed from pending to paid (webhook)"},
+				{Timestamp: at(3 * time.Hour), Source: TimelineSourceShipping, Description: "Shipment created"},
+				{Timestamp: at(5 * time.Hour), Source: TimelineSourceOrder, Description: "Status changed from paid to shipped (api)"},
+			},
+		},
+		{
+			name: "Unparsable shipment timestamps skipped",
+			shipment: &Shipment{
+				Status:    "delivered",
+				CreatedAt: "yesterday",
+				UpdatedAt: at(9 * time.Hour).Format(time.RFC3339),
+			},
+			want: []TimelineEntry{
+				{Timestamp: placed, Source: TimelineSourceOrder, Description: "Order placed"},
+				{Timestamp: at(time.Hour), Source: TimelineSourceOrder, Description: "Status changed from pending to paid (webhook)"},
+				{Timestamp: at(5 * time.Hour), Source: TimelineSourceOrder, Description: "Status changed from paid to shipped (api)"},
+				{Timestamp: at(9 * time.Hour), Source: TimelineSourceShipping, Description: "Shipment status: delivered"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildTimeline(order, history, tt.shipment)
+			if !slices.EqualFunc(got, tt.want, func(a, b TimelineEntry) bool {
+				return a.Timestamp.Equal(b.Timestamp) && a.Source == b.Source && a.Description == b.Description
+			}) {
+				t.Errorf("buildTimeline() =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildTimelineKeepsOrderOfSimultaneousEvents(t *testing.T) {
+	placed := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	order := &domain.Order{ID: "7", CreatedAt: placed}
+	history := []domain.StatusChange{
+		{FromStatus: domain.OrderStatusDraft, ToStatus: domain.OrderStatusPending, Source: "api", CreatedAt: placed},
+	}
+
+	got := buildTimeline(order, history, nil)
+	if len(got) != 2 || got[0].Description != "Order placed" {
+		t.Errorf("buildTimeline() = %+v, want the placement first on a tie", got)
+	}
+}
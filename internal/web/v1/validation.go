@@ -1,23 +1,141 @@
 package v1
 
-import "strings"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// maxClientErrorLength bounds an error message passed through to clients (bytes)
+const maxClientErrorLength = 100
+
+// internalErrorMarkers are lowercase substrings of validator, binding and decoder errors;
+// a message containing any of them is never shown to clients
+var internalErrorMarkers = []string{"validation", "validator", "key:", "error:", "bind", "unmarshal", "struct"}
+
+// fieldPathPattern matches Go struct field paths such as CreateOrderRequest.Items[0].Quantity
+var fieldPathPattern = regexp.MustCompile(`[A-Za-z_]\w*(\[\d*\])?\.[A-Za-z_]`)
 
 // sanitizeValidationError returns a user-friendly message for validation/binding errors.
-// Never expose raw gin/go validation errors to clients (security + UX).
+// Never expose raw gin/go validation errors to clients (security + UX): only a short, single-line,
+// printable ASCII message without validator markers or struct field paths is passed through.
 func sanitizeValidationError(err error) string {
 	if err == nil {
 		return ""
 	}
 	msg := err.Error()
-	if strings.Contains(msg, "validation") ||
-		strings.Contains(msg, "Field validation") ||
-		strings.Contains(msg, "cannot unmarshal") ||
-		strings.Contains(msg, "bind") ||
-		strings.Contains(msg, "Key:") {
+	if msg == "" || len(msg) >= maxClientErrorLength || fieldPathPattern.MatchString(msg) {
 		return "Invalid request"
 	}
-	if len(msg) < 100 && !strings.Contains(msg, "Error:") {
-		return msg
+	if strings.IndexFunc(msg, func(r rune) bool { return r > unicode.MaxASCII || !unicode.IsPrint(r) }) >= 0 {
+		return "Invalid request"
+	}
+	lower := strings.ToLower(msg)
+	for _, marker := range internalErrorMarkers {
+		if strings.Contains(lower, marker) {
+			return "Invalid request"
+		}
+	}
+	return msg
+}
+
+// bindErrorMessage returns the client message for a failed ShouldBindJSON.
+// Gin decodes with encoding/json before validating, so decode failures surface as the typed
+// json errors and are reported precisely (syntax offset, mistyped field); anything else is a
+// validation failure and goes through sanitizeValidationError.
+func bindErrorMessage(err error) string {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.Is(err, io.EOF):
+		return "request body is empty"
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return "malformed JSON: unexpected end of input"
+	case errors.As(err, &syntaxErr):
+		return fmt.Sprintf("malformed JSON at offset %d", syntaxErr.Offset)
+	case errors.As(err, &typeErr):
+		if typeErr.Field == "" {
+			return "request body must be " + jsonKindName(typeErr.Type)
+		}
+		return fmt.Sprintf("field %s must be %s", typeErr.Field, jsonKindName(typeErr.Type))
+	}
+	if field, ok := unknownJSONField(err); ok {
+		return fmt.Sprintf("unknown field %q", field)
+	}
+	return sanitizeValidationError(err)
+}
+
+// unknownJSONField extracts the field name from the untyped error encoding/json returns
+// when DisallowUnknownFields rejects a field: `json: unknown field "name"`.
+func unknownJSONField(err error) (string, bool) {
+	quoted, ok := strings.CutPrefix(err.Error(), "json: unknown field ")
+	if !ok {
+		return "", false
+	}
+	field, unquoteErr := strconv.Unquote(quoted)
+	return field, unquoteErr == nil
+}
+
+// bindCreateOrderRequest binds the body of order create and quote requests. With StrictJSON,
+// unknown fields at any depth (e.g. a misspelled "prodcutId" in an item) are rejected
+// instead of silently ignored.
+func (cfg HandlerConfig) bindCreateOrderRequest(c *gin.Context, req *domain.CreateOrderRequest) error {
+	if !cfg.StrictJSON {
+		return c.ShouldBindJSON(req)
+	}
+	return c.ShouldBindWith(req, strictJSONBinding{})
+}
+
+// strictJSONBinding is gin's JSON binding with DisallowUnknownFields
+type strictJSONBinding struct{}
+
+func (strictJSONBinding) Name() string {
+	return "json"
+}
+
+func (strictJSONBinding) Bind(req *http.Request, obj any) error {
+	if req == nil || req.Body == nil {
+		return errors.New("invalid request")
+	}
+	dec := json.NewDecoder(req.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(obj); err != nil {
+		return err
+	}
+	return binding.Validator.ValidateStruct(obj)
+}
+
+// jsonKindName describes the JSON value expected for a Go type, e.g. "a number" for int
+func jsonKindName(t reflect.Type) string {
+	if t == nil {
+		return "a valid value"
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Map, reflect.Struct:
+		return "an object"
+	case reflect.Pointer:
+		return jsonKindName(t.Elem())
 	}
-	return "Invalid request"
+	return "a valid value"
 }
@@ -0,0 +1,153 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"github.com/gin-gonic/gin"
+)
+
+func FuzzSanitizeValidationError(f *testing.F) {
+	seeds := []string{
+		"invalid request",
+		"Key: 'CreateOrderRequest.Items[0].Quantity' Error:Field validation for 'Quantity' failed on the 'gt' tag",
+		"json: cannot unmarshal string into Go struct field OrderItem.items.quantity of type int",
+		"KEY: 'x' ERROR: bad",
+		"Validation failed",
+		"order.items[0].price is required",
+		"Items[2].ProductID must be set",
+		"bad\ninput",
+		"héllo",
+		strings.Repeat("a", 99),
+		strings.Repeat("a", 100),
+		"",
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, raw string) {
+		got := sanitizeValidationError(errors.New(raw))
+		if got == "Invalid request" {
+			return
+		}
+
+		if len(got) >= maxClientErrorLength {
+			t.Errorf("sanitizeValidationError(%q) = %d bytes, want < %d", raw, len(got), maxClientErrorLength)
+		}
+		lower := strings.ToLower(got)
+		for _, leak := range []string{"key:", "error:", "validation"} {
+			if strings.Contains(lower, leak) {
+				t.Errorf("sanitizeValidationError(%q) = %q, leaks %q", raw, got, leak)
+			}
+		}
+		if fieldPathPattern.MatchString(got) {
+			t.Errorf("sanitizeValidationError(%q) = %q, leaks a struct field path", raw, got)
+		}
+		if strings.IndexFunc(got, func(r rune) bool { return !unicode.IsPrint(r) }) >= 0 {
+			t.Errorf("sanitizeValidationError(%q) = %q, contains non-printable characters", raw, got)
+		}
+	})
+}
+
+func TestBindCreateOrderRequestStrictJSON(t *testing.T) {
+	const item = `{"product_id": "101", "product_name": "Mug", "quantity": 1, "price": 4}`
+
+	tests := []struct {
+		name        string
+		strict      bool
+		body        string
+		wantUnknown string // "" expects the body to bind
+	}{
+		{name: "Known fields", strict: true, body: `{"items": [` + item + `]}`},
+		{name: "Unknown top-level field", strict: true, body: `{"coupon": "SAVE10", "items": [` + item + `]}`, wantUnknown: "coupon"},
+		{name: "Unknown item field", strict: true, body: `{"items": [{"prodcutId": "101", "product_id": "101", "product_name": "Mug", "quantity": 1, "price": 4}]}`, wantUnknown: "prodcutId"},
+		{name: "Unknown address field", strict: true, body: `{"items": [` + item + `], "shipping_address": {"zip": "10000"}}`, wantUnknown: "zip"},
+		{name: "Unknown fields ignored when strict mode is off", body: `{"coupon": "SAVE10", "items": [{"prodcutId": "1", "product_id": "101", "product_name": "Mug", "quantity": 1, "price": 4}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			var req domain.CreateOrderRequest
+			err := HandlerConfig{StrictJSON: tt.strict}.bindCreateOrderRequest(c, &req)
+			if tt.wantUnknown == "" {
+				if err != nil {
+					t.Fatalf("bindCreateOrderRequest() error = %v", err)
+				}
+				if len(req.Items) != 1 || req.Items[0].ProductID != "101" {
+					t.Errorf("bound items = %+v, want product 101", req.Items)
+				}
+				return
+			}
+			field, ok := unknownJSONField(err)
+			if !ok || field != tt.wantUnknown {
+				t.Fatalf("bindCreateOrderRequest() error = %v, want unknown field %q", err, tt.wantUnknown)
+			}
+			if msg, want := bindErrorMessage(err), `unknown field "`+tt.wantUnknown+`"`; msg != want {
+				t.Errorf("bindErrorMessage() = %q, want %q", msg, want)
+			}
+		})
+	}
+}
+
+func TestUnknownJSONField(t *testing.T) {
+	tests := []struct {
+		err    error
+		want   string
+		wantOK bool
+	}{
+		{err: errors.New(`json: unknown field "coupon"`), want: "coupon", wantOK: true},
+		{err: errors.New(`json: unknown field "with \"quote\""`), want: `with "quote"`, wantOK: true},
+		{err: errors.New(`json: unknown field coupon`)},
+		{err: errors.New(`unexpected EOF`)},
+	}
+
+	for _, tt := range tests {
+		field, ok := unknownJSONField(tt.err)
+		if field != tt.want || ok != tt.wantOK {
+			t.Errorf("unknownJSONField(%q) = %q, %v; want %q, %v", tt.err, field, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestBindErrorMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{name: "Empty body", body: "", want: "request body is empty"},
+		{name: "Truncated JSON", body: `{"items": [`, want: "malformed JSON: unexpected end of input"},
+		{name: "Syntax error", body: `{"items": [}`, want: "malformed JSON at offset 12"},
+		{name: "Mistyped field", body: `{"items": [{"product_id": "101", "quantity": "two"}]}`, want: "field items.0.quantity must be a number"},
+		{name: "Mistyped nested object", body: `{"items": [], "shipping_address": "Hanoi"}`, want: "field shipping_address must be an object"},
+		{name: "Body of the wrong type", body: `["101"]`, want: "request body must be an object"},
+		{name: "Failed validator", body: `{"priority": "express"}`, want: "Invalid request"},
+	}
+
+	for _, tt := range tests {
+		for _, strict := range []bool{false, true} {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			var req domain.CreateOrderRequest
+			err := HandlerConfig{StrictJSON: strict}.bindCreateOrderRequest(c, &req)
+			if err == nil {
+				t.Fatalf("%s (strict %v): bindCreateOrderRequest() error = nil", tt.name, strict)
+			}
+			if got := bindErrorMessage(err); got != tt.want {
+				t.Errorf("%s (strict %v): bindErrorMessage(%v) = %q, want %q", tt.name, strict, err, got, tt.want)
+			}
+		}
+	}
+}
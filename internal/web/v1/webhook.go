@@ -0,0 +1,151 @@
+package v1
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	logicv1 "github.com/duynhne/order-service/internal/logic/v1"
+	"github.com/duynhne/order-service/middleware"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// PaymentSignatureHeader carries the hex HMAC-SHA256 of the raw request body, prefixed with "sha256="
+const PaymentSignatureHeader = "X-Payment-Signature"
+
+// paymentEventSucceeded is the only payment event that changes order state
+const paymentEventSucceeded = "payment.succeeded"
+
+// maxWebhookBodyBytes caps the webhook payload read into memory for signature verification;
+// a larger body is refused with 413 rather than cut and failed as a bad signature
+const maxWebhookBodyBytes = 64 << 10
+
+// PaymentWebhookEvent is the payload sent by the payment provider
+type PaymentWebhookEvent struct {
+	Event     string `json:"event"`
+	OrderID   string `json:"order_id"`
+	PaymentID string `json:"payment_id"`
+}
+
+// PaymentWebhookHandler handles inbound payment provider webhooks
+type PaymentWebhookHandler struct {
+	orderService *logicv1.OrderService
+	secret       []byte
+}
+
+// NewPaymentWebhookHandler creates a webhook handler that verifies requests with the given HMAC secret.
+// An empty secret rejects every request.
+func NewPaymentWebhookHandler(orderService *logicv1.OrderService, secret string) *PaymentWebhookHandler {
+	return &PaymentWebhookHandler{
+		orderService: orderService,
+		secret:       []byte(secret),
+	}
+}
+
+// HandlePayment handles POST /order/v1/public/webhooks/payment
+// Verifies the HMAC signature, then transitions the order from pending to paid (idempotent).
+func (h *PaymentWebhookHandler) HandlePayment(c *gin.Context) {
+	ctx, span := middleware.StartSpan(c.Request.Context(), "http.request", trace.WithAttributes(
+		attribute.String("layer", "web"),
+		attribute.String("method", c.Request.Method),
+		attribute.String("path", c.Request.URL.Path),
+		attribute.String("endpoint.type", "webhook"),
+	))
+	defer span.End()
+
+	zapLogger := middleware.GetLoggerFromGinContext(c)
+
+	// One byte past the limit tells an oversized body from one exactly at it
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxWebhookBodyBytes+1))
+	if err != nil {
+		span.RecordError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	if len(body) > maxWebhookBodyBytes {
+		span.SetAttributes(attribute.Bool("request.too_large", true))
+		zapLogger.Warn("Payment webhook rejected: body too large", zap.Int("limit_bytes", maxWebhookBodyBytes))
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Request body too large"})
+		return
+	}
+
+	if !h.validSignature(body, c.GetHeader(PaymentSignatureHeader)) {
+		span.SetAttributes(attribute.Bool("webhook.signature_valid", false))
+		zapLogger.Warn("Payment webhook rejected: invalid or missing signature")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid signature"})
+		return
+	}
+	span.SetAttributes(attribute.Bool("webhook.signature_valid", true))
+
+	var event PaymentWebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil || event.OrderID == "" {
+		span.SetAttributes(attribute.Bool("request.valid", false))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	span.SetAttributes(
+		attribute.String("order.id", event.OrderID),
+		attribute.String("webhook.event", event.Event),
+	)
+
+	if event.Event != paymentEventSucceeded {
+		zapLogger.Info("Payment webhook event ignored", zap.String("event", event.Event))
+		c.JSON(http.StatusOK, gin.H{"status": "ignored"})
+		return
+	}
+
+	alreadyPaid, err := h.orderService.MarkOrderPaid(ctx, event.OrderID)
+	if err != nil {
+		span.RecordError(err)
+		zapLogger.Error("Failed to mark order paid", zap.Error(err), zap.String("order_id", event.OrderID))
+
+		switch {
+		case errors.Is(err, logicv1.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order ID"})
+		case errors.Is(err, logicv1.ErrOrderNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Order not found"})
+		case errors.Is(err, logicv1.ErrInvalidOrderState):
+			c.JSON(http.StatusConflict, gin.H{"error": "Order cannot be marked as paid"})
+		default:
+			respondInternalError(c, err)
+		}
+		return
+	}
+
+	status := "paid"
+	if alreadyPaid {
+		status = "already_paid"
+	}
+	zapLogger.Info("Payment webhook processed",
+		zap.String("order_id", event.OrderID),
+		zap.String("payment_id", event.PaymentID),
+		zap.Bool("already_paid", alreadyPaid),
+	)
+	c.JSON(http.StatusOK, gin.H{"status": status})
+}
+
+// validSignature checks header "sha256=<hex>" against the HMAC-SHA256 of body in constant time
+func (h *PaymentWebhookHandler) validSignature(body []byte, header string) bool {
+	if len(h.secret) == 0 {
+		return false
+	}
+	sigHex, ok := strings.CutPrefix(header, "sha256=")
+	if !ok {
+		return false
+	}
+	sig, err := hex.DecodeString(sigHex)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, h.secret)
+	mac.Write(body)
+	return hmac.Equal(sig, mac.Sum(nil))
+}
@@ -0,0 +1,78 @@
+package v1
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	logicv1 "github.com/duynhne/order-service/internal/logic/v1"
+	"github.com/gin-gonic/gin"
+)
+
+const testWebhookSecret = "whsec-test"
+
+// signWebhook returns the X-Payment-Signature value for body under testWebhookSecret
+func signWebhook(body string) string {
+	mac := hmac.New(sha256.New, []byte(testWebhookSecret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestHandlePayment(t *testing.T) {
+	paid := `{"event": "payment.succeeded", "order_id": "1", "payment_id": "pay_1"}`
+	oversized := `{"event": "payment.succeeded", "order_id": "1", "padding": "` + strings.Repeat("x", maxWebhookBodyBytes) + `"}`
+
+	tests := []struct {
+		name       string
+		body       string
+		signature  string
+		wantStatus int
+		wantPaid   bool
+	}{
+		{name: "Signed payment", body: paid, signature: signWebhook(paid), wantStatus: http.StatusOK, wantPaid: true},
+		{name: "Missing signature", body: paid, wantStatus: http.StatusUnauthorized},
+		{name: "Bad signature", body: paid, signature: signWebhook(paid + " "), wantStatus: http.StatusUnauthorized},
+		{name: "Oversized body", body: oversized, signature: signWebhook(oversized), wantStatus: http.StatusRequestEntityTooLarge},
+		{
+			name:       "Non-numeric order ID",
+			body:       `{"event": "payment.succeeded", "order_id": "abc"}`,
+			signature:  signWebhook(`{"event": "payment.succeeded", "order_id": "abc"}`),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Unknown order",
+			body:       `{"event": "payment.succeeded", "order_id": "404"}`,
+			signature:  signWebhook(`{"event": "payment.succeeded", "order_id": "404"}`),
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeOrderRepository(domain.Order{ID: "1", UserID: "user1", Status: domain.OrderStatusPending})
+			service := logicv1.NewOrderService(repo, fakeTransactionManager{})
+			router := gin.New()
+			router.POST("/webhooks/payment", NewPaymentWebhookHandler(service, testWebhookSecret).HandlePayment)
+
+			req := httptest.NewRequest(http.MethodPost, "/webhooks/payment", strings.NewReader(tt.body))
+			if tt.signature != "" {
+				req.Header.Set(PaymentSignatureHeader, tt.signature)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body)
+			}
+			order, _ := repo.FindByID(req.Context(), "1")
+			if got := order.Status == domain.OrderStatusPaid; got != tt.wantPaid {
+				t.Errorf("order status = %s, want paid %v", order.Status, tt.wantPaid)
+			}
+		})
+	}
+}
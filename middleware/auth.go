@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/duynhne/order-service/internal/core/domain"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -18,8 +19,15 @@ type AuthUser struct {
 	ID       string `json:"id"`
 	Username string `json:"username"`
 	Email    string `json:"email"`
+	Role     string `json:"role"`
 }
 
+// RoleAdmin is the auth-service role allowed to use admin endpoints
+const RoleAdmin = domain.RoleAdmin
+
+// fallbackUserID is the user of unauthenticated requests in demo mode (AUTH_ALLOW_UNAUTHENTICATED_FALLBACK)
+const fallbackUserID = "1"
+
 // AuthClient handles communication with the auth service
 type AuthClient struct {
 	baseURL    string
@@ -66,16 +74,22 @@ func (c *AuthClient) GetMe(token string) (*AuthUser, error) {
 	return &user, nil
 }
 
+// setAuth attaches the authenticated principal to the request context, where handlers and the
+// service layer read it with domain.AuthFromContext
+func setAuth(c *gin.Context, auth domain.AuthContext) {
+	c.Request = c.Request.WithContext(domain.WithAuthContext(c.Request.Context(), auth))
+}
+
 // AuthMiddleware creates a middleware that validates tokens via auth service
-// It sets "user_id" in the gin context if authentication succeeds.
-// When allowUnauthenticatedFallback is true (demo mode), missing/invalid tokens fall back to user_id="1".
+// It attaches a domain.AuthContext (user ID and role) to the request context if authentication succeeds.
+// When allowUnauthenticatedFallback is true (demo mode), missing/invalid tokens fall back to user "1" without roles.
 // When false (default), returns 401 for missing or invalid tokens.
 func AuthMiddleware(authClient *AuthClient, logger *zap.Logger, allowUnauthenticatedFallback bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
 			if allowUnauthenticatedFallback {
-				c.Set("user_id", "1")
+				setAuth(c, domain.AuthContext{UserID: fallbackUserID})
 				c.Next()
 				return
 			}
@@ -86,7 +100,7 @@ func AuthMiddleware(authClient *AuthClient, logger *zap.Logger, allowUnauthentic
 		const bearerPrefix = "Bearer "
 		if len(authHeader) <= len(bearerPrefix) || authHeader[:len(bearerPrefix)] != bearerPrefix {
 			if allowUnauthenticatedFallback {
-				c.Set("user_id", "1")
+				setAuth(c, domain.AuthContext{UserID: fallbackUserID})
 				c.Next()
 				return
 			}
@@ -101,7 +115,7 @@ func AuthMiddleware(authClient *AuthClient, logger *zap.Logger, allowUnauthentic
 				logger.Debug("Auth validation failed", zap.Error(err))
 			}
 			if allowUnauthenticatedFallback {
-				c.Set("user_id", "1")
+				setAuth(c, domain.AuthContext{UserID: fallbackUserID})
 				c.Next()
 				return
 			}
@@ -109,8 +123,23 @@ func AuthMiddleware(authClient *AuthClient, logger *zap.Logger, allowUnauthentic
 			return
 		}
 
-		c.Set("user_id", user.ID)
-		c.Set("username", user.Username)
+		auth := domain.AuthContext{UserID: user.ID}
+		if user.Role != "" {
+			auth.Roles = []string{user.Role}
+		}
+		setAuth(c, auth)
+		c.Next()
+	}
+}
+
+// RequireRole returns a middleware that rejects requests whose principal (the domain.AuthContext
+// set by AuthMiddleware) does not hold role. Must run after AuthMiddleware.
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if auth, _ := domain.AuthFromContext(c.Request.Context()); !auth.HasRole(role) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+			return
+		}
 		c.Next()
 	}
 }
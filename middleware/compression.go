@@ -0,0 +1,225 @@
+package middleware
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// alreadyCompressedTypes are Content-Type prefixes whose bodies gain nothing from compression
+var alreadyCompressedTypes = []string{
+	"image/", "video/", "audio/",
+	"application/gzip", "application/zip", "application/x-gzip", "application/zstd", "application/octet-stream",
+}
+
+var gzipWriters = sync.Pool{
+	New: func() any { return gzip.NewWriter(io.Discard) },
+}
+
+// CompressionMiddleware compresses response bodies of at least minSize bytes with gzip or deflate,
+// whichever the request's Accept-Encoding prefers (gzip on a tie). Smaller bodies, bodies that
+// already carry a Content-Encoding and already-compressed media types are sent as they are.
+//
+// Up to minSize bytes are buffered to decide; a handler that flushes before that (a stream) gets
+// the buffered bytes sent uncompressed and the rest passed through. Content-Length is dropped from
+// compressed responses. A minSize <= 0 disables the middleware.
+func CompressionMiddleware(minSize int) gin.HandlerFunc {
+	if minSize <= 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return func(c *gin.Context) {
+		// Whether a body is compressed depends on Accept-Encoding, even when this one is not
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"))
+		if encoding == "" || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		w := &compressWriter{ResponseWriter: c.Writer, encoding: encoding, minSize: minSize}
+		c.Writer = w
+		defer func() {
+			w.finish()
+			c.Writer = w.ResponseWriter
+		}()
+		c.Next()
+	}
+}
+
+// negotiateEncoding picks gzip or deflate from an Accept-Encoding header ("" if neither is acceptable)
+func negotiateEncoding(header string) string {
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "gzip" && name != "deflate" {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > bestQ || (q == bestQ && name == "gzip") {
+			best, bestQ = name, q
+		}
+	}
+	if bestQ <= 0 {
+		return ""
+	}
+	return best
+}
+
+// compressWriter buffers the start of a response until it knows whether to compress it
+type compressWriter struct {
+	gin.ResponseWriter
+	encoding string
+	minSize  int
+
+	buf     []byte
+	decided bool
+	written bool // the handler has written or flushed (possibly still buffered here)
+	enc     io.WriteCloser
+	gz      *gzip.Writer // pooled; set when enc is gzip
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	w.written = true
+	if w.decided {
+		if w.enc != nil {
+			return w.enc.Write(b)
+		}
+		return w.ResponseWriter.Write(b)
+	}
+
+	w.buf = append(w.buf, b...)
+	if len(w.buf) >= w.minSize {
+		if err := w.decide(); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController (e.g. SetWriteDeadline)
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// WriteHeaderNow is deferred until the compression decision; the status is kept by WriteHeader
+func (w *compressWriter) WriteHeaderNow() {
+	w.written = true
+}
+
+func (w *compressWriter) Written() bool {
+	return w.written || w.ResponseWriter.Written()
+}
+
+// Flush sends what is buffered so far; an undecided response is committed uncompressed
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		_ = w.passThrough()
+	}
+	if w.gz != nil {
+		_ = w.gz.Flush()
+	} else if f, ok := w.enc.(*flate.Writer); ok {
+		_ = f.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// decide compresses the response if it is large enough and compressible, then writes the headers
+// and the buffered bytes
+func (w *compressWriter) decide() error {
+	if len(w.buf) < w.minSize || !w.compressible() {
+		return w.passThrough()
+	}
+	w.decided = true
+
+	h := w.Header()
+	h.Set("Content-Encoding", w.encoding)
+	h.Del("Content-Length")
+	w.ResponseWriter.WriteHeaderNow()
+
+	if w.encoding == "gzip" {
+		w.gz = gzipWriters.Get().(*gzip.Writer)
+		w.gz.Reset(w.ResponseWriter)
+		w.enc = w.gz
+	} else {
+		fw, err := flate.NewWriter(w.ResponseWriter, flate.DefaultCompression)
+		if err != nil {
+			return err
+		}
+		w.enc = fw
+	}
+
+	buf := w.buf
+	w.buf = nil
+	_, err := w.enc.Write(buf)
+	return err
+}
+
+// passThrough commits the response uncompressed, writing anything buffered
+func (w *compressWriter) passThrough() error {
+	w.decided = true
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		if w.written {
+			w.ResponseWriter.WriteHeaderNow()
+		}
+		return nil
+	}
+	_, err := w.ResponseWriter.Write(buf)
+	return err
+}
+
+// compressible reports whether the response may be compressed, judging by its status and headers
+func (w *compressWriter) compressible() bool {
+	switch status := w.Status(); {
+	case status < 200, status == http.StatusNoContent, status == http.StatusNotModified:
+		return false
+	}
+	h := w.Header()
+	if h.Get("Content-Encoding") != "" {
+		return false
+	}
+	contentType := strings.ToLower(h.Get("Content-Type"))
+	for _, prefix := range alreadyCompressedTypes {
+		if strings.HasPrefix(contentType, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// finish completes the response once the handler returns: small bodies go out as they are,
+// compressed ones have their encoder closed
+func (w *compressWriter) finish() {
+	if !w.decided {
+		_ = w.passThrough()
+		return
+	}
+	if w.enc == nil {
+		return
+	}
+	_ = w.enc.Close()
+	if w.gz != nil {
+		w.gz.Reset(io.Discard)
+		gzipWriters.Put(w.gz)
+	}
+}
@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+const testMinCompressSize = 1024
+
+func TestCompressionMiddleware(t *testing.T) {
+	large := strings.Repeat(`{"id": "1", "status": "pending"}`, 100)
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		body           string
+		wantEncoding   string
+	}{
+		{name: "Small body is sent as is", acceptEncoding: "gzip", body: `{"id": "1"}`},
+		{name: "Large body is gzipped", acceptEncoding: "gzip, deflate", body: large, wantEncoding: "gzip"},
+		{name: "Client without Accept-Encoding", body: large},
+		{name: "Client refusing gzip", acceptEncoding: "gzip;q=0", body: large},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(CompressionMiddleware(testMinCompressSize))
+			router.GET("/orders", func(c *gin.Context) {
+				// A length set up front must not survive compression
+				c.Header("Content-Length", strconv.Itoa(len(tt.body)))
+				c.Data(http.StatusOK, "application/json", []byte(tt.body))
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if got := w.Header().Get("Content-Encoding"); got != tt.wantEncoding {
+				t.Fatalf("Content-Encoding = %q, want %q", got, tt.wantEncoding)
+			}
+			if got := w.Header().Get("Vary"); got != "Accept-Encoding" {
+				t.Errorf("Vary = %q, want Accept-Encoding", got)
+			}
+
+			body := w.Body.String()
+			if tt.wantEncoding == "gzip" {
+				if got := w.Header().Get("Content-Length"); got != "" {
+					t.Errorf("Content-Length = %q on a compressed body, want none", got)
+				}
+				zr, err := gzip.NewReader(w.Body)
+				if err != nil {
+					t.Fatalf("gzip.NewReader() error = %v", err)
+				}
+				raw, err := io.ReadAll(zr)
+				if err != nil {
+					t.Fatalf("read gzip body: %v", err)
+				}
+				body = string(raw)
+			}
+			if body != tt.body {
+				t.Errorf("body = %d bytes, want the %d bytes written", len(body), len(tt.body))
+			}
+		})
+	}
+}
+
+func TestCompressionMiddlewareStreamsFlushedNDJSON(t *testing.T) {
+	resume := make(chan struct{})
+	router := gin.New()
+	router.Use(CompressionMiddleware(testMinCompressSize))
+	router.GET("/export", func(c *gin.Context) {
+		c.Header("Content-Type", "application/x-ndjson")
+		c.Status(http.StatusOK)
+		c.Writer.WriteString(`{"id": "1"}` + "\n")
+		c.Writer.Flush()
+		select {
+		case <-resume:
+		case <-time.After(5 * time.Second):
+		}
+		c.Writer.WriteString(`{"id": "2"}` + "\n")
+		c.Writer.Flush()
+	})
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/export", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET /export error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if got := resp.Header.Get("Content-Encoding"); got != "" {
+		t.Errorf("Content-Encoding = %q on a flushed stream, want none", got)
+	}
+	lines := bufio.NewReader(resp.Body)
+	// The first line arrives while the handler is still blocked: the stream was not held back
+	first, err := lines.ReadString('\n')
+	if err != nil || first != `{"id": "1"}`+"\n" {
+		t.Fatalf("first line = %q, %v", first, err)
+	}
+	close(resume)
+	second, err := lines.ReadString('\n')
+	if err != nil || second != `{"id": "2"}`+"\n" {
+		t.Errorf("second line = %q, %v", second, err)
+	}
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{header: "", want: ""},
+		{header: "gzip", want: "gzip"},
+		{header: "deflate", want: "deflate"},
+		{header: "deflate, gzip", want: "gzip"},
+		{header: "gzip;q=0.5, deflate", want: "deflate"},
+		{header: "gzip;q=0", want: ""},
+		{header: "br, identity", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := negotiateEncoding(tt.header); got != tt.want {
+			t.Errorf("negotiateEncoding(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+// concurrencyLimitRetryAfter is the Retry-After hint (seconds) sent with 503 when all slots are taken
+const concurrencyLimitRetryAfter = "1"
+
+var requestsShed = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "requests_shed_total",
+		Help: "Number of HTTP requests rejected with 503 because MAX_CONCURRENT_REQUESTS was reached",
+	},
+	[]string{"method", "path"},
+)
+
+// ConcurrencyLimitMiddleware caps the number of requests handled at once at limit.
+// A request arriving while every slot is taken is rejected immediately with 503 and Retry-After
+// instead of queueing, so spikes are shed before they pile up goroutines waiting on the DB pool.
+// Infrastructure endpoints (health checks, metrics) bypass the limit so probes keep answering
+// under load. A limit <= 0 disables the middleware.
+func ConcurrencyLimitMiddleware(limit int, logger *zap.Logger) gin.HandlerFunc {
+	if limit <= 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	slots := make(chan struct{}, limit)
+	return func(c *gin.Context) {
+		if !shouldCollectMetrics(c.Request.URL.Path) {
+			c.Next()
+			return
+		}
+
+		select {
+		case slots <- struct{}{}:
+			defer func() { <-slots }()
+			c.Next()
+		default:
+			path := c.FullPath()
+			if path == "" {
+				path = "unknown"
+			}
+			requestsShed.WithLabelValues(c.Request.Method, path).Inc()
+			logger.Warn("Request rejected: concurrency limit reached",
+				zap.String("method", c.Request.Method),
+				zap.String("path", path),
+				zap.Int("limit", limit),
+			)
+			c.Header("Retry-After", concurrencyLimitRetryAfter)
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "Server is busy, please retry"})
+		}
+	}
+}
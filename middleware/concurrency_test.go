@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+func TestConcurrencyLimitMiddleware(t *testing.T) {
+	const limit = 2
+	entered := make(chan struct{})
+	release := make(chan struct{})
+
+	router := gin.New()
+	router.Use(ConcurrencyLimitMiddleware(limit, zap.NewNop()))
+	router.GET("/orders", func(c *gin.Context) {
+		if c.Query("block") != "" {
+			entered <- struct{}{}
+			<-release
+		}
+		c.Status(http.StatusOK)
+	})
+	for _, path := range []string{"/health", "/ready", "/metrics"} {
+		router.GET(path, func(c *gin.Context) { c.Status(http.StatusOK) })
+	}
+	serve := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	// Take every slot with requests that stay in the handler
+	var wg sync.WaitGroup
+	for range limit {
+		wg.Go(func() { serve("/orders?block=1") })
+		<-entered
+	}
+
+	w := serve("/orders")
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("status with every slot taken = %d, want 503", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != concurrencyLimitRetryAfter {
+		t.Errorf("Retry-After = %q, want %q", got, concurrencyLimitRetryAfter)
+	}
+	for _, path := range []string{"/health", "/ready", "/metrics"} {
+		if w := serve(path); w.Code != http.StatusOK {
+			t.Errorf("%s with every slot taken: status = %d, want 200 (exempt)", path, w.Code)
+		}
+	}
+
+	close(release)
+	wg.Wait()
+	if w := serve("/orders"); w.Code != http.StatusOK {
+		t.Errorf("status after the slots were freed = %d, want 200", w.Code)
+	}
+}
+
+func TestConcurrencyLimitMiddlewareDisabled(t *testing.T) {
+	router := gin.New()
+	router.Use(ConcurrencyLimitMiddleware(0, zap.NewNop()))
+	router.GET("/orders", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200", w.Code)
+	}
+}
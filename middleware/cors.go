@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/duynhne/order-service/config"
+	"github.com/gin-gonic/gin"
+)
+
+// corsExposedHeaders are the response headers browser clients need to read beyond the CORS-safelisted ones
+var corsExposedHeaders = strings.Join([]string{
+	"X-Total-Count", "X-Page-Limit", "Link", "Location", "Retry-After", TraceIDHeader,
+}, ", ")
+
+// CORSMiddleware answers requests from the configured browser origins with CORS headers and
+// ends their preflight (OPTIONS with Access-Control-Request-Method) with 204, before routing
+// and authentication. Requests from other origins, and same-origin or non-browser requests
+// without an Origin header, pass through unchanged: without the headers the browser blocks
+// the response. With no allowed origins (the default) the middleware does nothing.
+//
+// Credentials (cookies) are not allowed; the API authenticates with the Authorization header,
+// which a preflight can approve through AllowedHeaders.
+func CORSMiddleware(cfg config.CORSConfig) gin.HandlerFunc {
+	origins := cfg.Origins()
+	if len(origins) == 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+	anyOrigin := slices.Contains(origins, "*")
+	methods := strings.Join(cfg.Methods(), ", ")
+	headers := strings.Join(cfg.Headers(), ", ")
+	maxAge := strconv.Itoa(int(cfg.MaxAge.Seconds()))
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		// The answer depends on Origin even when this request gets no CORS headers
+		c.Writer.Header().Add("Vary", "Origin")
+		if origin == "" || (!anyOrigin && !slices.Contains(origins, origin)) {
+			c.Next()
+			return
+		}
+
+		h := c.Writer.Header()
+		h.Set("Access-Control-Allow-Origin", origin)
+		if anyOrigin {
+			h.Set("Access-Control-Allow-Origin", "*")
+		}
+
+		if c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != "" {
+			h.Set("Access-Control-Allow-Methods", methods)
+			if headers != "" {
+				h.Set("Access-Control-Allow-Headers", headers)
+			}
+			h.Set("Access-Control-Max-Age", maxAge)
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		h.Set("Access-Control-Expose-Headers", corsExposedHeaders)
+		c.Next()
+	}
+}
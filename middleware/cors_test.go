@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/duynhne/order-service/config"
+	"github.com/gin-gonic/gin"
+)
+
+func TestCORSMiddleware(t *testing.T) {
+	shop := config.CORSConfig{
+		AllowedOrigins: "https://shop.example.com, https://admin.example.com",
+		AllowedMethods: "get,post",
+		AllowedHeaders: "Authorization,Content-Type",
+		MaxAge:         10 * time.Minute,
+	}
+	anyOrigin := shop
+	anyOrigin.AllowedOrigins = "*"
+
+	tests := []struct {
+		name        string
+		cfg         config.CORSConfig
+		method      string
+		origin      string
+		wantStatus  int
+		wantOrigin  string
+		wantMethods string
+		wantHeaders string
+		wantMaxAge  string
+		wantExposed bool
+	}{
+		{
+			name:        "Preflight from an allowed origin",
+			cfg:         shop,
+			method:      http.MethodOptions,
+			origin:      "https://shop.example.com",
+			wantStatus:  http.StatusNoContent,
+			wantOrigin:  "https://shop.example.com",
+			wantMethods: "GET, POST",
+			wantHeaders: "Authorization, Content-Type",
+			wantMaxAge:  "600",
+		},
+		{
+			name:        "Request from an allowed origin",
+			cfg:         shop,
+			method:      http.MethodGet,
+			origin:      "https://admin.example.com",
+			wantStatus:  http.StatusOK,
+			wantOrigin:  "https://admin.example.com",
+			wantExposed: true,
+		},
+		{name: "Preflight from another origin", cfg: shop, method: http.MethodOptions, origin: "https://evil.example.com", wantStatus: http.StatusNotFound},
+		{name: "Request from another origin", cfg: shop, method: http.MethodGet, origin: "https://evil.example.com", wantStatus: http.StatusOK},
+		{name: "Request without Origin", cfg: shop, method: http.MethodGet, wantStatus: http.StatusOK},
+		{
+			name:        "Preflight with any origin allowed",
+			cfg:         anyOrigin,
+			method:      http.MethodOptions,
+			origin:      "https://evil.example.com",
+			wantStatus:  http.StatusNoContent,
+			wantOrigin:  "*",
+			wantMethods: "GET, POST",
+			wantHeaders: "Authorization, Content-Type",
+			wantMaxAge:  "600",
+		},
+		{
+			name:        "Request with any origin allowed",
+			cfg:         anyOrigin,
+			method:      http.MethodGet,
+			origin:      "https://evil.example.com",
+			wantStatus:  http.StatusOK,
+			wantOrigin:  "*",
+			wantExposed: true,
+		},
+		{name: "CORS disabled", cfg: config.CORSConfig{}, method: http.MethodGet, origin: "https://shop.example.com", wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(CORSMiddleware(tt.cfg))
+			router.GET("/orders", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			req := httptest.NewRequest(tt.method, "/orders", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			if tt.method == http.MethodOptions {
+				req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			h := w.Header()
+			for header, want := range map[string]string{
+				"Access-Control-Allow-Origin":  tt.wantOrigin,
+				"Access-Control-Allow-Methods": tt.wantMethods,
+				"Access-Control-Allow-Headers": tt.wantHeaders,
+				"Access-Control-Max-Age":       tt.wantMaxAge,
+			} {
+				if got := h.Get(header); got != want {
+					t.Errorf("%s = %q, want %q", header, got, want)
+				}
+			}
+			if got := h.Get("Access-Control-Expose-Headers"); (got != "") != tt.wantExposed {
+				t.Errorf("Access-Control-Expose-Headers = %q, want set: %v", got, tt.wantExposed)
+			}
+		})
+	}
+}
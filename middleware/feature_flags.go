@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"maps"
+	"strings"
+
+	"github.com/duynhne/order-service/internal/core/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// FeatureFlagsHeader lists per-request feature flags, comma-separated: "name" enables a flag and
+// "-name" disables one enabled by default
+const FeatureFlagsHeader = "X-Feature-Flags"
+
+// Bounds on the header so a client cannot make every request carry an arbitrarily large set
+const (
+	maxFeatureFlags      = 32
+	maxFeatureFlagLength = 64
+)
+
+// FeatureFlagsMiddleware stores the request's domain.FeatureFlags in its context, for
+// domain.IsEnabled: the defaults (comma-separated, from FEATURE_FLAGS) adjusted by the
+// X-Feature-Flags header. Flag names are case-insensitive; entries past the first
+// maxFeatureFlags and over-long names are ignored.
+func FeatureFlagsMiddleware(defaults string) gin.HandlerFunc {
+	base := domain.FeatureFlags{}
+	applyFeatureFlags(base, defaults)
+
+	return func(c *gin.Context) {
+		flags := base
+		if header := c.GetHeader(FeatureFlagsHeader); header != "" {
+			flags = maps.Clone(base)
+			applyFeatureFlags(flags, header)
+		}
+		c.Request = c.Request.WithContext(domain.WithFeatureFlags(c.Request.Context(), flags))
+		c.Next()
+	}
+}
+
+// applyFeatureFlags enables ("name") or disables ("-name") each flag of a comma-separated list in flags
+func applyFeatureFlags(flags domain.FeatureFlags, list string) {
+	n := 0
+	for entry := range strings.SplitSeq(list, ",") {
+		name := strings.ToLower(strings.TrimSpace(entry))
+		name, disable := strings.CutPrefix(name, "-")
+		if name == "" || len(name) > maxFeatureFlagLength {
+			continue
+		}
+		if n++; n > maxFeatureFlags {
+			return
+		}
+		if disable {
+			delete(flags, name)
+		} else {
+			flags[name] = true
+		}
+	}
+}
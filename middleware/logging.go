@@ -3,6 +3,7 @@ package middleware
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"fmt"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -63,13 +64,58 @@ func generateTraceID() string {
 	return hex.EncodeToString(b)
 }
 
-// LoggingMiddleware creates a Gin middleware for structured logging with trace-id
-func LoggingMiddleware(logger *zap.Logger) gin.HandlerFunc {
+// RouteLogLevels maps "METHOD /route/pattern" (Gin's FullPath) to the level that route's
+// info logs are written at. Only zapcore.DebugLevel changes anything; see demoteInfoCore.
+type RouteLogLevels map[string]zapcore.Level
+
+// NewRouteLogLevels converts parsed config (config.LoggingConfig.ParseRouteLevels) into RouteLogLevels
+func NewRouteLogLevels(levels map[string]string) (RouteLogLevels, error) {
+	routeLevels := make(RouteLogLevels, len(levels))
+	for route, name := range levels {
+		level, err := zapcore.ParseLevel(name)
+		if err != nil {
+			return nil, fmt.Errorf("route %q: %w", route, err)
+		}
+		routeLevels[route] = level
+	}
+	return routeLevels, nil
+}
+
+// demoteInfoCore rewrites info entries to debug, so with the usual info level they are dropped
+// while warn and error entries pass through unchanged
+type demoteInfoCore struct {
+	zapcore.Core
+}
+
+func (c demoteInfoCore) With(fields []zapcore.Field) zapcore.Core {
+	return demoteInfoCore{c.Core.With(fields)}
+}
+
+func (c demoteInfoCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if ent.Level == zapcore.InfoLevel {
+		ent.Level = zapcore.DebugLevel
+	}
+	return c.Core.Check(ent, ce)
+}
+
+// LoggingMiddleware creates a Gin middleware for structured logging with trace-id.
+// Routes listed in routeLevels at debug have their info logs (the access log and handler
+// success logs) demoted to debug; routeLevels may be nil.
+func LoggingMiddleware(logger *zap.Logger, routeLevels RouteLogLevels) gin.HandlerFunc {
+	demoted := logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return demoteInfoCore{core}
+	}))
+
 	return func(c *gin.Context) {
 		start := time.Now()
 		path := c.Request.URL.Path
 		method := c.Request.Method
 
+		logger := logger
+		if routeLevels[method+" "+c.FullPath()] == zapcore.DebugLevel {
+			logger = demoted
+		}
+
 		// Get or generate trace-id
 		traceID := GetTraceID(c)
 
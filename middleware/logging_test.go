@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLoggingMiddlewareRouteLevels(t *testing.T) {
+	routeLevels, err := NewRouteLogLevels(map[string]string{
+		"GET /orders":     "debug",
+		"GET /orders/:id": "info",
+	})
+	if err != nil {
+		t.Fatalf("NewRouteLogLevels() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		status int
+		want   []string // messages logged at info or above, in order
+	}{
+		{name: "Demoted read", method: http.MethodGet, path: "/orders", status: http.StatusOK, want: nil},
+		{name: "Demoted read keeps warnings and errors", method: http.MethodGet, path: "/orders", status: http.StatusBadGateway,
+			want: []string{"Upstream slow", "HTTP error"}},
+		{name: "Route left at info", method: http.MethodGet, path: "/orders/7", status: http.StatusOK, want: []string{"Handler done", "HTTP request"}},
+		{name: "Same path, other method", method: http.MethodPost, path: "/orders", status: http.StatusCreated, want: []string{"Handler done", "HTTP request"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.InfoLevel)
+			router := gin.New()
+			router.Use(LoggingMiddleware(zap.New(core), routeLevels))
+			handler := func(c *gin.Context) {
+				logger := GetLoggerFromGinContext(c)
+				if tt.status >= 400 {
+					logger.Warn("Upstream slow")
+				} else {
+					logger.Info("Handler done")
+				}
+				c.Status(tt.status)
+			}
+			router.GET("/orders", handler)
+			router.POST("/orders", handler)
+			router.GET("/orders/:id", handler)
+
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tt.method, tt.path, nil))
+
+			var got []string
+			for _, entry := range logs.All() {
+				got = append(got, entry.Message)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("logged %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoggingMiddlewareDemotedRouteAtDebugLevel(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	router := gin.New()
+	router.Use(LoggingMiddleware(zap.New(core), RouteLogLevels{"GET /orders": zapcore.DebugLevel}))
+	router.GET("/orders", func(c *gin.Context) {
+		GetLoggerFromGinContext(c).Info("Orders listed")
+		c.Status(http.StatusOK)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/orders", nil))
+
+	if n := logs.FilterLevelExact(zapcore.DebugLevel).Len(); n != 2 {
+		t.Errorf("debug entries = %d, want the handler and access logs (%v)", n, logs.All())
+	}
+}
+
+func TestNewRouteLogLevelsInvalidLevel(t *testing.T) {
+	if _, err := NewRouteLogLevels(map[string]string{"GET /orders": "loud"}); err == nil {
+		t.Error("NewRouteLogLevels() error = nil, want an error for an unknown level")
+	}
+}
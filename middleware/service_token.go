@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ServiceTokenHeader carries the shared secret internal services present on /order/v1/internal routes
+const ServiceTokenHeader = "X-Service-Token"
+
+// ServiceTokenMiddleware admits only requests whose ServiceTokenHeader equals token, for
+// service-to-service routes that bypass user scoping; anything else gets 401. The comparison is
+// constant-time. An empty token rejects every request, so the routes stay closed until configured.
+// No principal is set: the logic layer treats the call like a trusted background job.
+func ServiceTokenMiddleware(token string, logger *zap.Logger) gin.HandlerFunc {
+	expected := []byte(token)
+	return func(c *gin.Context) {
+		got := []byte(c.GetHeader(ServiceTokenHeader))
+		if len(expected) == 0 || subtle.ConstantTimeCompare(got, expected) != 1 {
+			logger.Warn("Internal request rejected: invalid or missing service token",
+				zap.String("path", c.Request.URL.Path),
+				zap.String("client_ip", c.ClientIP()),
+			)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid service token"})
+			return
+		}
+		c.Next()
+	}
+}
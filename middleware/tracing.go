@@ -43,8 +43,9 @@ func InitTracing(cfg *config.Config) (*sdktrace.TracerProvider, error) {
 	if cfg.Tracing.Endpoint == "" {
 		return nil, errors.New("OTEL_COLLECTOR_ENDPOINT is required when tracing is enabled")
 	}
-	if cfg.Tracing.SampleRate < 0 || cfg.Tracing.SampleRate > 1.0 {
-		return nil, fmt.Errorf("OTEL_SAMPLE_RATE must be between 0.0 and 1.0, got: %.2f", cfg.Tracing.SampleRate)
+	sampler, err := newSampler(cfg.Tracing.Sampler, cfg.Tracing.SampleRate)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create context with timeout for exporter initialization
@@ -79,7 +80,7 @@ func InitTracing(cfg *config.Config) (*sdktrace.TracerProvider, error) {
 	// Create tracer provider with batch export configuration
 	// BatchTimeout: How often to flush spans (default: 5s)
 	// ExportTimeout: Max time to wait for export (default: 30s)
-	// SampleRate: Percentage of traces to sample (10% production, 100% dev)
+	// Sampler: OTEL_TRACES_SAMPLER, by default parent-based with SampleRate (10% production, 100% dev) for root spans
 	tracerProvider = sdktrace.NewTracerProvider(
 		sdktrace.WithBatcher(exporter,
 			sdktrace.WithBatchTimeout(5*time.Second),
@@ -87,7 +88,7 @@ func InitTracing(cfg *config.Config) (*sdktrace.TracerProvider, error) {
 			sdktrace.WithMaxExportBatchSize(cfg.Tracing.MaxExportBatchSize),
 		),
 		sdktrace.WithResource(res),
-		sdktrace.WithSampler(sdktrace.TraceIDRatioBased(cfg.Tracing.SampleRate)),
+		sdktrace.WithSampler(sampler),
 	)
 
 	// Set global tracer provider
@@ -105,6 +106,30 @@ func InitTracing(cfg *config.Config) (*sdktrace.TracerProvider, error) {
 	return tracerProvider, nil
 }
 
+// newSampler builds the sampler named by OTEL_TRACES_SAMPLER; ratio applies to the *traceidratio samplers.
+// Parent-based samplers honor an incoming sampled/unsampled traceparent so traces are never half-recorded
+// across services; the base sampler only decides for root spans.
+func newSampler(name string, ratio float64) (sdktrace.Sampler, error) {
+	if ratio < 0 || ratio > 1.0 {
+		return nil, fmt.Errorf("OTEL_TRACES_SAMPLER_ARG must be between 0.0 and 1.0, got: %.2f", ratio)
+	}
+	switch name {
+	case config.TracesSamplerAlwaysOn:
+		return sdktrace.AlwaysSample(), nil
+	case config.TracesSamplerAlwaysOff:
+		return sdktrace.NeverSample(), nil
+	case config.TracesSamplerTraceIDRatio:
+		return sdktrace.TraceIDRatioBased(ratio), nil
+	case config.TracesSamplerParentBasedAlwaysOn:
+		return sdktrace.ParentBased(sdktrace.AlwaysSample()), nil
+	case config.TracesSamplerParentBasedAlwaysOff:
+		return sdktrace.ParentBased(sdktrace.NeverSample()), nil
+	case config.TracesSamplerParentBasedTraceIDRatio, "":
+		return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio)), nil
+	}
+	return nil, fmt.Errorf("unknown OTEL_TRACES_SAMPLER %q", name)
+}
+
 // shouldTrace determines if a request should be traced based on path
 // Skips health checks, metrics endpoints, and static resources
 func shouldTrace(path string) bool {
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/duynhne/order-service/config"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNewSampler(t *testing.T) {
+	traceID := trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36}
+	parent := func(flags trace.TraceFlags) context.Context {
+		return trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     trace.SpanID{0, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+			TraceFlags: flags,
+			Remote:     true,
+		}))
+	}
+
+	tests := []struct {
+		name    string
+		sampler string
+		ratio   float64
+		// whether a root span, a span under a sampled parent and one under an unsampled parent are sampled
+		wantRoot, wantSampledParent, wantUnsampledParent bool
+		wantErr                                          bool
+	}{
+		{name: "always_on", sampler: config.TracesSamplerAlwaysOn, ratio: 0.5, wantRoot: true, wantSampledParent: true, wantUnsampledParent: true},
+		{name: "always_off", sampler: config.TracesSamplerAlwaysOff, ratio: 0.5},
+		{name: "traceidratio 1", sampler: config.TracesSamplerTraceIDRatio, ratio: 1, wantRoot: true, wantSampledParent: true, wantUnsampledParent: true},
+		{name: "traceidratio 0 ignores the parent", sampler: config.TracesSamplerTraceIDRatio, ratio: 0},
+		{name: "parentbased_always_on", sampler: config.TracesSamplerParentBasedAlwaysOn, ratio: 0, wantRoot: true, wantSampledParent: true},
+		{name: "parentbased_always_off", sampler: config.TracesSamplerParentBasedAlwaysOff, ratio: 1, wantSampledParent: true},
+		{name: "parentbased_traceidratio 0", sampler: config.TracesSamplerParentBasedTraceIDRatio, ratio: 0, wantSampledParent: true},
+		{name: "parentbased_traceidratio 1", sampler: config.TracesSamplerParentBasedTraceIDRatio, ratio: 1, wantRoot: true, wantSampledParent: true},
+		{name: "Unset is parentbased_traceidratio", sampler: "", ratio: 1, wantRoot: true, wantSampledParent: true},
+		{name: "Unknown sampler", sampler: "sometimes", ratio: 0.5, wantErr: true},
+		{name: "Ratio above 1", sampler: config.TracesSamplerTraceIDRatio, ratio: 1.5, wantErr: true},
+		{name: "Negative ratio", sampler: config.TracesSamplerParentBasedTraceIDRatio, ratio: -0.1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sampler, err := newSampler(tt.sampler, tt.ratio)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("newSampler(%q, %v) = %s, want an error", tt.sampler, tt.ratio, sampler.Description())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("newSampler(%q, %v) error = %v", tt.sampler, tt.ratio, err)
+			}
+
+			for _, c := range []struct {
+				parent string
+				ctx    context.Context
+				want   bool
+			}{
+				{parent: "none", ctx: context.Background(), want: tt.wantRoot},
+				{parent: "sampled", ctx: parent(trace.FlagsSampled), want: tt.wantSampledParent},
+				{parent: "unsampled", ctx: parent(0), want: tt.wantUnsampledParent},
+			} {
+				result := sampler.ShouldSample(sdktrace.SamplingParameters{ParentContext: c.ctx, TraceID: traceID, Name: "order.get"})
+				if got := result.Decision == sdktrace.RecordAndSample; got != c.want {
+					t.Errorf("%s with parent %s: sampled = %v, want %v", sampler.Description(), c.parent, got, c.want)
+				}
+			}
+		})
+	}
+}